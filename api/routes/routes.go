@@ -1,12 +1,26 @@
 package routes
 
 import (
+	"context"
+	"fmt"
 	"gin_starter/internal/config"
 	"gin_starter/internal/domain/admin"
 	"gin_starter/internal/domain/blog"
+	"gin_starter/internal/domain/role"
 	"gin_starter/internal/domain/user"
 	"gin_starter/internal/infrastructure/database"
 	"gin_starter/internal/middleware"
+	"gin_starter/internal/websocket"
+	"gin_starter/pkg/health"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/mailer"
+	"gin_starter/pkg/metrics"
+	"gin_starter/pkg/ratelimit"
+	"gin_starter/pkg/rbac"
+	"gin_starter/pkg/revocation"
+	"net/http"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -14,8 +28,17 @@ import (
 )
 
 // SetupRoutes 모든 라우트 설정
-func SetupRoutes(r *gin.Engine, db *database.DB, cfg *config.Config) {
-	// 미들웨어 설정
+// probes는 main에서 만들어 전달 (종료 시 readiness를 503으로 전환하기 위함)
+func SetupRoutes(r *gin.Engine, db *database.DB, hub *websocket.Hub, probes *health.Registry, cfg *config.Config) {
+	// 미들웨어 설정 (요청 ID가 가장 먼저 - 이후 모든 로그에 포함)
+	r.Use(middleware.RequestIDMiddleware())
+
+	// 지표 수집 (Recovery보다 앞에 두어 패닉으로 인한 500도 집계)
+	registry := metrics.NewRegistry()
+	if cfg.Metrics.Enabled {
+		r.Use(middleware.MetricsMiddleware(registry))
+	}
+
 	r.Use(middleware.CORSMiddleware())
 	r.Use(middleware.LoggerMiddleware())
 	r.Use(gin.Recovery())
@@ -23,53 +46,147 @@ func SetupRoutes(r *gin.Engine, db *database.DB, cfg *config.Config) {
 	// Swagger
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// Health check
-	r.GET("/health", healthCheckHandler(db))
+	// Health check (/healthz: liveness, /readyz: readiness, /health: 상세 상태)
+	setupHealthRoutes(r, probes, db, hub, cfg)
+
+	// Prometheus 지표
+	if cfg.Metrics.Enabled {
+		setupMetricsRoutes(r, registry, db, hub, cfg)
+	}
 
 	// 관리자 페이지 라우트
 	setupAdminPageRoutes(r)
 
+	// 역할별 권한 캐시 (AUTH_POLICY_CACHE_TTL마다, 또는 역할 변경 후 DB에서 다시 읽음)
+	policy := rbac.NewPolicy(role.NewPolicyStore(db), cfg.JWT.PolicyCacheTTL)
+
+	// 인증 사용자 권한 정보 캐시 (모든 인증 미들웨어가 공유)
+	principals := middleware.NewPrincipalCache(user.NewPrincipalLoader(user.NewRepository(db), policy), cfg.JWT.PrincipalCacheTTL)
+
+	// 액세스 토큰 폐기 목록 (DB에 저장, AUTH_REVOCATION_SYNC마다 다른 인스턴스의 폐기 반영)
+	revoked := revocation.NewList(user.NewRevocationStore(db), cfg.JWT.RevocationSync)
+	if err := revoked.Load(context.Background()); err != nil {
+		logger.Warn("토큰 폐기 목록 로드 실패: %v", err)
+	}
+
+	// API 키 확인 (블로그, 관리자 API는 로그인 토큰 대신 API 키로도 호출 가능)
+	apiKeys := user.NewAPIKeyVerifier(user.NewRepository(db))
+
+	// 인증, 비밀번호 재설정 메일 발송 (MAIL_DRIVER)
+	mail := newMailer(cfg)
+
+	// 요청 제한 저장소 (RATE_LIMIT_ENABLED=false면 nil - 제한 없음)
+	var limits ratelimit.Store
+	if cfg.RateLimit.Enabled {
+		limits = ratelimit.NewMemoryStore(time.Minute)
+	}
+
 	// API 라우트 그룹
 	api := r.Group("/api")
+	api.Use(middleware.TimeoutMiddleware(cfg.Server.Timeout))
+	api.Use(middleware.RateLimitMiddleware(limits, middleware.RateLimitPolicy{
+		Name:  "api",
+		Limit: ratelimit.PerMinute(cfg.RateLimit.APIPerMin),
+		Key:   middleware.KeyByIP,
+	}))
+	if cfg.Database.ReadYourWrites {
+		api.Use(middleware.ReadYourWritesMiddleware())
+	}
 	{
 		// User 도메인
-		setupUserRoutes(api, db, cfg)
+		setupUserRoutes(api, db, cfg, principals, revoked, mail, limits)
 
 		// Blog 도메인
-		setupBlogRoutes(api, db, cfg)
+		setupBlogRoutes(api, db, cfg, principals, revoked, apiKeys, limits)
 
-		// Admin 도메인 (관리자 전용)
-		setupAdminRoutes(api, db, cfg)
+		// Admin 도메인 (admin:access 권한 필요)
+		setupAdminRoutes(api, db, cfg, policy, principals, revoked, apiKeys, limits)
 	}
+
+	// WebSocket 라우트
+	websocket.SetupWebSocketRoutes(r, hub, cfg, principals, revoked)
+}
+
+// userRateLimit 인증된 사용자당 요청 제한 (AuthMiddleware 뒤에 둔다, API 키 요청은 키마다 따로 계산)
+func userRateLimit(limits ratelimit.Store, cfg *config.Config) gin.HandlerFunc {
+	return middleware.RateLimitMiddleware(limits, middleware.RateLimitPolicy{
+		Name:  "user",
+		Limit: ratelimit.PerMinute(cfg.RateLimit.UserPerMin),
+		Key:   middleware.KeyByAPIKey,
+	})
 }
 
 // setupUserRoutes 사용자 관련 라우트
-func setupUserRoutes(rg *gin.RouterGroup, db *database.DB, cfg *config.Config) {
+func setupUserRoutes(rg *gin.RouterGroup, db *database.DB, cfg *config.Config, principals *middleware.PrincipalCache, revoked *revocation.List, mail mailer.Mailer, limits ratelimit.Store) {
 	// 의존성 주입
 	repo := user.NewRepository(db)
-	service := user.NewService(repo, cfg)
+	service := user.NewService(repo, cfg, revoked, mail, principals)
 	handler := user.NewHandler(service)
 
 	userGroup := rg.Group("/user")
 	{
-		// 인증 불필요한 라우트
-		userGroup.POST("/register", handler.Register)
-		userGroup.POST("/login", handler.Login)
-		userGroup.POST("/refresh", handler.RefreshToken)
+		// 인증 불필요한 라우트 (무차별 대입 방지 - IP당 엄격한 제한)
+		public := userGroup.Group("")
+		public.Use(middleware.RateLimitMiddleware(limits, middleware.RateLimitPolicy{
+			Name:  "auth",
+			Limit: ratelimit.PerMinute(cfg.RateLimit.AuthPerMin),
+			Key:   middleware.KeyByIP,
+		}))
+		{
+			public.POST("/register", handler.Register)
+			public.POST("/login", handler.Login)
+			public.POST("/login/mfa", handler.LoginMFA)
+			public.POST("/refresh", handler.RefreshToken)
+
+			// 비밀번호 재설정, 이메일 인증 (메일로 받은 일회용 토큰)
+			public.POST("/password/forgot", handler.ForgotPassword)
+			public.POST("/password/reset", handler.ResetPassword)
+			public.GET("/email/verify", handler.VerifyEmail)
+			public.POST("/email/verify", handler.VerifyEmail)
+			public.POST("/email/verify/resend", handler.ResendVerification)
+
+			// 소셜 로그인 (제공자 로그인 화면으로 이동, 제공자 콜백)
+			public.GET("/oauth", handler.OAuthProviders)
+			public.GET("/oauth/:provider", handler.OAuthLogin)
+			public.GET("/oauth/:provider/callback", handler.OAuthCallback)
+		}
 
-		// 인증 필요한 라우트
+		// 인증 필요한 라우트 (계정 관리는 로그인 토큰으로만 - API 키는 받지 않음)
 		auth := userGroup.Group("")
-		auth.Use(middleware.AuthMiddleware(cfg))
+		auth.Use(middleware.AuthMiddleware(cfg, principals, revoked, nil))
+		auth.Use(userRateLimit(limits, cfg))
 		{
 			auth.GET("/profile", handler.GetProfile)
 			auth.PUT("/profile", handler.UpdateProfile)
 			auth.POST("/logout", handler.Logout)
+
+			// 기기별 로그인 세션
+			auth.GET("/sessions", handler.GetSessions)
+			auth.DELETE("/sessions", handler.RevokeOtherSessions)
+			auth.DELETE("/sessions/:id", handler.RevokeSession)
+
+			// 2단계 인증 (TOTP)
+			auth.GET("/mfa", handler.GetMFA)
+			auth.POST("/mfa/setup", handler.SetupMFA)
+			auth.POST("/mfa/enable", handler.EnableMFA)
+			auth.POST("/mfa/disable", handler.DisableMFA)
+			auth.POST("/mfa/recovery-codes", handler.RegenerateRecoveryCodes)
+
+			// API 키 (서버 간 호출)
+			auth.GET("/api-keys", handler.GetAPIKeys)
+			auth.POST("/api-keys", middleware.RequirePermission("apikey:create"), handler.CreateAPIKey)
+			auth.DELETE("/api-keys/:id", handler.RevokeAPIKey)
+
+			// 소셜 계정 연결
+			auth.POST("/oauth/:provider/link", handler.OAuthLink)
+			auth.GET("/identities", handler.GetIdentities)
+			auth.DELETE("/identities/:provider", handler.UnlinkIdentity)
 		}
 	}
 }
 
 // setupBlogRoutes 블로그 관련 라우트
-func setupBlogRoutes(rg *gin.RouterGroup, db *database.DB, cfg *config.Config) {
+func setupBlogRoutes(rg *gin.RouterGroup, db *database.DB, cfg *config.Config, principals *middleware.PrincipalCache, revoked *revocation.List, apiKeys middleware.APIKeyVerifier, limits ratelimit.Store) {
 	// 의존성 주입
 	repo := blog.NewRepository(db)
 	service := blog.NewService(repo)
@@ -82,14 +199,43 @@ func setupBlogRoutes(rg *gin.RouterGroup, db *database.DB, cfg *config.Config) {
 		blogGroup.GET("/:id", handler.Get)                        // 상세
 		blogGroup.GET("/author/:author_id", handler.ListByAuthor) // 작성자별 목록
 
-		// 인증 필요한 라우트
+		// 인증 필요한 라우트 (이메일 미인증 계정은 EMAIL_UNVERIFIED_ACCESS에 따라 제한)
+		// 수정, 삭제는 핸들러에서 본인 글(blog:*:own)과 모든 글(blog:*:any) 권한을 구분한다
+		// API 키는 사용 범위에 해당 권한(blog:create, blog:* 등)이 있어야 한다
 		auth := blogGroup.Group("")
-		auth.Use(middleware.AuthMiddleware(cfg))
+		auth.Use(middleware.AuthMiddleware(cfg, principals, revoked, apiKeys))
+		auth.Use(middleware.RequireVerifiedEmail(cfg.Account.UnverifiedAccess))
+		auth.Use(userRateLimit(limits, cfg))
 		{
-			auth.POST("", handler.Create)       // 생성
-			auth.PUT("/:id", handler.Update)    // 수정
-			auth.DELETE("/:id", handler.Delete) // 삭제
+			auth.POST("", middleware.RequirePermission("blog:create"), handler.Create) // 생성
+			auth.PUT("/:id", handler.Update)                                           // 수정
+			auth.DELETE("/:id", handler.Delete)                                        // 삭제
+		}
+	}
+}
+
+// newMailer MAIL_DRIVER에 맞는 메일 발송기 생성
+func newMailer(cfg *config.Config) mailer.Mailer {
+	switch cfg.Mail.Driver {
+	case "smtp":
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.Mail.SMTPHost,
+			Port:     cfg.Mail.SMTPPort,
+			Username: cfg.Mail.SMTPUser,
+			Password: cfg.Mail.SMTPPassword,
+			From:     cfg.Mail.From,
+			Timeout:  cfg.Mail.SMTPTimeout,
+		})
+	case "file":
+		mail, err := mailer.NewFileMailer(cfg.Mail.File)
+		if err != nil {
+			logger.Fatal("MAIL_FILE 설정 오류: %v", err)
 		}
+		return mail
+	case "memory":
+		return mailer.NewMemoryMailer()
+	default:
+		return mailer.NewConsoleMailer()
 	}
 }
 
@@ -108,42 +254,148 @@ func setupAdminPageRoutes(r *gin.Engine) {
 }
 
 // setupAdminRoutes 관리자 API 라우트
-func setupAdminRoutes(rg *gin.RouterGroup, db *database.DB, cfg *config.Config) {
+func setupAdminRoutes(rg *gin.RouterGroup, db *database.DB, cfg *config.Config, policy *rbac.Policy, principals *middleware.PrincipalCache, revoked *revocation.List, apiKeys middleware.APIKeyVerifier, limits ratelimit.Store) {
 	// 의존성 주입
 	userRepo := user.NewRepository(db)
-	service := admin.NewService(userRepo, db)
+	blogRepo := blog.NewRepository(db)
+	service := admin.NewService(userRepo, blogRepo, db, principals, revoked, cfg.JWT.AccessTTL())
 	handler := admin.NewHandler(service)
 
-	// Admin 그룹 (인증 + 관리자 권한 필요)
+	roleService := role.NewService(role.NewRepository(db), userRepo, policy, principals, revoked, cfg.JWT.AccessTTL())
+	roleHandler := role.NewHandler(roleService)
+
+	// Admin 그룹 (인증 + 관리자 API 접근 권한 필요, 라우트별로 세부 권한 확인)
+	// API 키는 사용 범위에 admin:access와 라우트별 권한이 모두 있어야 한다
 	adminGroup := rg.Group("/admin")
-	adminGroup.Use(middleware.AuthMiddleware(cfg))
-	adminGroup.Use(middleware.RequireUserType("A")) // 관리자만
+	adminGroup.Use(middleware.AuthMiddleware(cfg, principals, revoked, apiKeys))
+	adminGroup.Use(middleware.RequirePermission("admin:access"))
+	adminGroup.Use(middleware.RequireMFA(cfg.MFA.RequiredForAdmin))
+	adminGroup.Use(userRateLimit(limits, cfg))
 	{
+		// 사용자 조회
+		read := adminGroup.Group("", middleware.RequirePermission("user:read"))
+		read.GET("/users", handler.GetUsers)                     // 목록
+		read.GET("/users/:id", handler.GetUser)                  // 상세
+		read.GET("/users/:id/sessions", handler.GetUserSessions) // 로그인 세션
+		read.GET("/lockouts", handler.GetLockouts)               // 로그인 잠금 기록 (의심 활동)
+		read.GET("/users/:id/roles", roleHandler.GetUserRoles)   // 역할과 권한
+		read.GET("/users/:id/api-keys", handler.GetUserAPIKeys)  // API 키
+
 		// 사용자 관리
-		adminGroup.GET("/users", handler.GetUsers)                     // 목록
-		adminGroup.GET("/users/:id", handler.GetUser)                  // 상세
-		adminGroup.PUT("/users/:id/auth", handler.UpdateUserAuth)      // 권한 수정
-		adminGroup.DELETE("/users/:id", handler.DeleteUser)            // 삭제
+		manage := adminGroup.Group("", middleware.RequirePermission("user:manage"))
+		manage.POST("/users/:id/unlock", handler.UnlockUser)                 // 로그인 잠금 해제
+		manage.DELETE("/users/:id/mfa", handler.ResetUserMFA)                // 2단계 인증 초기화
+		manage.DELETE("/users/:id/sessions", handler.RevokeUserSessions)     // 모든 세션 종료
+		manage.DELETE("/users/:id/sessions/:sid", handler.RevokeUserSession) // 세션 종료
+		manage.DELETE("/users/:id/api-keys", handler.RevokeUserAPIKeys)      // 모든 API 키 폐기
+		manage.DELETE("/users/:id/api-keys/:kid", handler.RevokeUserAPIKey)  // API 키 폐기
+
+		adminGroup.DELETE("/users/:id", middleware.RequirePermission("user:delete"), handler.DeleteUser) // 삭제
+
+		// 역할, 권한 관리
+		roles := adminGroup.Group("", middleware.RequirePermission("role:manage"))
+		roles.GET("/roles", roleHandler.ListRoles)
+		roles.POST("/roles", roleHandler.CreateRole)
+		roles.GET("/roles/:code", roleHandler.GetRole)
+		roles.PUT("/roles/:code", roleHandler.UpdateRole)
+		roles.DELETE("/roles/:code", roleHandler.DeleteRole)
+		roles.PUT("/roles/:code/permissions", roleHandler.SetPermissions)
+		roles.GET("/permissions", roleHandler.ListPermissions)
+		roles.PUT("/users/:id/roles", roleHandler.SetUserRoles)
+		roles.PUT("/users/:id/auth", handler.UpdateUserAuth) // 대표 역할, 레벨 수정 (역할을 바꾸므로 역할 관리 권한 필요)
 
 		// 통계
-		adminGroup.GET("/stats", handler.GetStats)
+		adminGroup.GET("/stats", middleware.RequirePermission("stats:read"), handler.GetStats)
+	}
+}
+
+// setupMetricsRoutes 지표 수집기 등록 및 /metrics 라우트
+func setupMetricsRoutes(r *gin.Engine, registry *metrics.Registry, db *database.DB, hub *websocket.Hub, cfg *config.Config) {
+	registry.Register(metrics.RuntimeCollector())
+	if db != nil {
+		registry.Register(db)
+	}
+	if hub != nil {
+		registry.Register(hub)
+	}
+
+	r.GET("/metrics",
+		middleware.MetricsAuthMiddleware(cfg.Metrics.Token, cfg.Metrics.AllowIPs),
+		gin.WrapH(metrics.Handler(registry)),
+	)
+}
+
+// setupHealthRoutes liveness/readiness 검사 등록 및 라우트
+func setupHealthRoutes(r *gin.Engine, probes *health.Registry, db *database.DB, hub *websocket.Hub, cfg *config.Config) {
+	// liveness: 외부 의존성 없이 프로세스 내부 상태만
+	if hub != nil {
+		probes.AddLiveness("websocket_hub", hubChecker(hub))
+	}
+
+	// readiness: 트래픽을 받는 데 필요한 의존성
+	if db != nil {
+		probes.AddReadiness("database", health.CheckerFunc(func(ctx context.Context) error {
+			return db.HealthCheck()
+		}))
+
+		// 마이그레이션 파일이 배포된 경우만 버전 확인
+		dir := database.MigrationsDir(cfg.Database.MigrationsDir, db)
+		if _, err := os.Stat(dir); err == nil {
+			check, err := database.NewMigrator(db, dir).HealthChecker()
+			if err != nil {
+				// 파일을 읽지 못하면 검사 대신 로드 에러를 계속 보고
+				check = func(context.Context) error { return err }
+			}
+			probes.AddReadiness("migrations", health.CheckerFunc(check))
+		}
 	}
+	if hub != nil {
+		probes.AddReadiness("websocket_hub", hubChecker(hub))
+	}
+	if cfg.Server.MinFreeDiskMB > 0 {
+		probes.AddReadiness("upload_disk", health.DiskSpace(cfg.Server.UploadDir, uint64(cfg.Server.MinFreeDiskMB)<<20))
+	}
+
+	r.GET("/healthz", gin.WrapH(probes.LivenessHandler()))
+	r.GET("/readyz", gin.WrapH(probes.ReadinessHandler()))
+	r.GET("/health", healthCheckHandler(db, probes))
 }
 
-// healthCheckHandler 헬스 체크 핸들러
-func healthCheckHandler(db *database.DB) gin.HandlerFunc {
+// hubChecker WebSocket Hub 실행 여부 검사
+func hubChecker(hub *websocket.Hub) health.Checker {
+	return health.CheckerFunc(func(ctx context.Context) error {
+		if !hub.IsRunning() {
+			return fmt.Errorf("WebSocket Hub가 실행 중이 아닙니다")
+		}
+		return nil
+	})
+}
+
+// healthCheckHandler 상세 상태 핸들러 (readiness 결과 + 커넥션 풀 부가 정보)
+func healthCheckHandler(db *database.DB, probes *health.Registry) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		report := probes.Readiness(c.Request.Context())
+
 		status := gin.H{
-			"status":   "ok",
+			"status":   report.Status,
+			"checks":   report.Checks,
 			"database": "disconnected",
 		}
 
 		if db != nil {
-			if err := db.HealthCheck(); err == nil {
+			if result, ok := report.Checks["database"]; ok && result.Status == health.StatusOK {
 				status["database"] = "connected"
 			}
+			status["statements"] = db.StmtCacheStats()
+			if replicas := db.ReplicaStatus(); replicas != nil {
+				status["replicas"] = replicas
+			}
 		}
 
-		c.JSON(200, status)
+		code := http.StatusOK
+		if report.Status != health.StatusOK {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, status)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"gin_starter/internal/config"
+	"gin_starter/internal/infrastructure/database"
+	"gin_starter/pkg/logger"
+	"os"
+	"strconv"
+	"time"
+)
+
+const usage = `사용법: migrate [옵션] <명령>
+
+명령:
+  up              미적용 마이그레이션 전체 적용
+  down [N]        최근 마이그레이션 N개 롤백 (기본 1)
+  to <버전>       지정한 버전까지 적용 또는 롤백 (0이면 전체 롤백)
+  status          마이그레이션 적용 상태 출력
+
+옵션:
+`
+
+func main() {
+	dir := flag.String("dir", "", "마이그레이션 파일 디렉토리 (기본: DB_MIGRATIONS_DIR/{드라이버})")
+	dryRun := flag.Bool("dry-run", false, "실행하지 않고 SQL만 출력")
+	lockTimeout := flag.Duration("lock-timeout", 30*time.Second, "마이그레이션 잠금 대기 시간")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// 설정 로드 및 DB 연결
+	cfg := config.Load()
+	if err := logger.Configure(cfg.LoggerConfig()); err != nil {
+		logger.Fatal("로거 설정 실패: %v", err)
+	}
+	defer logger.Close()
+
+	db, err := database.Connect(cfg)
+	if err != nil {
+		logger.Fatal("데이터베이스 연결 실패: %v", err)
+	}
+	defer db.Close()
+
+	if *dir == "" {
+		*dir = database.MigrationsDir(cfg.Database.MigrationsDir, db)
+	}
+
+	migrator := database.NewMigrator(db, *dir)
+	migrator.DryRun = *dryRun
+	migrator.LockTimeout = *lockTimeout
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		exitOnError(err)
+		logger.Info("마이그레이션 %d개 적용 완료", count)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps = parseInt(args[1])
+		}
+		count, err := migrator.Down(ctx, steps)
+		exitOnError(err)
+		logger.Info("마이그레이션 %d개 롤백 완료", count)
+
+	case "to":
+		if len(args) < 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		count, err := migrator.To(ctx, int64(parseInt(args[1])))
+		exitOnError(err)
+		logger.Info("마이그레이션 %d개 처리 완료 (목표 버전: %s)", count, args[1])
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		exitOnError(err)
+		printStatus(statuses)
+
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령: %s\n\n", args[0])
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// printStatus 마이그레이션 상태 표 출력
+func printStatus(statuses []database.MigrationStatus) {
+	fmt.Printf("%-8s %-40s %-10s %s\n", "VERSION", "NAME", "STATUS", "APPLIED AT")
+	for _, s := range statuses {
+		state := "pending"
+		switch {
+		case s.Missing:
+			state = "missing"
+		case s.ChecksumMismatch:
+			state = "modified"
+		case s.Applied:
+			state = "applied"
+		}
+
+		appliedAt := "-"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Printf("%-8d %-40s %-10s %s\n", s.Version, s.Name, state, appliedAt)
+	}
+}
+
+func parseInt(value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "숫자가 아닙니다: %s\n", value)
+		os.Exit(2)
+	}
+	return n
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		logger.Fatal("마이그레이션 실패: %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"gin_starter/internal/config"
 	"gin_starter/internal/infrastructure/database"
 	"gin_starter/internal/websocket"
+	"gin_starter/pkg/health"
 	"gin_starter/pkg/logger"
 	"net/http"
 	"os"
@@ -29,8 +30,12 @@ func main() {
 	// 설정 로드
 	cfg := config.Load()
 
-	// 로그 레벨 설정
-	logger.SetLevelFromString(cfg.App.Environment)
+	// 로거 설정 (LOG_LEVEL, LOG_FORMAT, LOG_FILE) - slog/log 출력도 같은 로거로 기록
+	if err := logger.Configure(cfg.LoggerConfig()); err != nil {
+		logger.Fatal("로거 설정 실패: %v", err)
+	}
+	defer logger.Close()
+	logger.RedirectSlog()
 	logger.Info("🚀 서버 시작 중... (환경: %s)", cfg.App.Environment)
 
 	// Gin 모드 설정
@@ -43,6 +48,16 @@ func main() {
 	}
 	defer db.Close()
 
+	// 마이그레이션 자동 적용 (DB_AUTO_MIGRATE=true, 인메모리 SQLite 등)
+	if cfg.Database.AutoMigrate {
+		migrator := database.NewMigrator(db, database.MigrationsDir(cfg.Database.MigrationsDir, db))
+		count, err := migrator.Up(context.Background())
+		if err != nil {
+			logger.Fatal("마이그레이션 실패: %v", err)
+		}
+		logger.Info("마이그레이션 %d개 적용 완료", count)
+	}
+
 	// WebSocket Hub 생성 및 시작
 	hub := websocket.NewHub()
 	go hub.Run()
@@ -51,11 +66,14 @@ func main() {
 	// Gin 엔진 생성
 	r := gin.New()
 
-	// 라우트 설정
-	routes.SetupRoutes(r, db, cfg)
+	// 프록시 헤더(X-Forwarded-For) 신뢰 범위 - 요청 제한, 로그의 클라이언트 IP 기준
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal("TRUSTED_PROXIES 설정 오류: %v", err)
+	}
 
-	// WebSocket 라우트 설정
-	websocket.SetupWebSocketRoutes(r, hub, cfg)
+	// 라우트 설정 (WebSocket, 헬스 체크 포함)
+	probes := health.NewRegistry(2 * time.Second)
+	routes.SetupRoutes(r, db, hub, probes, cfg)
 
 	// HTTP 서버 설정
 	srv := &http.Server{
@@ -83,6 +101,13 @@ func main() {
 
 	logger.Info("🛑 서버 종료 중...")
 
+	// readiness를 먼저 503으로 바꿔 로드밸런서가 새 트래픽을 보내지 않도록 한 뒤 종료
+	probes.Shutdown()
+	if cfg.Server.ShutdownDelay > 0 {
+		logger.Info("트래픽 정리 대기 중... (%s)", cfg.Server.ShutdownDelay)
+		time.Sleep(cfg.Server.ShutdownDelay)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -91,4 +116,4 @@ func main() {
 	}
 
 	logger.Info("👋 서버가 정상적으로 종료되었습니다")
-}
\ No newline at end of file
+}
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/password"
 	"log"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,20 +16,35 @@ import (
 
 // Config 애플리케이션 전체 설정을 담는 구조체
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	App      AppConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	App       AppConfig
+	Log       LogConfig
+	Metrics   MetricsConfig
+	RateLimit RateLimitConfig
+	Login     LoginConfig
+	Mail      MailConfig
+	Account   AccountConfig
+	MFA       MFAConfig
+	Password  PasswordConfig
+	APIKey    APIKeyConfig
+	OAuth     OAuthConfig
 }
 
 type ServerConfig struct {
-	Port     string
-	GinMode  string
-	Timeout  time.Duration
-	BasePath string
+	Port           string
+	GinMode        string
+	Timeout        time.Duration
+	BasePath       string
+	ShutdownDelay  time.Duration // 종료 시 readiness를 503으로 바꾼 뒤 트래픽이 빠지기를 기다리는 시간
+	UploadDir      string        // 업로드 파일 디렉토리 (readiness 여유 공간 검사 대상)
+	MinFreeDiskMB  int           // 업로드 디렉토리 최소 여유 공간 (0이면 검사 안 함)
+	TrustedProxies []string      // X-Forwarded-For를 신뢰할 프록시 IP/CIDR (비우면 직접 연결한 주소만 사용)
 }
 
 type DatabaseConfig struct {
+	Driver          string // mysql, sqlite
 	Host            string
 	Port            string
 	User            string
@@ -34,21 +53,142 @@ type DatabaseConfig struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+	QueryTimeout    time.Duration // 쿼리 1건당 최대 실행 시간 (0이면 요청 컨텍스트만 따름)
+	TxIsolation     string        // 트랜잭션 기본 격리 수준 (read_committed, repeatable_read, serializable)
+	StmtCacheSize   int           // prepared statement 캐시 크기 (0이면 미사용)
+	AutoMigrate     bool          // 서버 시작 시 마이그레이션 자동 적용
+	MigrationsDir   string        // 마이그레이션 루트 디렉토리 (하위에 드라이버별 디렉토리)
+
+	Replicas             []string      // 읽기 복제본 (DSN 또는 MySQL host:port, SQLite 파일 경로)
+	ReplicaCheckInterval time.Duration // 복제본 헬스 체크 주기
+	ReplicaMaxLag        time.Duration // 허용 복제 지연 (초과 시 읽기 대상에서 제외, 0이면 검사 안 함)
+	ReadYourWrites       bool          // 요청 안에서 쓰기 이후 읽기는 primary로 보냄
 }
 
 type JWTConfig struct {
-	AccessSecret       []byte
-	RefreshSecret      []byte
-	TokenSecret        []byte
-	AccessExpireMin    int
-	RefreshExpireDays  int
-	RefreshReuseHours  int // 리프레시 토큰 재사용 기준 시간
+	AccessKeys        Keyring // 액세스 토큰 서명 키
+	RefreshKeys       Keyring // 리프레시 토큰 서명 키
+	TokenKeys         Keyring // 토큰 내용 암호화 키
+	AccessExpireMin   int
+	RefreshExpireDays int
+	PrincipalCacheTTL time.Duration // 사용자 권한 정보 캐시 유지 시간
+	PolicyCacheTTL    time.Duration // 역할별 권한 캐시 유지 시간 (다른 서버의 역할 변경 반영)
+	RevocationSync    time.Duration // 토큰 폐기 목록을 DB에서 다시 읽는 주기 (다른 인스턴스의 로그아웃 반영)
+}
+
+// AccessTTL 액세스 토큰 유효 기간
+func (j JWTConfig) AccessTTL() time.Duration {
+	return time.Duration(j.AccessExpireMin) * time.Minute
+}
+
+type LogConfig struct {
+	Level          string // debug, info, warn, error (기본: GIN_MODE가 debug면 debug, 아니면 info)
+	Format         string // console, json (기본: GIN_MODE가 debug면 console, 아니면 json)
+	File           string // 로그 파일 경로 (비우면 stdout만)
+	FileMaxSizeMB  int    // 로그 파일 교체 기준 크기
+	FileMaxBackups int    // 보관할 이전 로그 파일 개수
+}
+
+type MetricsConfig struct {
+	Enabled  bool     // /metrics 엔드포인트와 요청 지표 수집 사용
+	Token    string   // 조회 토큰 (Authorization: Bearer)
+	AllowIPs []string // 조회 허용 IP/CIDR (토큰과 둘 다 비우면 제한 없음)
+}
+
+type RateLimitConfig struct {
+	Enabled    bool // 요청 제한 사용
+	AuthPerMin int  // 로그인/가입/토큰 갱신 IP당 분당 요청 수
+	APIPerMin  int  // /api 전체 IP당 분당 요청 수
+	UserPerMin int  // 인증된 사용자당 분당 요청 수 (API 키 요청은 키마다)
+}
+
+type LoginConfig struct {
+	MaxFailures   int           // 계정 잠금까지 허용하는 연속 실패 횟수 (0이면 잠금 안 함)
+	LockDuration  time.Duration // 계정/IP 잠금 시간
+	BackoffBase   time.Duration // 실패 후 다음 시도까지 대기 시간 (실패할 때마다 2배, 0이면 대기 없음)
+	BackoffMax    time.Duration // 대기 시간 상한
+	IPMaxFailures int           // IP 잠금까지 허용하는 실패 횟수 (IPWindow 안에서, 0이면 잠금 안 함)
+	IPWindow      time.Duration // IP 실패 횟수를 세는 기간
+}
+
+type MailConfig struct {
+	Driver       string // smtp, console, file, memory
+	From         string // 보내는 사람 주소
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string // 비우면 인증 없이 보냄
+	SMTPPassword string
+	SMTPTimeout  time.Duration
+	File         string // file 드라이버가 메일 내용을 기록할 파일
+}
+
+// 이메일 미인증 계정의 이용 범위
+const (
+	UnverifiedFull    = "full"    // 제한 없음 (인증은 선택)
+	UnverifiedLimited = "limited" // 로그인과 조회만 가능, 글 작성 등 쓰기 기능 제한
+	UnverifiedNone    = "none"    // 인증 전에는 로그인 불가
+)
+
+type AccountConfig struct {
+	UnverifiedAccess string        // 이메일 미인증 계정의 이용 범위 (full, limited, none)
+	VerifyTTL        time.Duration // 이메일 인증 링크 유효 시간
+	ResetTTL         time.Duration // 비밀번호 재설정 링크 유효 시간
+	VerifyURL        string        // 인증 메일의 링크 주소 (?token= 붙여서 보냄)
+	ResetURL         string        // 재설정 메일의 링크 주소 (프론트엔드 페이지, 비우면 토큰만 보냄)
+}
+
+type MFAConfig struct {
+	RequiredForAdmin bool          // 관리자(u_auth_type=A)는 2단계 인증을 설정해야 /api/admin 사용 가능
+	Issuer           string        // 인증 앱에 표시할 서비스 이름
+	LoginTTL         time.Duration // 비밀번호 확인 후 2단계 인증 코드를 입력할 수 있는 시간
+	RecoveryCodes    int           // 발급할 복구 코드 개수
+}
+
+type PasswordConfig struct {
+	MinLength        int      // 최소 글자 수
+	MaxLength        int      // 최대 글자 수
+	MinClasses       int      // 소문자, 대문자, 숫자, 특수문자 중 최소 종류 수
+	DisallowIdentity bool     // 아이디, 이메일 포함 금지
+	Blocklist        []string // PASSWORD_BLOCKLIST_FILE의 금지 비밀번호 (내장 목록에 추가)
+	History          int      // 재사용 금지할 최근 비밀번호 수 (0이면 검사 안 함)
+
+	Algorithm     string // 해싱 알고리즘 (bcrypt, argon2id) - 바꾸면 로그인할 때 다시 해싱
+	BcryptCost    int
+	Argon2Memory  int // KiB
+	Argon2Time    int
+	Argon2Threads int
+}
+
+type APIKeyConfig struct {
+	MaxPerUser int           // 사용자당 유효한 API 키 최대 개수
+	MaxTTL     time.Duration // API 키 최대 유효 기간 (0이면 만료 없는 키 허용)
+}
+
+type OAuthConfig struct {
+	Providers    []OAuthProviderConfig // OAUTH_PROVIDERS 순서
+	StateTTL     time.Duration         // 로그인 시작 후 제공자 콜백까지 허용 시간
+	AutoRegister bool                  // 연결된 계정이 없으면 새 계정 생성
+	LinkByEmail  bool                  // 제공자와 우리 쪽 모두 인증된 같은 이메일이면 기존 계정에 자동 연결
+}
+
+type OAuthProviderConfig struct {
+	Name         string // 라우트와 OAUTH_<NAME>_* 환경변수에 쓰는 이름 (google, keycloak 등)
+	ClientID     string
+	ClientSecret string
+	Issuer       string   // OIDC issuer (비어 있지 않으면 디스커버리로 나머지 주소를 채움)
+	AuthURL      string   // 인가 주소 (issuer가 없을 때 필수)
+	TokenURL     string   // 토큰 주소 (issuer가 없을 때 필수)
+	UserInfoURL  string   // 사용자 정보 주소 (ID 토큰이 없는 제공자)
+	JWKSURL      string   // ID 토큰 서명 키 주소
+	RedirectURL  string   // 콜백 주소 (기본: APP_URL/api/user/oauth/<name>/callback)
+	Scopes       []string // 요청 범위 (기본: openid, email, profile)
 }
 
 type AppConfig struct {
 	ServiceName string
 	Environment string
 	Debug       bool
+	BaseURL     string // 외부에서 접속하는 서버 주소 (메일 링크 등)
 }
 
 var (
@@ -56,6 +196,9 @@ var (
 	once     sync.Once
 )
 
+// oauthNamePattern OAuth 제공자 이름 형식 (라우트 경로, _user_identities.ui_provider에 사용)
+var oauthNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
 // Load .env 파일을 로드하고 설정을 초기화
 func Load() *Config {
 	once.Do(func() {
@@ -65,11 +208,21 @@ func Load() *Config {
 		}
 
 		instance = &Config{
-			Server:   loadServerConfig(),
-			Database: loadDatabaseConfig(),
-			JWT:      loadJWTConfig(),
-			App:      loadAppConfig(),
+			Server:    loadServerConfig(),
+			Database:  loadDatabaseConfig(),
+			JWT:       loadJWTConfig(),
+			App:       loadAppConfig(),
+			Log:       loadLogConfig(),
+			Metrics:   loadMetricsConfig(),
+			RateLimit: loadRateLimitConfig(),
+			Login:     loadLoginConfig(),
+			Mail:      loadMailConfig(),
 		}
+		instance.Account = loadAccountConfig(instance.App.BaseURL)
+		instance.MFA = loadMFAConfig(instance.App.ServiceName)
+		instance.Password = loadPasswordConfig()
+		instance.APIKey = loadAPIKeyConfig()
+		instance.OAuth = loadOAuthConfig(instance.App.BaseURL)
 
 		// 필수 값 검증
 		instance.validate()
@@ -91,15 +244,20 @@ func loadServerConfig() ServerConfig {
 	timeout := getEnvAsInt("SERVER_TIMEOUT", 30)
 
 	return ServerConfig{
-		Port:     port,
-		GinMode:  ginMode,
-		Timeout:  time.Duration(timeout) * time.Second,
-		BasePath: "/",
+		Port:           port,
+		GinMode:        ginMode,
+		Timeout:        time.Duration(timeout) * time.Second,
+		BasePath:       "/",
+		ShutdownDelay:  time.Duration(getEnvAsInt("SERVER_SHUTDOWN_DELAY", 5)) * time.Second,
+		UploadDir:      getEnv("UPLOAD_DIR", "./uploads"),
+		MinFreeDiskMB:  getEnvAsInt("HEALTH_MIN_FREE_DISK_MB", 100),
+		TrustedProxies: getEnvAsList("TRUSTED_PROXIES"),
 	}
 }
 
 func loadDatabaseConfig() DatabaseConfig {
 	return DatabaseConfig{
+		Driver:          strings.ToLower(getEnv("DB_DRIVER", "mysql")),
 		Host:            getEnv("DB_HOST", "localhost"),
 		Port:            getEnv("DB_PORT", "3306"),
 		User:            getEnv("DB_USER", "root"),
@@ -108,26 +266,34 @@ func loadDatabaseConfig() DatabaseConfig {
 		MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
 		MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
 		ConnMaxLifetime: time.Duration(getEnvAsInt("DB_CONN_MAX_LIFETIME", 5)) * time.Minute,
+		QueryTimeout:    time.Duration(getEnvAsInt("DB_QUERY_TIMEOUT", 10)) * time.Second,
+		TxIsolation:     getEnv("DB_TX_ISOLATION", ""),
+		StmtCacheSize:   getEnvAsInt("DB_STMT_CACHE_SIZE", 100),
+		AutoMigrate:     getEnvAsBool("DB_AUTO_MIGRATE", false),
+		MigrationsDir:   getEnv("DB_MIGRATIONS_DIR", "migrations"),
+
+		Replicas:             getEnvAsList("DB_REPLICAS"),
+		ReplicaCheckInterval: time.Duration(getEnvAsInt("DB_REPLICA_CHECK_INTERVAL", 5)) * time.Second,
+		ReplicaMaxLag:        time.Duration(getEnvAsInt("DB_REPLICA_MAX_LAG", 10)) * time.Second,
+		ReadYourWrites:       getEnvAsBool("DB_READ_YOUR_WRITES", true),
 	}
 }
 
 func loadJWTConfig() JWTConfig {
-	accessSecret := getEnv("JWT_SECRET", "")
-	refreshSecret := getEnv("JWT_REFRESH_SECRET", "")
-	tokenSecret := getEnv("JWT_TOKEN_SECRET", "")
-
-	// 32바이트 검증
-	if len(accessSecret) != 32 || len(refreshSecret) != 32 || len(tokenSecret) != 32 {
-		log.Fatal("❌ JWT_SECRET, JWT_REFRESH_SECRET, JWT_TOKEN_SECRET는 각각 32자여야 합니다")
-	}
+	// 키 교체: 새 키를 JWT_PREVIOUS_KEY_IDS로 먼저 배포(검증만) -> JWT_KEY_ID를 새 키로 전환 ->
+	// 이전 키로 만든 토큰이 모두 만료되면 JWT_RETIRED_KEY_IDS로 옮기거나 제거
+	// 키 모음별로 따로 교체하려면 JWT_ACCESS_*, JWT_REFRESH_*, JWT_TOKEN_* 키 ID를 설정 (비우면 공통 값 사용)
+	shared := loadKeyIDs("JWT", keyIDs{Primary: "default"})
 
 	return JWTConfig{
-		AccessSecret:      []byte(accessSecret),
-		RefreshSecret:     []byte(refreshSecret),
-		TokenSecret:       []byte(tokenSecret),
+		AccessKeys:        loadKeyring("JWT_SECRET", loadKeyIDs("JWT_ACCESS", shared)),
+		RefreshKeys:       loadKeyring("JWT_REFRESH_SECRET", loadKeyIDs("JWT_REFRESH", shared)),
+		TokenKeys:         loadKeyring("JWT_TOKEN_SECRET", loadKeyIDs("JWT_TOKEN", shared)),
 		AccessExpireMin:   getEnvAsInt("JWT_EXPIRES_IN", 30),
 		RefreshExpireDays: getEnvAsInt("JWT_EXPIRES_RE", 7),
-		RefreshReuseHours: 24, // 리프레시 토큰 24시간 이상 남으면 재사용
+		PrincipalCacheTTL: time.Duration(getEnvAsInt("AUTH_CACHE_TTL", 30)) * time.Second,
+		PolicyCacheTTL:    time.Duration(getEnvAsInt("AUTH_POLICY_CACHE_TTL", 60)) * time.Second,
+		RevocationSync:    time.Duration(getEnvAsInt("AUTH_REVOCATION_SYNC", 10)) * time.Second,
 	}
 }
 
@@ -139,7 +305,149 @@ func loadAppConfig() AppConfig {
 		ServiceName: getEnv("SERVICE_NAME", "GinStarter"),
 		Environment: ginMode,
 		Debug:       debug,
+		BaseURL:     strings.TrimSuffix(getEnv("APP_URL", "http://localhost:"+getEnv("PORT", "8080")), "/"),
+	}
+}
+
+func loadLogConfig() LogConfig {
+	level, format := "info", "json"
+	if getEnv("GIN_MODE", "debug") == "debug" {
+		level, format = "debug", "console"
+	}
+
+	return LogConfig{
+		Level:          strings.ToLower(getEnv("LOG_LEVEL", level)),
+		Format:         strings.ToLower(getEnv("LOG_FORMAT", format)),
+		File:           getEnv("LOG_FILE", ""),
+		FileMaxSizeMB:  getEnvAsInt("LOG_FILE_MAX_SIZE", 100),
+		FileMaxBackups: getEnvAsInt("LOG_FILE_MAX_BACKUPS", 5),
+	}
+}
+
+func loadMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		Enabled:  getEnvAsBool("METRICS_ENABLED", true),
+		Token:    getEnv("METRICS_TOKEN", ""),
+		AllowIPs: getEnvAsList("METRICS_ALLOW_IPS"),
+	}
+}
+
+func loadRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Enabled:    getEnvAsBool("RATE_LIMIT_ENABLED", true),
+		AuthPerMin: getEnvAsInt("RATE_LIMIT_AUTH_PER_MIN", 10),
+		APIPerMin:  getEnvAsInt("RATE_LIMIT_API_PER_MIN", 120),
+		UserPerMin: getEnvAsInt("RATE_LIMIT_USER_PER_MIN", 300),
+	}
+}
+
+func loadLoginConfig() LoginConfig {
+	return LoginConfig{
+		MaxFailures:   getEnvAsInt("LOGIN_MAX_FAILURES", 5),
+		LockDuration:  time.Duration(getEnvAsInt("LOGIN_LOCK_MINUTES", 15)) * time.Minute,
+		BackoffBase:   time.Duration(getEnvAsInt("LOGIN_BACKOFF_BASE", 1)) * time.Second,
+		BackoffMax:    time.Duration(getEnvAsInt("LOGIN_BACKOFF_MAX", 30)) * time.Second,
+		IPMaxFailures: getEnvAsInt("LOGIN_IP_MAX_FAILURES", 20),
+		IPWindow:      time.Duration(getEnvAsInt("LOGIN_IP_WINDOW", 15)) * time.Minute,
+	}
+}
+
+func loadMailConfig() MailConfig {
+	return MailConfig{
+		Driver:       strings.ToLower(getEnv("MAIL_DRIVER", "console")),
+		From:         getEnv("MAIL_FROM", "no-reply@localhost"),
+		SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUser:     getEnv("SMTP_USER", ""),
+		SMTPPassword: getEnv("SMTP_PASS", ""),
+		SMTPTimeout:  time.Duration(getEnvAsInt("SMTP_TIMEOUT", 10)) * time.Second,
+		File:         getEnv("MAIL_FILE", "./mail.log"),
+	}
+}
+
+func loadAccountConfig(baseURL string) AccountConfig {
+	return AccountConfig{
+		UnverifiedAccess: strings.ToLower(getEnv("EMAIL_UNVERIFIED_ACCESS", UnverifiedLimited)),
+		VerifyTTL:        time.Duration(getEnvAsInt("EMAIL_VERIFY_TTL", 24)) * time.Hour,
+		ResetTTL:         time.Duration(getEnvAsInt("PASSWORD_RESET_TTL", 30)) * time.Minute,
+		VerifyURL:        getEnv("EMAIL_VERIFY_URL", baseURL+"/api/user/email/verify"),
+		ResetURL:         getEnv("PASSWORD_RESET_URL", ""),
+	}
+}
+
+func loadMFAConfig(serviceName string) MFAConfig {
+	return MFAConfig{
+		RequiredForAdmin: getEnvAsBool("MFA_REQUIRED_FOR_ADMIN", false),
+		Issuer:           getEnv("MFA_ISSUER", serviceName),
+		LoginTTL:         time.Duration(getEnvAsInt("MFA_LOGIN_TTL", 5)) * time.Minute,
+		RecoveryCodes:    getEnvAsInt("MFA_RECOVERY_CODES", 10),
+	}
+}
+
+func loadPasswordConfig() PasswordConfig {
+	cfg := PasswordConfig{
+		MinLength:        getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+		MaxLength:        getEnvAsInt("PASSWORD_MAX_LENGTH", 64),
+		MinClasses:       getEnvAsInt("PASSWORD_MIN_CLASSES", 2),
+		DisallowIdentity: getEnvAsBool("PASSWORD_DISALLOW_IDENTITY", true),
+		History:          getEnvAsInt("PASSWORD_HISTORY", 3),
+		Algorithm:        strings.ToLower(getEnv("PASSWORD_HASH", password.AlgBcrypt)),
+		BcryptCost:       getEnvAsInt("BCRYPT_COST", 10),
+		Argon2Memory:     getEnvAsInt("ARGON2_MEMORY", int(password.DefaultArgon2Params.Memory)),
+		Argon2Time:       getEnvAsInt("ARGON2_TIME", int(password.DefaultArgon2Params.Time)),
+		Argon2Threads:    getEnvAsInt("ARGON2_THREADS", int(password.DefaultArgon2Params.Threads)),
+	}
+
+	if path := getEnv("PASSWORD_BLOCKLIST_FILE", ""); path != "" {
+		words, err := password.LoadBlocklist(path)
+		if err != nil {
+			log.Fatalf("❌ PASSWORD_BLOCKLIST_FILE을 읽을 수 없습니다: %v", err)
+		}
+		cfg.Blocklist = words
+	}
+	return cfg
+}
+
+func loadAPIKeyConfig() APIKeyConfig {
+	return APIKeyConfig{
+		MaxPerUser: getEnvAsInt("API_KEY_MAX_PER_USER", 10),
+		MaxTTL:     time.Duration(getEnvAsInt("API_KEY_MAX_TTL_DAYS", 0)) * 24 * time.Hour,
+	}
+}
+
+func loadOAuthConfig(baseURL string) OAuthConfig {
+	cfg := OAuthConfig{
+		StateTTL:     time.Duration(getEnvAsInt("OAUTH_STATE_TTL", 10)) * time.Minute,
+		AutoRegister: getEnvAsBool("OAUTH_AUTO_REGISTER", true),
+		LinkByEmail:  getEnvAsBool("OAUTH_LINK_BY_EMAIL", true),
 	}
+
+	// 제공자별 설정은 OAUTH_<NAME>_CLIENT_ID 형식 (JWT_SECRET_<KID>와 같은 방식)
+	for _, name := range getEnvAsList("OAUTH_PROVIDERS") {
+		name = strings.ToLower(name)
+		if !oauthNamePattern.MatchString(name) {
+			log.Fatalf("❌ 잘못된 OAuth 제공자 이름입니다: %q (영문 소문자로 시작, 소문자, 숫자, _ 20자 이하)", name)
+		}
+
+		prefix := "OAUTH_" + strings.ToUpper(name) + "_"
+		scopes := getEnvAsList(prefix + "SCOPES")
+		if len(scopes) == 0 {
+			scopes = []string{"openid", "email", "profile"}
+		}
+		cfg.Providers = append(cfg.Providers, OAuthProviderConfig{
+			Name:         name,
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			AuthURL:      getEnv(prefix+"AUTH_URL", ""),
+			TokenURL:     getEnv(prefix+"TOKEN_URL", ""),
+			UserInfoURL:  getEnv(prefix+"USERINFO_URL", ""),
+			JWKSURL:      getEnv(prefix+"JWKS_URL", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", baseURL+"/api/user/oauth/"+name+"/callback"),
+			Scopes:       scopes,
+		})
+	}
+	return cfg
 }
 
 // validate 필수 설정값 검증
@@ -147,8 +455,94 @@ func (c *Config) validate() {
 	if c.Database.Database == "" {
 		log.Fatal("❌ DB_NAME이 설정되지 않았습니다")
 	}
-	if c.Database.Password == "" {
-		log.Println("⚠️  DB_PASS가 비어있습니다")
+
+	switch c.Database.Driver {
+	case "mysql":
+		if c.Database.Password == "" {
+			log.Println("⚠️  DB_PASS가 비어있습니다")
+		}
+	case "sqlite":
+		// DB_NAME은 파일 경로 또는 :memory:
+	default:
+		log.Fatalf("❌ 지원하지 않는 DB_DRIVER입니다: %s (mysql, sqlite)", c.Database.Driver)
+	}
+
+	switch c.Mail.Driver {
+	case "smtp", "console", "file", "memory":
+	default:
+		log.Fatalf("❌ 지원하지 않는 MAIL_DRIVER입니다: %s (smtp, console, file, memory)", c.Mail.Driver)
+	}
+
+	switch c.Account.UnverifiedAccess {
+	case UnverifiedFull, UnverifiedLimited, UnverifiedNone:
+	default:
+		log.Fatalf("❌ 잘못된 EMAIL_UNVERIFIED_ACCESS입니다: %s (full, limited, none)", c.Account.UnverifiedAccess)
+	}
+
+	if c.MFA.RecoveryCodes < 1 || c.MFA.RecoveryCodes > 50 {
+		log.Fatalf("❌ MFA_RECOVERY_CODES는 1~50 사이여야 합니다: %d", c.MFA.RecoveryCodes)
+	}
+
+	if c.APIKey.MaxPerUser < 1 {
+		log.Fatalf("❌ API_KEY_MAX_PER_USER는 1 이상이어야 합니다: %d", c.APIKey.MaxPerUser)
+	}
+
+	c.validatePassword()
+	c.validateOAuth()
+}
+
+// validateOAuth 소셜 로그인 제공자 설정 검증
+func (c *Config) validateOAuth() {
+	seen := make(map[string]bool)
+	for _, p := range c.OAuth.Providers {
+		prefix := "OAUTH_" + strings.ToUpper(p.Name) + "_"
+		if seen[p.Name] {
+			log.Fatalf("❌ OAUTH_PROVIDERS에 같은 제공자가 두 번 있습니다: %s", p.Name)
+		}
+		seen[p.Name] = true
+
+		if p.ClientID == "" {
+			log.Fatalf("❌ %sCLIENT_ID가 설정되지 않았습니다", prefix)
+		}
+		if p.Issuer == "" && (p.AuthURL == "" || p.TokenURL == "") {
+			log.Fatalf("❌ %sISSUER 또는 %sAUTH_URL, %sTOKEN_URL을 설정해야 합니다", prefix, prefix, prefix)
+		}
+		if p.Issuer == "" && p.UserInfoURL == "" {
+			log.Fatalf("❌ %sISSUER가 없으면 %sUSERINFO_URL이 필요합니다 (ID 토큰의 iss를 확인할 수 없음)", prefix, prefix)
+		}
+	}
+
+	if len(c.OAuth.Providers) > 0 && c.OAuth.StateTTL <= 0 {
+		log.Fatalf("❌ OAUTH_STATE_TTL은 1 이상이어야 합니다")
+	}
+}
+
+// validatePassword 비밀번호 정책, 해싱 설정 검증
+func (c *Config) validatePassword() {
+	p := c.Password
+
+	if p.MinLength < 1 || p.MaxLength < p.MinLength {
+		log.Fatalf("❌ 잘못된 비밀번호 길이 설정입니다: PASSWORD_MIN_LENGTH=%d, PASSWORD_MAX_LENGTH=%d", p.MinLength, p.MaxLength)
+	}
+	if p.MinClasses < 1 || p.MinClasses > 4 {
+		log.Fatalf("❌ PASSWORD_MIN_CLASSES는 1~4 사이여야 합니다: %d", p.MinClasses)
+	}
+	if p.History < 0 || p.History > 24 {
+		log.Fatalf("❌ PASSWORD_HISTORY는 0~24 사이여야 합니다: %d", p.History)
+	}
+
+	switch p.Algorithm {
+	case password.AlgBcrypt:
+		if p.BcryptCost < 10 || p.BcryptCost > 16 {
+			log.Fatalf("❌ BCRYPT_COST는 10~16 사이여야 합니다: %d", p.BcryptCost)
+		}
+	case password.AlgArgon2id:
+		if p.Argon2Memory < 19*1024 || p.Argon2Time < 1 || p.Argon2Threads < 1 || p.Argon2Threads > 255 {
+			log.Fatalf("❌ 잘못된 argon2id 설정입니다: ARGON2_MEMORY=%d(KiB, 19456 이상), ARGON2_TIME=%d, ARGON2_THREADS=%d",
+				p.Argon2Memory, p.Argon2Time, p.Argon2Threads)
+		}
+	default:
+		log.Fatalf("❌ 지원하지 않는 PASSWORD_HASH입니다: %s (bcrypt, argon2id)", p.Algorithm)
 	}
 }
 
@@ -162,13 +556,71 @@ func (c *Config) IsProduction() bool {
 	return c.App.Environment == "release"
 }
 
-// GetDSN MySQL DSN 문자열 생성
+// LoggerConfig 기본 로거 설정 (콘솔 색상은 개발 환경에서만)
+func (c *Config) LoggerConfig() logger.Config {
+	return logger.Config{
+		Level:          c.Log.Level,
+		Format:         c.Log.Format,
+		Color:          c.IsDevelopment(),
+		File:           c.Log.File,
+		FileMaxSizeMB:  c.Log.FileMaxSizeMB,
+		FileMaxBackups: c.Log.FileMaxBackups,
+	}
+}
+
+// IsInMemoryDB SQLite 인메모리 DB 사용 여부
+func (c *Config) IsInMemoryDB() bool {
+	return c.Database.Driver == "sqlite" && c.Database.Database == ":memory:"
+}
+
+// GetDSN 드라이버별 DSN 문자열 생성
 func (c *Config) GetDSN() string {
+	if c.Database.Driver == "sqlite" {
+		return c.getSQLiteDSN()
+	}
+
 	return c.Database.User + ":" + c.Database.Password +
 		"@tcp(" + c.Database.Host + ":" + c.Database.Port + ")/" +
 		c.Database.Database + "?charset=utf8mb4&parseTime=True&loc=Local"
 }
 
+// GetReplicaDSNs 읽기 복제본 DSN 목록
+// 완성된 DSN(user@tcp(...), file:...)은 그대로 쓰고, MySQL host[:port]는 primary 계정/DB로,
+// SQLite 파일 경로는 읽기 전용 DSN으로 변환한다
+func (c *Config) GetReplicaDSNs() []string {
+	dsns := make([]string, 0, len(c.Database.Replicas))
+	for _, replica := range c.Database.Replicas {
+		switch {
+		case strings.Contains(replica, "@") || strings.HasPrefix(replica, "file:"):
+			dsns = append(dsns, replica)
+		case c.Database.Driver == "sqlite":
+			dsns = append(dsns, "file:"+replica+"?mode=ro&_pragma=busy_timeout(5000)")
+		default:
+			host, port := replica, c.Database.Port
+			if i := strings.LastIndex(replica, ":"); i >= 0 {
+				host, port = replica[:i], replica[i+1:]
+			}
+			dsns = append(dsns, c.Database.User+":"+c.Database.Password+
+				"@tcp("+host+":"+port+")/"+
+				c.Database.Database+"?charset=utf8mb4&parseTime=True&loc=Local")
+		}
+	}
+	return dsns
+}
+
+// getSQLiteDSN SQLite DSN 생성 (외래키, 잠금 대기 설정 포함)
+// 시간 값은 SQLite 표준 형식(YYYY-MM-DD HH:MM:SS)으로 저장해 문자열 비교가 시간 순서와 같게 한다
+func (c *Config) getSQLiteDSN() string {
+	pragmas := "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite"
+
+	if c.IsInMemoryDB() {
+		// 커넥션 간 공유되는 이름 있는 인메모리 DB
+		return "file:gin_starter?mode=memory&cache=shared&" + pragmas
+	}
+
+	return "file:" + c.Database.Database + "?" + pragmas + "&_pragma=journal_mode(WAL)"
+}
+
 // Helper functions
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -183,4 +635,23 @@ func getEnvAsInt(key string, defaultValue int) int {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsList 쉼표로 구분된 값 목록 (빈 항목 제외)
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// JWTKey kid로 구분되는 비밀 키
+type JWTKey struct {
+	ID      string
+	Secret  []byte
+	Retired bool // 폐기된 키 (이 키로 만든 토큰은 더 이상 받지 않음)
+}
+
+// Keyring 키 교체용 키 모음
+// 새 토큰은 Primary 키로 서명/암호화하고, 검증은 폐기되지 않은 모든 키로 한다
+type Keyring struct {
+	Primary string
+	Keys    map[string]JWTKey
+}
+
+// PrimaryKey 서명/암호화에 쓰는 키
+func (k Keyring) PrimaryKey() JWTKey {
+	return k.Keys[k.Primary]
+}
+
+// Lookup kid로 검증용 키 조회 (kid가 비어 있으면 kid 도입 전 토큰으로 보고 Primary 사용)
+func (k Keyring) Lookup(id string) (JWTKey, error) {
+	if id == "" {
+		id = k.Primary
+	}
+
+	key, ok := k.Keys[id]
+	if !ok {
+		return JWTKey{}, fmt.Errorf("알 수 없는 키 ID: %s", id)
+	}
+	if key.Retired {
+		return JWTKey{}, fmt.Errorf("폐기된 키 ID: %s", id)
+	}
+	return key, nil
+}
+
+// keyIDPattern 키 ID 형식 (환경변수 이름 일부로 쓰므로 영문, 숫자, _만 허용)
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// keyIDs 키 모음 하나의 키 ID 설정
+type keyIDs struct {
+	Primary  string   // 새 토큰에 쓰는 키 ID
+	Previous []string // 검증만 하는 이전 키 ID
+	Retired  []string // 폐기한 키 ID
+}
+
+// loadKeyIDs <prefix>_KEY_ID, <prefix>_PREVIOUS_KEY_IDS, <prefix>_RETIRED_KEY_IDS 로드 (비어 있으면 fallback 값)
+func loadKeyIDs(prefix string, fallback keyIDs) keyIDs {
+	ids := keyIDs{
+		Primary:  getEnv(prefix+"_KEY_ID", fallback.Primary),
+		Previous: getEnvAsList(prefix + "_PREVIOUS_KEY_IDS"),
+		Retired:  getEnvAsList(prefix + "_RETIRED_KEY_IDS"),
+	}
+	if ids.Previous == nil {
+		ids.Previous = fallback.Previous
+	}
+	if ids.Retired == nil {
+		ids.Retired = fallback.Retired
+	}
+
+	validateKeyIDs(append(append([]string{ids.Primary}, ids.Previous...), ids.Retired...)...)
+	return ids
+}
+
+// loadKeyring 환경변수에서 키 모음 로드
+// primary 키는 envKey, 이전 키는 envKey_<KID>에서 읽는다. 폐기 키는 비밀 값 없이 등록만 한다
+func loadKeyring(envKey string, ids keyIDs) Keyring {
+	ring := Keyring{Primary: ids.Primary, Keys: make(map[string]JWTKey)}
+
+	add := func(id, env string) {
+		secret := getEnv(env, "")
+		if len(secret) != 32 {
+			log.Fatalf("❌ %s는 32자여야 합니다", env)
+		}
+		ring.Keys[id] = JWTKey{ID: id, Secret: []byte(secret)}
+	}
+
+	add(ids.Primary, envKey)
+	for _, id := range ids.Previous {
+		add(id, envKey+"_"+strings.ToUpper(id))
+	}
+	for _, id := range ids.Retired {
+		if id == ids.Primary {
+			log.Fatalf("❌ %s의 primary 키(%s)는 폐기할 수 없습니다", envKey, id)
+		}
+		ring.Keys[id] = JWTKey{ID: id, Retired: true}
+	}
+
+	return ring
+}
+
+// validateKeyIDs 키 ID 형식 검증
+func validateKeyIDs(ids ...string) {
+	for _, id := range ids {
+		if !keyIDPattern.MatchString(id) {
+			log.Fatalf("❌ 잘못된 JWT 키 ID입니다: %q (영문, 숫자, _만 사용)", id)
+		}
+	}
+}
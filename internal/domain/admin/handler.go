@@ -40,9 +40,9 @@ func (h *Handler) GetUsers(c *gin.Context) {
 	userType := c.Query("user_type")
 
 	// 사용자 목록 조회
-	result, err := h.service.GetAllUsers(page, limit, userType)
+	result, err := h.service.GetAllUsers(c.Request.Context(), page, limit, userType)
 	if err != nil {
-		response.InternalError(c, err.Error())
+		response.FromError(c, err)
 		return
 	}
 
@@ -67,25 +67,28 @@ func (h *Handler) GetUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.service.GetUserByID(id)
+	user, err := h.service.GetUserByID(c.Request.Context(), id)
 	if err != nil {
-		response.NotFound(c, "사용자를 찾을 수 없습니다")
+		response.FromError(c, err)
 		return
 	}
 
 	response.Success(c, gin.H{
-		"id":         user.ID,
-		"name":       user.Name,
-		"email":      user.Email,
-		"auth_type":  user.AuthType,
-		"auth_level": user.AuthLevel,
-		"created_at": user.CreatedAt,
+		"id":           user.ID,
+		"name":         user.Name,
+		"email":        user.Email,
+		"auth_type":    user.AuthType,
+		"auth_level":   user.AuthLevel,
+		"roles":        user.Roles,
+		"created_at":   user.CreatedAt,
+		"login_fails":  user.LoginFails,
+		"locked_until": user.LockedUntil,
 	})
 }
 
 // UpdateUserAuth 사용자 권한 수정
 // @Summary      사용자 권한 수정 (관리자)
-// @Description  사용자의 권한 타입과 레벨을 수정합니다
+// @Description  사용자의 대표 역할(권한 타입)과 레벨을 수정합니다. 사용자의 역할은 지정한 역할 하나로 바뀝니다 (role:manage 권한 필요)
 // @Tags         admin
 // @Accept       json
 // @Produce      json
@@ -110,8 +113,8 @@ func (h *Handler) UpdateUserAuth(c *gin.Context) {
 	}
 
 	// 권한 수정
-	if err := h.service.UpdateUserAuth(id, req.AuthType, req.AuthLevel); err != nil {
-		response.BadRequest(c, err.Error())
+	if err := h.service.UpdateUserAuth(c.Request.Context(), id, req.AuthType, req.AuthLevel, c.GetString("user_id")); err != nil {
+		response.FromError(c, err)
 		return
 	}
 
@@ -137,14 +140,253 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 	}
 
 	// 사용자 삭제
-	if err := h.service.DeleteUser(id); err != nil {
-		response.BadRequest(c, err.Error())
+	if err := h.service.DeleteUser(c.Request.Context(), id); err != nil {
+		response.FromError(c, err)
 		return
 	}
 
 	response.Success(c, gin.H{"message": "사용자가 삭제되었습니다"})
 }
 
+// UnlockUser 로그인 잠금 해제
+// @Summary      로그인 잠금 해제 (관리자)
+// @Description  로그인 실패로 잠긴 계정의 잠금과 실패 횟수를 초기화합니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Success      200 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/unlock [post]
+func (h *Handler) UnlockUser(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "사용자 ID는 필수입니다")
+		return
+	}
+
+	if err := h.service.UnlockUser(c.Request.Context(), id, c.GetString("user_id")); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "로그인 잠금이 해제되었습니다"})
+}
+
+// ResetUserMFA 2단계 인증 초기화
+// @Summary      2단계 인증 초기화 (관리자)
+// @Description  인증 앱과 복구 코드를 모두 잃어버린 사용자의 2단계 인증 설정을 삭제합니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Success      200 {object} response.Response
+// @Failure      400 {object} response.Response "MFA_NOT_ENABLED"
+// @Failure      404 {object} response.Response
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/mfa [delete]
+func (h *Handler) ResetUserMFA(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "사용자 ID는 필수입니다")
+		return
+	}
+
+	if err := h.service.ResetUserMFA(c.Request.Context(), id, c.GetString("user_id")); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "2단계 인증이 초기화되었습니다"})
+}
+
+// GetUserSessions 사용자 로그인 세션 조회
+// @Summary      사용자 로그인 세션 (관리자)
+// @Description  사용자의 활성 로그인 세션(기기별)을 조회합니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Success      200 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/sessions [get]
+func (h *Handler) GetUserSessions(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "사용자 ID는 필수입니다")
+		return
+	}
+
+	sessions, err := h.service.GetUserSessions(c.Request.Context(), id)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"sessions": sessions})
+}
+
+// RevokeUserSession 사용자 세션 종료
+// @Summary      사용자 세션 종료 (관리자)
+// @Description  사용자의 로그인 세션 하나를 종료합니다 (해당 기기의 리프레시 토큰 무효화)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Param        sid path string true "세션 ID"
+// @Success      200 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/sessions/{sid} [delete]
+func (h *Handler) RevokeUserSession(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "사용자 ID는 필수입니다")
+		return
+	}
+
+	if err := h.service.RevokeUserSession(c.Request.Context(), id, c.Param("sid"), c.GetString("user_id")); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "세션이 종료되었습니다"})
+}
+
+// RevokeUserSessions 사용자 모든 세션 종료
+// @Summary      사용자 모든 기기 로그아웃 (관리자)
+// @Description  사용자의 모든 로그인 세션을 종료합니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Success      200 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/sessions [delete]
+func (h *Handler) RevokeUserSessions(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "사용자 ID는 필수입니다")
+		return
+	}
+
+	count, err := h.service.RevokeUserSessions(c.Request.Context(), id, c.GetString("user_id"))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "모든 세션이 종료되었습니다", "revoked": count})
+}
+
+// GetUserAPIKeys 사용자 API 키 조회
+// @Summary      사용자 API 키 (관리자)
+// @Description  사용자의 폐기하지 않은 API 키를 조회합니다 (사용 범위, 허용 IP, 마지막 사용 시각)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Success      200 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/api-keys [get]
+func (h *Handler) GetUserAPIKeys(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "사용자 ID는 필수입니다")
+		return
+	}
+
+	keys, err := h.service.GetUserAPIKeys(c.Request.Context(), id)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"api_keys": keys})
+}
+
+// RevokeUserAPIKey 사용자 API 키 폐기
+// @Summary      사용자 API 키 폐기 (관리자)
+// @Description  사용자의 API 키 하나를 폐기합니다 (이후 이 키로 보낸 요청은 거부)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Param        kid path string true "API 키 ID"
+// @Success      200 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/api-keys/{kid} [delete]
+func (h *Handler) RevokeUserAPIKey(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "사용자 ID는 필수입니다")
+		return
+	}
+
+	if err := h.service.RevokeUserAPIKey(c.Request.Context(), id, c.Param("kid"), c.GetString("user_id")); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "API 키가 폐기되었습니다"})
+}
+
+// RevokeUserAPIKeys 사용자 모든 API 키 폐기
+// @Summary      사용자 모든 API 키 폐기 (관리자)
+// @Description  사용자의 모든 API 키를 폐기합니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Success      200 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/api-keys [delete]
+func (h *Handler) RevokeUserAPIKeys(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		response.BadRequest(c, "사용자 ID는 필수입니다")
+		return
+	}
+
+	count, err := h.service.RevokeUserAPIKeys(c.Request.Context(), id, c.GetString("user_id"))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "모든 API 키가 폐기되었습니다", "revoked": count})
+}
+
+// GetLockouts 로그인 잠금 기록 조회
+// @Summary      로그인 잠금 기록 (관리자)
+// @Description  로그인 실패 반복으로 잠긴 계정/IP 기록을 최신순으로 조회합니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "페이지 번호 (기본: 1)"
+// @Param        limit query int false "페이지 크기 (기본: 20)"
+// @Success      200 {object} response.Response{data=AdminLockoutListResponse}
+// @Security     BearerAuth
+// @Router       /api/admin/lockouts [get]
+func (h *Handler) GetLockouts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	result, err := h.service.GetLockouts(c.Request.Context(), page, limit)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
 // GetStats 통계 조회
 // @Summary      통계 조회 (관리자)
 // @Description  전체 사용자, 블로그 등의 통계를 조회합니다
@@ -155,9 +397,9 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 // @Security     BearerAuth
 // @Router       /api/admin/stats [get]
 func (h *Handler) GetStats(c *gin.Context) {
-	stats, err := h.service.GetStats()
+	stats, err := h.service.GetStats(c.Request.Context())
 	if err != nil {
-		response.InternalError(c, err.Error())
+		response.FromError(c, err)
 		return
 	}
 
@@ -6,7 +6,7 @@ import "gin_starter/internal/domain/user"
 type AdminUserListRequest struct {
 	Page     int    `json:"page"`
 	Limit    int    `json:"limit"`
-	UserType string `json:"user_type"` // U, A, 전체는 빈 문자열
+	UserType string `json:"user_type"` // 대표 역할 (U, A 등), 전체는 빈 문자열
 }
 
 // AdminUserListResponse 관리자 사용자 목록 응답
@@ -19,14 +19,24 @@ type AdminUserListResponse struct {
 
 // AdminUpdateUserAuthRequest 사용자 권한 수정 요청
 type AdminUpdateUserAuthRequest struct {
-	AuthType  string `json:"auth_type"`  // U, A
+	AuthType  string `json:"auth_type"`  // 역할 코드 (U, A, M, AG 등)
 	AuthLevel int    `json:"auth_level"` // 1-10
 }
 
 // AdminStatsResponse 관리자 통계 응답
 type AdminStatsResponse struct {
-	TotalUsers  int64 `json:"total_users"`
-	AdminUsers  int64 `json:"admin_users"`
-	NormalUsers int64 `json:"normal_users"`
-	TotalBlogs  int64 `json:"total_blogs"`
+	TotalUsers     int64 `json:"total_users"`
+	AdminUsers     int64 `json:"admin_users"`
+	NormalUsers    int64 `json:"normal_users"`
+	TotalBlogs     int64 `json:"total_blogs"`
+	LockedUsers    int64 `json:"locked_users"`    // 현재 로그인 잠긴 계정 수
+	RecentLockouts int64 `json:"recent_lockouts"` // 최근 24시간 로그인 잠금 수 (계정 + IP)
+}
+
+// AdminLockoutListResponse 로그인 잠금 기록 목록 응답
+type AdminLockoutListResponse struct {
+	Lockouts []user.Lockout `json:"lockouts"`
+	Total    int64          `json:"total"`
+	Page     int            `json:"page"`
+	Limit    int            `json:"limit"`
 }
\ No newline at end of file
@@ -7,25 +7,39 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const templateDir = "web/admin/templates/"
+
 // PageHandler 관리자 페이지 핸들러
 type PageHandler struct {
-	templates *template.Template
+	login     *template.Template
+	dashboard *template.Template
+	users     *template.Template
 }
 
 // NewPageHandler 관리자 페이지 핸들러 생성
 func NewPageHandler() *PageHandler {
-	// 템플릿 로드
-	templates := template.Must(template.ParseGlob("web/admin/templates/*.html"))
-
 	return &PageHandler{
-		templates: templates,
+		login:     parsePage("login.html"),
+		dashboard: parsePage("layout.html", "dashboard.html"),
+		users:     parsePage("layout.html", "users.html"),
 	}
 }
 
+// parsePage 페이지별 템플릿 로드
+// 프론트엔드(Vue)가 {{ }}를 사용하므로 Go 템플릿은 [[ ]] 구분자를 사용
+func parsePage(files ...string) *template.Template {
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = templateDir + file
+	}
+
+	return template.Must(template.New(files[0]).Delims("[[", "]]").ParseFiles(paths...))
+}
+
 // LoginPage 로그인 페이지
 func (h *PageHandler) LoginPage(c *gin.Context) {
 	c.Header("Content-Type", "text/html; charset=utf-8")
-	h.templates.ExecuteTemplate(c.Writer, "login.html", nil)
+	h.login.Execute(c.Writer, nil)
 }
 
 // DashboardPage 대시보드 페이지
@@ -41,9 +55,8 @@ func (h *PageHandler) DashboardPage(c *gin.Context) {
 	c.Header("Content-Type", "text/html; charset=utf-8")
 	c.Writer.WriteHeader(http.StatusOK)
 
-	// layout과 dashboard를 함께 렌더링
-	h.templates.ExecuteTemplate(c.Writer, "layout.html", data)
-	h.templates.ExecuteTemplate(c.Writer, "dashboard.html", data)
+	// layout에 dashboard content를 넣어 렌더링
+	h.dashboard.Execute(c.Writer, data)
 }
 
 // UsersPage 사용자 관리 페이지
@@ -58,8 +71,7 @@ func (h *PageHandler) UsersPage(c *gin.Context) {
 	c.Header("Content-Type", "text/html; charset=utf-8")
 	c.Writer.WriteHeader(http.StatusOK)
 
-	h.templates.ExecuteTemplate(c.Writer, "layout.html", data)
-	h.templates.ExecuteTemplate(c.Writer, "users.html", data)
+	h.users.Execute(c.Writer, data)
 }
 
 // LogoutPage 로그아웃
@@ -84,4 +96,4 @@ func (h *PageHandler) LogoutPage(c *gin.Context) {
 		</body>
 		</html>
 	`)
-}
\ No newline at end of file
+}
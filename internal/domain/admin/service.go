@@ -1,36 +1,62 @@
 package admin
 
 import (
+	"context"
+	"database/sql"
+	"gin_starter/internal/domain/blog"
 	"gin_starter/internal/domain/user"
 	"gin_starter/internal/infrastructure/database"
+	"gin_starter/internal/middleware"
 	"gin_starter/pkg/errors"
 	"gin_starter/pkg/logger"
+	"gin_starter/pkg/revocation"
+	"time"
 )
 
 // Service 관리자 비즈니스 로직 인터페이스
 type Service interface {
-	GetAllUsers(page, limit int, userType string) (*AdminUserListResponse, error)
-	GetUserByID(id string) (*user.User, error)
-	UpdateUserAuth(id string, authType string, authLevel int) error
-	DeleteUser(id string) error
-	GetStats() (*AdminStatsResponse, error)
+	GetAllUsers(ctx context.Context, page, limit int, userType string) (*AdminUserListResponse, error)
+	GetUserByID(ctx context.Context, id string) (*user.User, error)
+	UpdateUserAuth(ctx context.Context, id string, authType string, authLevel int, adminID string) error
+	DeleteUser(ctx context.Context, id string) error
+	UnlockUser(ctx context.Context, id string, adminID string) error
+	ResetUserMFA(ctx context.Context, id string, adminID string) error
+	GetUserSessions(ctx context.Context, id string) ([]user.Session, error)
+	RevokeUserSession(ctx context.Context, id, sessionID, adminID string) error
+	RevokeUserSessions(ctx context.Context, id, adminID string) (int64, error)
+	GetUserAPIKeys(ctx context.Context, id string) ([]user.APIKey, error)
+	RevokeUserAPIKey(ctx context.Context, id, keyID, adminID string) error
+	RevokeUserAPIKeys(ctx context.Context, id, adminID string) (int64, error)
+	GetLockouts(ctx context.Context, page, limit int) (*AdminLockoutListResponse, error)
+	GetStats(ctx context.Context) (*AdminStatsResponse, error)
 }
 
 type service struct {
-	userRepo user.Repository
-	db       *database.DB
+	userRepo   user.Repository
+	blogRepo   blog.Repository
+	db         *database.DB
+	base       *database.Repository
+	principals *middleware.PrincipalCache
+	revoked    *revocation.List
+	accessTTL  time.Duration
 }
 
 // NewService 관리자 서비스 생성
-func NewService(userRepo user.Repository, db *database.DB) Service {
+// revoked: 권한 변경, 삭제, 세션 종료 시 사용자의 액세스 토큰을 즉시 폐기할 목록
+func NewService(userRepo user.Repository, blogRepo blog.Repository, db *database.DB, principals *middleware.PrincipalCache, revoked *revocation.List, accessTTL time.Duration) Service {
 	return &service{
-		userRepo: userRepo,
-		db:       db,
+		userRepo:   userRepo,
+		blogRepo:   blogRepo,
+		db:         db,
+		base:       database.NewRepository(db),
+		principals: principals,
+		revoked:    revoked,
+		accessTTL:  accessTTL,
 	}
 }
 
 // GetAllUsers 모든 사용자 조회 (페이지네이션)
-func (s *service) GetAllUsers(page, limit int, userType string) (*AdminUserListResponse, error) {
+func (s *service) GetAllUsers(ctx context.Context, page, limit int, userType string) (*AdminUserListResponse, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -38,46 +64,47 @@ func (s *service) GetAllUsers(page, limit int, userType string) (*AdminUserListR
 		limit = 20
 	}
 
-	offset := (page - 1) * limit
-
-	// 쿼리 생성
-	query := "SELECT u_id, u_name, u_email, u_auth_type, u_auth_level, u_reg_date FROM _user"
-	countQuery := "SELECT COUNT(*) FROM _user"
-	var args []interface{}
-
+	var where database.Expr
 	if userType != "" {
-		query += " WHERE u_auth_type = ?"
-		countQuery += " WHERE u_auth_type = ?"
-		args = append(args, userType)
+		where = database.Eq("u_auth_type", userType)
 	}
 
-	query += " ORDER BY u_reg_date DESC LIMIT ? OFFSET ?"
-
 	// 전체 개수 조회
-	var total int64
-	err := s.db.DB.QueryRow(countQuery, args...).Scan(&total)
+	total, err := s.base.Select("_user").Where(where).Count(ctx)
 	if err != nil {
-		logger.Error("사용자 개수 조회 실패: %v", err)
-		return nil, errors.Wrap(err, "DATABASE_ERROR", "사용자 개수 조회 실패")
+		logger.FromContext(ctx).Error("사용자 개수 조회 실패: %v", err)
+		return nil, err
 	}
 
 	// 사용자 목록 조회
-	queryArgs := append(args, limit, offset)
-	rows, err := s.db.DB.Query(query, queryArgs...)
+	rows, err := s.base.Select("_user", "u_id", "u_name", "u_email", "u_auth_type", "u_auth_level", "u_regi_date", "u_locked_until", "u_email_verified_at").
+		Where(where).
+		OrderBy("u_regi_date", database.Desc).
+		Page(page, limit).
+		Query(ctx)
 	if err != nil {
-		logger.Error("사용자 목록 조회 실패: %v", err)
-		return nil, errors.Wrap(err, "DATABASE_ERROR", "사용자 목록 조회 실패")
+		return nil, err
 	}
 	defer rows.Close()
 
 	var users []user.User
 	for rows.Next() {
 		var u user.User
-		if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.AuthType, &u.AuthLevel, &u.CreatedAt); err != nil {
+		var lockedUntil, verifiedAt sql.NullTime
+		if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.AuthType, &u.AuthLevel, &u.CreatedAt, &lockedUntil, &verifiedAt); err != nil {
 			return nil, err
 		}
+		if verifiedAt.Valid {
+			u.EmailVerifiedAt = &verifiedAt.Time
+		}
+		if lockedUntil.Valid && lockedUntil.Time.After(time.Now()) {
+			u.LockedUntil = &lockedUntil.Time
+		}
 		users = append(users, u)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
 	return &AdminUserListResponse{
 		Users: users,
@@ -88,21 +115,34 @@ func (s *service) GetAllUsers(page, limit int, userType string) (*AdminUserListR
 }
 
 // GetUserByID 사용자 상세 조회
-func (s *service) GetUserByID(id string) (*user.User, error) {
-	return s.userRepo.FindByID(id)
+func (s *service) GetUserByID(ctx context.Context, id string) (*user.User, error) {
+	u, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Roles, err = s.userRepo.FindRoles(ctx, id); err != nil {
+		return nil, err
+	}
+	return u, nil
 }
 
 // UpdateUserAuth 사용자 권한 수정
-func (s *service) UpdateUserAuth(id string, authType string, authLevel int) error {
+// authType은 등록된 역할 코드여야 하고, 사용자의 역할을 이 역할 하나로 바꾼다 (여러 역할은 PUT /users/:id/roles)
+func (s *service) UpdateUserAuth(ctx context.Context, id string, authType string, authLevel int, adminID string) error {
 	// 사용자 존재 확인
-	_, err := s.userRepo.FindByID(id)
+	target, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
-		return errors.New("USER_NOT_FOUND", "사용자를 찾을 수 없습니다")
+		return err
 	}
 
-	// 권한 타입 검증
-	if authType != "U" && authType != "A" {
-		return errors.New("INVALID_AUTH_TYPE", "권한 타입은 U 또는 A여야 합니다")
+	// 권한 타입 검증 (등록된 역할)
+	exists, err := s.base.Exists(ctx, "_roles", "r_code = ?", authType)
+	if err != nil {
+		return errors.Wrap(err, "UPDATE_FAILED", "사용자 권한 수정 실패")
+	}
+	if !exists {
+		return errors.New("INVALID_AUTH_TYPE", "권한 타입은 등록된 역할 코드여야 합니다")
 	}
 
 	// 권한 레벨 검증
@@ -110,67 +150,297 @@ func (s *service) UpdateUserAuth(id string, authType string, authLevel int) erro
 		return errors.New("INVALID_AUTH_LEVEL", "권한 레벨은 1-10 사이여야 합니다")
 	}
 
-	// 업데이트
-	updates := map[string]interface{}{
-		"u_auth_type":  authType,
-		"u_auth_level": authLevel,
+	// 업데이트 (u_auth_type은 역할과 함께 기록)
+	// 레벨이 같으면 수정하지 않는다 (MySQL은 값이 바뀐 행만 세므로 0행이 사용자 없음으로 처리됨)
+	err = s.db.WithTx(ctx, func(ctx context.Context) error {
+		if target.AuthLevel != authLevel {
+			if err := s.userRepo.Update(ctx, id, map[string]interface{}{"u_auth_level": authLevel}); err != nil {
+				return err
+			}
+		}
+		return s.userRepo.ReplaceRoles(ctx, id, []string{authType}, adminID, time.Now())
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("사용자 권한 수정 실패: %v", err)
+		return errors.Wrap(err, "UPDATE_FAILED", "사용자 권한 수정 실패")
 	}
 
-	if err := s.userRepo.Update(id, updates); err != nil {
-		logger.Error("사용자 권한 수정 실패: %v", err)
-		return errors.Wrap(err, "UPDATE_FAILED", "사용자 권한 수정 실패")
+	// 캐시된 권한 정보 무효화 (토큰 만료를 기다리지 않고 즉시 반영)
+	s.principals.Invalidate(id)
+
+	// 이전 권한으로 발급된 액세스 토큰 폐기 (리프레시 토큰으로 새로 발급받음)
+	s.revoked.RevokeUser(ctx, id, s.accessTTL)
+
+	logger.FromContext(ctx).Info("사용자 권한 수정: %s (타입: %s, 레벨: %d)", id, authType, authLevel)
+	return nil
+}
+
+// DeleteUser 사용자와 작성한 블로그를 하나의 트랜잭션으로 삭제
+func (s *service) DeleteUser(ctx context.Context, id string) error {
+	var deletedBlogs int64
+
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		// 사용자 존재 확인
+		if _, err := s.userRepo.FindByID(ctx, id); err != nil {
+			return err
+		}
+
+		count, err := s.blogRepo.DeleteByAuthorID(ctx, id)
+		if err != nil {
+			return err
+		}
+		deletedBlogs = count
+
+		if _, err := s.userRepo.DeleteSessions(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := s.userRepo.DeleteUserTokens(ctx, id, ""); err != nil {
+			return err
+		}
+
+		if _, err := s.userRepo.DeleteRecoveryCodes(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := s.userRepo.DeletePasswordHistory(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := s.userRepo.DeleteRoles(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := s.userRepo.DeleteAPIKeys(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := s.userRepo.DeleteIdentities(ctx, id); err != nil {
+			return err
+		}
+
+		return s.userRepo.Delete(ctx, id)
+	})
+	if err != nil {
+		if errors.Is(err, errors.ErrUserNotFound) {
+			return err
+		}
+		logger.FromContext(ctx).Error("사용자 삭제 실패: %v", err)
+		return errors.Wrap(err, "DELETE_FAILED", "사용자 삭제 실패")
 	}
 
-	logger.Info("사용자 권한 수정: %s (타입: %s, 레벨: %d)", id, authType, authLevel)
+	s.principals.Invalidate(id)
+	s.revoked.RevokeUser(ctx, id, s.accessTTL)
+
+	logger.FromContext(ctx).Info("사용자 삭제: %s (블로그 %d개 삭제)", id, deletedBlogs)
 	return nil
 }
 
-// DeleteUser 사용자 삭제
-func (s *service) DeleteUser(id string) error {
-	// 사용자 존재 확인
-	_, err := s.userRepo.FindByID(id)
+// UnlockUser 로그인 잠금 해제 (실패 횟수 초기화, 잠금 기록에 해제한 관리자 기록)
+func (s *service) UnlockUser(ctx context.Context, id string, adminID string) error {
+	target, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
-		return errors.New("USER_NOT_FOUND", "사용자를 찾을 수 없습니다")
+		return err
 	}
 
-	// 삭제
-	if err := s.userRepo.Delete(id); err != nil {
-		logger.Error("사용자 삭제 실패: %v", err)
-		return errors.Wrap(err, "DELETE_FAILED", "사용자 삭제 실패")
+	// 초기화할 값이 있을 때만 수정 (잠기지 않은 사용자도 잠금 기록은 해제)
+	if target.LoginFails > 0 || target.LoginFailAt != nil || target.LockedUntil != nil {
+		if err := s.userRepo.ResetLoginFailures(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	released, err := s.userRepo.ReleaseLockouts(ctx, id, adminID, time.Now())
+	if err != nil {
+		return err
 	}
 
-	logger.Info("사용자 삭제: %s", id)
+	logger.FromContext(ctx).Info("로그인 잠금 해제: %s (관리자: %s, 잠금 기록 %d건)", id, adminID, released)
 	return nil
 }
 
+// ResetUserMFA 2단계 인증 초기화 (인증 앱과 복구 코드를 모두 잃어버린 경우, 다음 로그인부터 비밀번호만 확인)
+func (s *service) ResetUserMFA(ctx context.Context, id string, adminID string) error {
+	err := s.db.WithTx(ctx, func(ctx context.Context) error {
+		target, err := s.userRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !target.MFAEnabled() && target.MFASecret == "" {
+			return errors.ErrMFANotEnabled
+		}
+
+		updates := map[string]interface{}{
+			"u_mfa_secret":     nil,
+			"u_mfa_enabled_at": nil,
+			"u_mfa_last_step":  0,
+		}
+		if err := s.userRepo.Update(ctx, id, updates); err != nil {
+			return err
+		}
+
+		_, err = s.userRepo.DeleteRecoveryCodes(ctx, id)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// 2단계 인증 대기 중인 로그인도 무효화
+	if _, err := s.userRepo.DeleteUserTokens(ctx, id, user.TokenPurposeMFA); err != nil {
+		logger.FromContext(ctx).Warn("2단계 인증 대기 토큰 정리 실패: %v", err)
+	}
+	s.principals.Invalidate(id)
+
+	logger.FromContext(ctx).Info("2단계 인증 초기화: %s (관리자: %s)", id, adminID)
+	return nil
+}
+
+// GetUserSessions 사용자의 활성 로그인 세션
+func (s *service) GetUserSessions(ctx context.Context, id string) ([]user.Session, error) {
+	if _, err := s.userRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.FindActiveSessions(ctx, id, time.Now())
+}
+
+// RevokeUserSession 사용자의 세션 하나 종료
+func (s *service) RevokeUserSession(ctx context.Context, id, sessionID, adminID string) error {
+	revoked, err := s.userRepo.RevokeSession(ctx, id, sessionID, user.SessionRevokeAdmin, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.ErrSessionNotFound
+	}
+	s.revoked.RevokeSession(ctx, sessionID, s.accessTTL)
+
+	logger.FromContext(ctx).Info("세션 강제 종료: %s (세션: %s, 관리자: %s)", id, sessionID, adminID)
+	return nil
+}
+
+// RevokeUserSessions 사용자의 모든 세션 종료 (모든 기기 로그아웃)
+func (s *service) RevokeUserSessions(ctx context.Context, id, adminID string) (int64, error) {
+	if _, err := s.userRepo.FindByID(ctx, id); err != nil {
+		return 0, err
+	}
+
+	count, err := s.userRepo.RevokeSessions(ctx, id, "", user.SessionRevokeAdmin, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	s.revoked.RevokeUser(ctx, id, s.accessTTL)
+
+	logger.FromContext(ctx).Info("모든 세션 강제 종료: %s (%d개, 관리자: %s)", id, count, adminID)
+	return count, nil
+}
+
+// GetUserAPIKeys 사용자의 API 키 (폐기한 키 제외)
+func (s *service) GetUserAPIKeys(ctx context.Context, id string) ([]user.APIKey, error) {
+	if _, err := s.userRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.FindAPIKeys(ctx, id)
+}
+
+// RevokeUserAPIKey 사용자의 API 키 하나 폐기
+func (s *service) RevokeUserAPIKey(ctx context.Context, id, keyID, adminID string) error {
+	revoked, err := s.userRepo.RevokeAPIKey(ctx, id, keyID, adminID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.ErrAPIKeyNotFound
+	}
+
+	logger.FromContext(ctx).Info("API 키 강제 폐기: %s (키: %s, 관리자: %s)", id, keyID, adminID)
+	return nil
+}
+
+// RevokeUserAPIKeys 사용자의 모든 API 키 폐기
+func (s *service) RevokeUserAPIKeys(ctx context.Context, id, adminID string) (int64, error) {
+	if _, err := s.userRepo.FindByID(ctx, id); err != nil {
+		return 0, err
+	}
+
+	count, err := s.userRepo.RevokeAPIKeys(ctx, id, adminID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	logger.FromContext(ctx).Info("모든 API 키 강제 폐기: %s (%d개, 관리자: %s)", id, count, adminID)
+	return count, nil
+}
+
+// GetLockouts 로그인 잠금 기록 조회 (최신순)
+func (s *service) GetLockouts(ctx context.Context, page, limit int) (*AdminLockoutListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	lockouts, total, err := s.userRepo.FindLockouts(ctx, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminLockoutListResponse{
+		Lockouts: lockouts,
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}, nil
+}
+
 // GetStats 통계 조회
-func (s *service) GetStats() (*AdminStatsResponse, error) {
+func (s *service) GetStats(ctx context.Context) (*AdminStatsResponse, error) {
 	stats := &AdminStatsResponse{}
+	var err error
 
 	// 전체 사용자 수
-	err := s.db.DB.QueryRow("SELECT COUNT(*) FROM _user").Scan(&stats.TotalUsers)
+	stats.TotalUsers, err = s.base.Select("_user").Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 관리자 수 (역할 기준)
+	stats.AdminUsers, err = s.base.Select("_user_roles").Where(database.Eq("ur_role", "A")).Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 일반 사용자 수 (역할 기준)
+	stats.NormalUsers, err = s.base.Select("_user_roles").Where(database.Eq("ur_role", "U")).Count(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// 관리자 수
-	err = s.db.DB.QueryRow("SELECT COUNT(*) FROM _user WHERE u_auth_type = 'A'").Scan(&stats.AdminUsers)
+	// 로그인 잠긴 계정 수, 최근 24시간 잠금 수
+	now := time.Now()
+	stats.LockedUsers, err = s.base.Select("_user").Where(database.Gt("u_locked_until", now)).Count(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// 일반 사용자 수
-	err = s.db.DB.QueryRow("SELECT COUNT(*) FROM _user WHERE u_auth_type = 'U'").Scan(&stats.NormalUsers)
+	stats.RecentLockouts, err = s.base.Select("_login_lockouts").Where(database.Gte("ll_regi_date", now.Add(-24*time.Hour))).Count(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// 전체 블로그 수
-	err = s.db.DB.QueryRow("SELECT COUNT(*) FROM _blog").Scan(&stats.TotalBlogs)
+	stats.TotalBlogs, err = s.base.Select("_blog").Count(ctx)
 	if err != nil {
+		// 요청이 만료/취소된 경우는 그대로 반환
+		if ctx.Err() != nil {
+			return nil, err
+		}
 		// 블로그 테이블이 없을 수 있으므로 에러 무시
 		stats.TotalBlogs = 0
 	}
 
 	return stats, nil
-}
\ No newline at end of file
+}
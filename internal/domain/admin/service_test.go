@@ -0,0 +1,184 @@
+package admin_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gin_starter/internal/config"
+	"gin_starter/internal/domain/admin"
+	"gin_starter/internal/domain/blog"
+	"gin_starter/internal/domain/role"
+	"gin_starter/internal/domain/user"
+	"gin_starter/internal/infrastructure/database"
+	"gin_starter/internal/middleware"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/rbac"
+)
+
+func TestMain(m *testing.M) {
+	for key, value := range map[string]string{
+		"DB_DRIVER":          "sqlite",
+		"DB_NAME":            ":memory:",
+		"JWT_SECRET":         strings.Repeat("a", 32),
+		"JWT_REFRESH_SECRET": strings.Repeat("b", 32),
+		"JWT_TOKEN_SECRET":   strings.Repeat("c", 32),
+		"LOG_LEVEL":          "error",
+	} {
+		os.Setenv(key, value)
+	}
+	config.Load()
+
+	os.Exit(m.Run())
+}
+
+// changedRowsRepo MySQL 기본 DSN처럼 값이 실제로 바뀐 행만 영향받은 행으로 세는 저장소
+// (값이 같으면 0행이므로 사용자 저장소는 ErrUserNotFound를 돌려준다)
+type changedRowsRepo struct {
+	user.Repository
+}
+
+func (r changedRowsRepo) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	u, err := r.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if level, ok := updates["u_auth_level"]; ok && len(updates) == 1 && level == u.AuthLevel {
+		return errors.ErrUserNotFound
+	}
+	return r.Repository.Update(ctx, id, updates)
+}
+
+func (r changedRowsRepo) ResetLoginFailures(ctx context.Context, id string) error {
+	u, err := r.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if u.LoginFails == 0 && u.LoginFailAt == nil && u.LockedUntil == nil {
+		return errors.ErrUserNotFound
+	}
+	return r.Repository.ResetLoginFailures(ctx, id)
+}
+
+// adminEnv SQLite DB로 만든 관리자 서비스
+type adminEnv struct {
+	repo    user.Repository
+	service admin.Service
+}
+
+func newAdminEnv(t *testing.T) *adminEnv {
+	t.Helper()
+	ctx := context.Background()
+
+	cfg := *config.Get()
+	cfg.Database.Database = filepath.Join(t.TempDir(), "test.db")
+
+	db, err := database.Connect(&cfg)
+	if err != nil {
+		t.Fatalf("DB 연결 실패: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator := database.NewMigrator(db, database.MigrationsDir("../../../migrations", db))
+	migrator.Out = io.Discard
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("마이그레이션 실패: %v", err)
+	}
+
+	repo := changedRowsRepo{user.NewRepository(db)}
+	policy := rbac.NewPolicy(role.NewPolicyStore(db), time.Minute)
+	principals := middleware.NewPrincipalCache(user.NewPrincipalLoader(repo, policy), time.Minute)
+	service := admin.NewService(repo, blog.NewRepository(db), db, principals, nil, cfg.JWT.AccessTTL())
+
+	return &adminEnv{repo: repo, service: service}
+}
+
+// createUser 일반 사용자 생성
+func (e *adminEnv) createUser(t *testing.T, id string) {
+	t.Helper()
+
+	err := e.repo.Create(context.Background(), &user.User{
+		ID: id, Password: "hash", Name: "Tester", Email: id + "@example.com", AuthType: "U", AuthLevel: 1,
+	})
+	if err != nil {
+		t.Fatalf("사용자 생성 실패: %v", err)
+	}
+}
+
+func TestUpdateUserAuthRoleOnly(t *testing.T) {
+	ctx := context.Background()
+	env := newAdminEnv(t)
+	env.createUser(t, "member")
+
+	// 레벨은 그대로 두고 역할만 변경
+	if err := env.service.UpdateUserAuth(ctx, "member", "M", 1, "admin"); err != nil {
+		t.Fatalf("역할만 변경 실패: %v", err)
+	}
+
+	roles, err := env.repo.FindRoles(ctx, "member")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 || roles[0] != "M" {
+		t.Errorf("역할 = %v, want [M]", roles)
+	}
+
+	u, err := env.repo.FindByID(ctx, "member")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.AuthType != "M" || u.AuthLevel != 1 {
+		t.Errorf("권한 = %s/%d, want M/1", u.AuthType, u.AuthLevel)
+	}
+
+	// 레벨 변경도 그대로 반영
+	if err := env.service.UpdateUserAuth(ctx, "member", "M", 3, "admin"); err != nil {
+		t.Fatalf("레벨 변경 실패: %v", err)
+	}
+	if u, _ := env.repo.FindByID(ctx, "member"); u.AuthLevel != 3 {
+		t.Errorf("레벨 = %d, want 3", u.AuthLevel)
+	}
+}
+
+func TestUnlockUser(t *testing.T) {
+	ctx := context.Background()
+	env := newAdminEnv(t)
+	env.createUser(t, "member")
+
+	// 잠기지 않은 사용자도 잠금 기록은 해제된다
+	lockout := &user.Lockout{Scope: user.LockoutScopeAccount, UserID: "member", FailCount: 5, LockedUntil: time.Now().Add(-time.Minute)}
+	if err := env.repo.CreateLockout(ctx, lockout); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.service.UnlockUser(ctx, "member", "admin"); err != nil {
+		t.Fatalf("잠기지 않은 사용자 잠금 해제 실패: %v", err)
+	}
+
+	lockouts, _, err := env.repo.FindLockouts(ctx, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lockouts) != 1 || lockouts[0].UnlockedBy != "admin" || lockouts[0].UnlockedAt == nil {
+		t.Errorf("잠금 기록이 해제되지 않음: %+v", lockouts)
+	}
+
+	// 잠긴 사용자는 잠금과 실패 횟수가 초기화된다
+	if err := env.repo.LockLogin(ctx, "member", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.service.UnlockUser(ctx, "member", "admin"); err != nil {
+		t.Fatalf("잠금 해제 실패: %v", err)
+	}
+	if u, _ := env.repo.FindByID(ctx, "member"); u.LockedUntil != nil {
+		t.Errorf("잠금이 남아 있음: %v", u.LockedUntil)
+	}
+
+	// 없는 사용자는 404
+	if err := env.service.UnlockUser(ctx, "nobody", "admin"); !errors.Is(err, errors.ErrUserNotFound) {
+		t.Errorf("없는 사용자 잠금 해제 = %v", err)
+	}
+}
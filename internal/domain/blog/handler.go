@@ -1,6 +1,7 @@
 package blog
 
 import (
+	"gin_starter/internal/middleware"
 	"gin_starter/pkg/response"
 	"gin_starter/pkg/validator"
 	"strconv"
@@ -41,39 +42,16 @@ func (h *Handler) Create(c *gin.Context) {
 	}
 
 	// 입력 검증
-	rules := []validator.Rule{
-		{
-			Field:    "title",
-			Label:    "제목",
-			Required: true,
-			MinLen:   2,
-			MaxLen:   200,
-		},
-		{
-			Field:    "content",
-			Label:    "내용",
-			Required: true,
-			MinLen:   1,
-			MaxLen:   10000,
-		},
-	}
-
-	result := validator.Validate(c, rules)
-	if !result.Valid {
+	var req CreateBlogRequest
+	if result := validator.Bind(c, &req); !result.Valid {
 		response.ValidationError(c, result.GetErrorMap())
 		return
 	}
 
-	// 요청 생성
-	req := &CreateBlogRequest{
-		Title:   result.Values["title"],
-		Content: result.Values["content"],
-	}
-
 	// 블로그 생성
-	blog, err := h.service.CreateBlog(userID.(string), req)
+	blog, err := h.service.CreateBlog(c.Request.Context(), userID.(string), &req)
 	if err != nil {
-		response.BadRequest(c, err.Error())
+		response.FromError(c, err)
 		return
 	}
 
@@ -100,9 +78,9 @@ func (h *Handler) Get(c *gin.Context) {
 	}
 
 	// 블로그 조회
-	blog, err := h.service.GetBlog(id)
+	blog, err := h.service.GetBlog(c.Request.Context(), id)
 	if err != nil {
-		response.NotFound(c, err.Error())
+		response.FromError(c, err)
 		return
 	}
 
@@ -126,9 +104,9 @@ func (h *Handler) List(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 
 	// 블로그 목록 조회
-	result, err := h.service.GetBlogs(page, limit)
+	result, err := h.service.GetBlogs(c.Request.Context(), page, limit)
 	if err != nil {
-		response.InternalError(c, err.Error())
+		response.FromError(c, err)
 		return
 	}
 
@@ -159,9 +137,9 @@ func (h *Handler) ListByAuthor(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 
 	// 블로그 목록 조회
-	result, err := h.service.GetBlogsByAuthor(authorID, page, limit)
+	result, err := h.service.GetBlogsByAuthor(c.Request.Context(), authorID, page, limit)
 	if err != nil {
-		response.InternalError(c, err.Error())
+		response.FromError(c, err)
 		return
 	}
 
@@ -170,7 +148,7 @@ func (h *Handler) ListByAuthor(c *gin.Context) {
 
 // Update 블로그 수정
 // @Summary      블로그 수정
-// @Description  자신의 블로그 글을 수정합니다
+// @Description  자신의 블로그 글을 수정합니다 (blog:update:own). blog:update:any 권한이 있으면 다른 사용자의 글도 수정할 수 있습니다
 // @Tags         blog
 // @Accept       json
 // @Produce      json
@@ -200,43 +178,20 @@ func (h *Handler) Update(c *gin.Context) {
 	}
 
 	// 입력 검증
-	rules := []validator.Rule{
-		{
-			Field:  "title",
-			Label:  "제목",
-			MinLen: 2,
-			MaxLen: 200,
-		},
-		{
-			Field:  "content",
-			Label:  "내용",
-			MinLen: 1,
-			MaxLen: 10000,
-		},
-	}
-
-	result := validator.Validate(c, rules)
-	if !result.Valid {
+	var req UpdateBlogRequest
+	if result := validator.Bind(c, &req); !result.Valid {
 		response.ValidationError(c, result.GetErrorMap())
 		return
 	}
 
-	// 요청 생성
-	req := &UpdateBlogRequest{
-		Title:   result.Values["title"],
-		Content: result.Values["content"],
-	}
-
 	// 블로그 수정
-	blog, err := h.service.UpdateBlog(id, userID.(string), req)
+	anyAuthor, ok := blogPermission(c, "update")
+	if !ok {
+		return
+	}
+	blog, err := h.service.UpdateBlog(c.Request.Context(), id, userID.(string), anyAuthor, &req)
 	if err != nil {
-		if err.Error() == "본인의 블로그만 수정할 수 있습니다" {
-			response.Forbidden(c, err.Error())
-		} else if err.Error() == "블로그를 찾을 수 없습니다" {
-			response.NotFound(c, err.Error())
-		} else {
-			response.BadRequest(c, err.Error())
-		}
+		response.FromError(c, err)
 		return
 	}
 
@@ -245,7 +200,7 @@ func (h *Handler) Update(c *gin.Context) {
 
 // Delete 블로그 삭제
 // @Summary      블로그 삭제
-// @Description  자신의 블로그 글을 삭제합니다
+// @Description  자신의 블로그 글을 삭제합니다 (blog:delete:own). blog:delete:any 권한이 있으면 다른 사용자의 글도 삭제할 수 있습니다
 // @Tags         blog
 // @Accept       json
 // @Produce      json
@@ -274,17 +229,29 @@ func (h *Handler) Delete(c *gin.Context) {
 	}
 
 	// 블로그 삭제
-	err = h.service.DeleteBlog(id, userID.(string))
+	anyAuthor, ok := blogPermission(c, "delete")
+	if !ok {
+		return
+	}
+	err = h.service.DeleteBlog(c.Request.Context(), id, userID.(string), anyAuthor)
 	if err != nil {
-		if err.Error() == "본인의 블로그만 삭제할 수 있습니다" {
-			response.Forbidden(c, err.Error())
-		} else if err.Error() == "블로그를 찾을 수 없습니다" {
-			response.NotFound(c, err.Error())
-		} else {
-			response.BadRequest(c, err.Error())
-		}
+		response.FromError(c, err)
 		return
 	}
 
 	response.Success(c, gin.H{"message": "블로그가 삭제되었습니다"})
+}
+
+// blogPermission 수정/삭제 권한 확인 (action: update, delete)
+// blog:{action}:any가 있으면 anyAuthor=true, blog:{action}:own만 있으면 본인 글만, 둘 다 없으면 403 응답 후 ok=false
+func blogPermission(c *gin.Context, action string) (anyAuthor bool, ok bool) {
+	if middleware.HasPermission(c, "blog:"+action+":any") {
+		return true, true
+	}
+	if middleware.HasPermission(c, "blog:"+action+":own") {
+		return false, true
+	}
+
+	middleware.PermissionDenied(c, "blog:"+action+":own")
+	return false, false
 }
\ No newline at end of file
@@ -14,14 +14,14 @@ type Blog struct {
 
 // CreateBlogRequest 블로그 생성 요청
 type CreateBlogRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title   string `json:"title" label:"제목" validate:"required,min=2,max=200"`
+	Content string `json:"content" label:"내용" validate:"required,min=1,max=10000"`
 }
 
 // UpdateBlogRequest 블로그 수정 요청
 type UpdateBlogRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title   string `json:"title" label:"제목" validate:"min=2,max=200"`
+	Content string `json:"content" label:"내용" validate:"min=1,max=10000"`
 }
 
 // BlogListResponse 블로그 목록 응답
@@ -1,23 +1,26 @@
 package blog
 
 import (
+	"context"
 	"database/sql"
 	"gin_starter/internal/infrastructure/database"
+	"gin_starter/pkg/errors"
 	"time"
 )
 
 // Repository 블로그 저장소 인터페이스
 type Repository interface {
-	Create(blog *Blog) error
-	CreateTx(tx *sql.Tx, blog *Blog) error
-	FindByID(id int64) (*Blog, error)
-	FindAll(page, limit int) ([]Blog, int64, error)
-	FindByAuthorID(authorID string, page, limit int) ([]Blog, int64, error)
-	Update(id int64, updates map[string]interface{}) error
-	UpdateTx(tx *sql.Tx, id int64, updates map[string]interface{}) error
-	Delete(id int64) error
-	DeleteTx(tx *sql.Tx, id int64) error
-	Exists(id int64) (bool, error)
+	Create(ctx context.Context, blog *Blog) error
+	CreateTx(ctx context.Context, tx *sql.Tx, blog *Blog) error
+	FindByID(ctx context.Context, id int64) (*Blog, error)
+	FindAll(ctx context.Context, page, limit int) ([]Blog, int64, error)
+	FindByAuthorID(ctx context.Context, authorID string, page, limit int) ([]Blog, int64, error)
+	Update(ctx context.Context, id int64, updates map[string]interface{}) error
+	UpdateTx(ctx context.Context, tx *sql.Tx, id int64, updates map[string]interface{}) error
+	Delete(ctx context.Context, id int64) error
+	DeleteTx(ctx context.Context, tx *sql.Tx, id int64) error
+	DeleteByAuthorID(ctx context.Context, authorID string) (int64, error)
+	Exists(ctx context.Context, id int64) (bool, error)
 }
 
 type repository struct {
@@ -32,7 +35,7 @@ func NewRepository(db *database.DB) Repository {
 }
 
 // Create 블로그 생성
-func (r *repository) Create(blog *Blog) error {
+func (r *repository) Create(ctx context.Context, blog *Blog) error {
 	data := map[string]interface{}{
 		"title":      blog.Title,
 		"content":    blog.Content,
@@ -41,7 +44,7 @@ func (r *repository) Create(blog *Blog) error {
 		"updated_at": time.Now(),
 	}
 
-	id, err := r.base.Insert("_blog", data)
+	id, err := r.base.Insert(ctx, "_blog", data)
 	if err != nil {
 		return err
 	}
@@ -50,7 +53,7 @@ func (r *repository) Create(blog *Blog) error {
 }
 
 // CreateTx 트랜잭션으로 블로그 생성
-func (r *repository) CreateTx(tx *sql.Tx, blog *Blog) error {
+func (r *repository) CreateTx(ctx context.Context, tx *sql.Tx, blog *Blog) error {
 	data := map[string]interface{}{
 		"title":      blog.Title,
 		"content":    blog.Content,
@@ -59,7 +62,7 @@ func (r *repository) CreateTx(tx *sql.Tx, blog *Blog) error {
 		"updated_at": time.Now(),
 	}
 
-	id, err := r.base.InsertTx(tx, "_blog", data)
+	id, err := r.base.InsertTx(ctx, tx, "_blog", data)
 	if err != nil {
 		return err
 	}
@@ -67,81 +70,60 @@ func (r *repository) CreateTx(tx *sql.Tx, blog *Blog) error {
 	return nil
 }
 
-// FindByID ID로 블로그 조회
-func (r *repository) FindByID(id int64) (*Blog, error) {
-	query := `
-		SELECT id, title, content, author_id, created_at, updated_at
-		FROM _blog
-		WHERE id = ?
-	`
+// blogColumns 조회 컬럼 (scanBlog 순서와 동일)
+var blogColumns = []string{"id", "title", "content", "author_id", "created_at", "updated_at"}
 
+// scanBlog 조회 결과를 Blog로 변환
+func scanBlog(scan func(dest ...interface{}) error) (*Blog, error) {
 	var blog Blog
-	err := r.base.QueryRow(query, id).Scan(&blog.ID, &blog.Title, &blog.Content,
-		&blog.AuthorID, &blog.CreatedAt, &blog.UpdatedAt)
-	if err != nil {
+	if err := scan(&blog.ID, &blog.Title, &blog.Content,
+		&blog.AuthorID, &blog.CreatedAt, &blog.UpdatedAt); err != nil {
 		return nil, err
 	}
-
 	return &blog, nil
 }
 
-// FindAll 모든 블로그 조회 (페이지네이션)
-func (r *repository) FindAll(page, limit int) ([]Blog, int64, error) {
-	offset := (page - 1) * limit
-
-	// 전체 개수 조회
-	total, err := r.base.Count("_blog", "")
-	if err != nil {
-		return nil, 0, err
+// FindByID ID로 블로그 조회
+func (r *repository) FindByID(ctx context.Context, id int64) (*Blog, error) {
+	row := r.base.Select("_blog", blogColumns...).
+		Where(database.Eq("id", id)).
+		QueryRow(ctx)
+
+	blog, err := scanBlog(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrBlogNotFound
 	}
-
-	// 목록 조회
-	query := `
-		SELECT id, title, content, author_id, created_at, updated_at
-		FROM _blog
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?
-	`
-
-	rows, err := r.base.Query(query, limit, offset)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	defer rows.Close()
 
-	var blogs []Blog
-	for rows.Next() {
-		var blog Blog
-		if err := rows.Scan(&blog.ID, &blog.Title, &blog.Content,
-			&blog.AuthorID, &blog.CreatedAt, &blog.UpdatedAt); err != nil {
-			return nil, 0, err
-		}
-		blogs = append(blogs, blog)
-	}
+	return blog, nil
+}
 
-	return blogs, total, nil
+// FindAll 모든 블로그 조회 (페이지네이션)
+func (r *repository) FindAll(ctx context.Context, page, limit int) ([]Blog, int64, error) {
+	return r.findPage(ctx, nil, page, limit)
 }
 
 // FindByAuthorID 작성자 ID로 블로그 목록 조회
-func (r *repository) FindByAuthorID(authorID string, page, limit int) ([]Blog, int64, error) {
-	offset := (page - 1) * limit
+func (r *repository) FindByAuthorID(ctx context.Context, authorID string, page, limit int) ([]Blog, int64, error) {
+	return r.findPage(ctx, database.Eq("author_id", authorID), page, limit)
+}
 
+// findPage 조건에 맞는 블로그 목록과 전체 개수 조회 (최신순)
+func (r *repository) findPage(ctx context.Context, where database.Expr, page, limit int) ([]Blog, int64, error) {
 	// 전체 개수 조회
-	total, err := r.base.Count("_blog", "author_id = ?", authorID)
+	total, err := r.base.Select("_blog").Where(where).Count(ctx)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// 목록 조회
-	query := `
-		SELECT id, title, content, author_id, created_at, updated_at
-		FROM _blog
-		WHERE author_id = ?
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?
-	`
-
-	rows, err := r.base.Query(query, authorID, limit, offset)
+	rows, err := r.base.Select("_blog", blogColumns...).
+		Where(where).
+		OrderBy("created_at", database.Desc).
+		Page(page, limit).
+		Query(ctx)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -149,44 +131,51 @@ func (r *repository) FindByAuthorID(authorID string, page, limit int) ([]Blog, i
 
 	var blogs []Blog
 	for rows.Next() {
-		var blog Blog
-		if err := rows.Scan(&blog.ID, &blog.Title, &blog.Content,
-			&blog.AuthorID, &blog.CreatedAt, &blog.UpdatedAt); err != nil {
+		blog, err := scanBlog(rows.Scan)
+		if err != nil {
 			return nil, 0, err
 		}
-		blogs = append(blogs, blog)
+		blogs = append(blogs, *blog)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
 	}
 
 	return blogs, total, nil
 }
 
 // Update 블로그 수정
-func (r *repository) Update(id int64, updates map[string]interface{}) error {
+func (r *repository) Update(ctx context.Context, id int64, updates map[string]interface{}) error {
 	updates["updated_at"] = time.Now()
-	_, err := r.base.Update("_blog", updates, "id = ?", id)
+	_, err := r.base.Update(ctx, "_blog", updates, "id = ?", id)
 	return err
 }
 
 // UpdateTx 트랜잭션으로 블로그 수정
-func (r *repository) UpdateTx(tx *sql.Tx, id int64, updates map[string]interface{}) error {
+func (r *repository) UpdateTx(ctx context.Context, tx *sql.Tx, id int64, updates map[string]interface{}) error {
 	updates["updated_at"] = time.Now()
-	_, err := r.base.UpdateTx(tx, "_blog", updates, "id = ?", id)
+	_, err := r.base.UpdateTx(ctx, tx, "_blog", updates, "id = ?", id)
 	return err
 }
 
 // Delete 블로그 삭제
-func (r *repository) Delete(id int64) error {
-	_, err := r.base.Delete("_blog", "id = ?", id)
+func (r *repository) Delete(ctx context.Context, id int64) error {
+	_, err := r.base.Delete(ctx, "_blog", "id = ?", id)
 	return err
 }
 
 // DeleteTx 트랜잭션으로 블로그 삭제
-func (r *repository) DeleteTx(tx *sql.Tx, id int64) error {
-	_, err := r.base.DeleteTx(tx, "_blog", "id = ?", id)
+func (r *repository) DeleteTx(ctx context.Context, tx *sql.Tx, id int64) error {
+	_, err := r.base.DeleteTx(ctx, tx, "_blog", "id = ?", id)
 	return err
 }
 
+// DeleteByAuthorID 작성자의 블로그 전체 삭제 (삭제된 개수 반환)
+func (r *repository) DeleteByAuthorID(ctx context.Context, authorID string) (int64, error) {
+	return r.base.Delete(ctx, "_blog", "author_id = ?", authorID)
+}
+
 // Exists 블로그 존재 여부 확인
-func (r *repository) Exists(id int64) (bool, error) {
-	return r.base.Exists("_blog", "id = ?", id)
-}
\ No newline at end of file
+func (r *repository) Exists(ctx context.Context, id int64) (bool, error) {
+	return r.base.Exists(ctx, "_blog", "id = ?", id)
+}
@@ -1,19 +1,21 @@
 package blog
 
 import (
+	"context"
 	"fmt"
 	"gin_starter/pkg/errors"
 	"gin_starter/pkg/logger"
+	"unicode/utf8"
 )
 
 // Service 블로그 비즈니스 로직 인터페이스
 type Service interface {
-	CreateBlog(authorID string, req *CreateBlogRequest) (*Blog, error)
-	GetBlog(id int64) (*Blog, error)
-	GetBlogs(page, limit int) (*BlogListResponse, error)
-	GetBlogsByAuthor(authorID string, page, limit int) (*BlogListResponse, error)
-	UpdateBlog(id int64, authorID string, req *UpdateBlogRequest) (*Blog, error)
-	DeleteBlog(id int64, authorID string) error
+	CreateBlog(ctx context.Context, authorID string, req *CreateBlogRequest) (*Blog, error)
+	GetBlog(ctx context.Context, id int64) (*Blog, error)
+	GetBlogs(ctx context.Context, page, limit int) (*BlogListResponse, error)
+	GetBlogsByAuthor(ctx context.Context, authorID string, page, limit int) (*BlogListResponse, error)
+	UpdateBlog(ctx context.Context, id int64, authorID string, anyAuthor bool, req *UpdateBlogRequest) (*Blog, error)
+	DeleteBlog(ctx context.Context, id int64, authorID string, anyAuthor bool) error
 }
 
 type service struct {
@@ -28,12 +30,12 @@ func NewService(repo Repository) Service {
 }
 
 // CreateBlog 블로그 생성
-func (s *service) CreateBlog(authorID string, req *CreateBlogRequest) (*Blog, error) {
+func (s *service) CreateBlog(ctx context.Context, authorID string, req *CreateBlogRequest) (*Blog, error) {
 	// 제목 검증
 	if req.Title == "" {
 		return nil, errors.New("TITLE_REQUIRED", "제목은 필수입니다")
 	}
-	if len(req.Title) < 2 || len(req.Title) > 200 {
+	if utf8.RuneCountInString(req.Title) < 2 || utf8.RuneCountInString(req.Title) > 200 {
 		return nil, errors.New("TITLE_LENGTH", "제목은 2-200자 사이여야 합니다")
 	}
 
@@ -41,7 +43,7 @@ func (s *service) CreateBlog(authorID string, req *CreateBlogRequest) (*Blog, er
 	if req.Content == "" {
 		return nil, errors.New("CONTENT_REQUIRED", "내용은 필수입니다")
 	}
-	if len(req.Content) > 10000 {
+	if utf8.RuneCountInString(req.Content) > 10000 {
 		return nil, errors.New("CONTENT_LENGTH", "내용은 10000자를 초과할 수 없습니다")
 	}
 
@@ -52,27 +54,27 @@ func (s *service) CreateBlog(authorID string, req *CreateBlogRequest) (*Blog, er
 		AuthorID: authorID,
 	}
 
-	if err := s.repo.Create(blog); err != nil {
-		logger.Error("블로그 생성 실패: %v", err)
+	if err := s.repo.Create(ctx, blog); err != nil {
+		logger.FromContext(ctx).Error("블로그 생성 실패: %v", err)
 		return nil, errors.Wrap(err, "BLOG_CREATE_FAILED", "블로그 생성에 실패했습니다")
 	}
 
-	logger.Info("블로그 생성 성공: %d (작성자: %s)", blog.ID, authorID)
+	logger.FromContext(ctx).Info("블로그 생성 성공: %d (작성자: %s)", blog.ID, authorID)
 	return blog, nil
 }
 
 // GetBlog 블로그 조회
-func (s *service) GetBlog(id int64) (*Blog, error) {
-	blog, err := s.repo.FindByID(id)
+func (s *service) GetBlog(ctx context.Context, id int64) (*Blog, error) {
+	blog, err := s.repo.FindByID(ctx, id)
 	if err != nil {
-		return nil, errors.New("BLOG_NOT_FOUND", "블로그를 찾을 수 없습니다")
+		return nil, err
 	}
 
 	return blog, nil
 }
 
 // GetBlogs 블로그 목록 조회
-func (s *service) GetBlogs(page, limit int) (*BlogListResponse, error) {
+func (s *service) GetBlogs(ctx context.Context, page, limit int) (*BlogListResponse, error) {
 	// 페이지네이션 검증
 	if page < 1 {
 		page = 1
@@ -81,9 +83,9 @@ func (s *service) GetBlogs(page, limit int) (*BlogListResponse, error) {
 		limit = 20
 	}
 
-	blogs, total, err := s.repo.FindAll(page, limit)
+	blogs, total, err := s.repo.FindAll(ctx, page, limit)
 	if err != nil {
-		logger.Error("블로그 목록 조회 실패: %v", err)
+		logger.FromContext(ctx).Error("블로그 목록 조회 실패: %v", err)
 		return nil, errors.Wrap(err, "BLOG_LIST_FAILED", "블로그 목록 조회에 실패했습니다")
 	}
 
@@ -96,7 +98,7 @@ func (s *service) GetBlogs(page, limit int) (*BlogListResponse, error) {
 }
 
 // GetBlogsByAuthor 작성자별 블로그 목록 조회
-func (s *service) GetBlogsByAuthor(authorID string, page, limit int) (*BlogListResponse, error) {
+func (s *service) GetBlogsByAuthor(ctx context.Context, authorID string, page, limit int) (*BlogListResponse, error) {
 	// 페이지네이션 검증
 	if page < 1 {
 		page = 1
@@ -105,9 +107,9 @@ func (s *service) GetBlogsByAuthor(authorID string, page, limit int) (*BlogListR
 		limit = 20
 	}
 
-	blogs, total, err := s.repo.FindByAuthorID(authorID, page, limit)
+	blogs, total, err := s.repo.FindByAuthorID(ctx, authorID, page, limit)
 	if err != nil {
-		logger.Error("작성자별 블로그 목록 조회 실패: %v", err)
+		logger.FromContext(ctx).Error("작성자별 블로그 목록 조회 실패: %v", err)
 		return nil, errors.Wrap(err, "BLOG_LIST_FAILED", "블로그 목록 조회에 실패했습니다")
 	}
 
@@ -119,29 +121,29 @@ func (s *service) GetBlogsByAuthor(authorID string, page, limit int) (*BlogListR
 	}, nil
 }
 
-// UpdateBlog 블로그 수정
-func (s *service) UpdateBlog(id int64, authorID string, req *UpdateBlogRequest) (*Blog, error) {
+// UpdateBlog 블로그 수정 (anyAuthor: 다른 사용자의 글도 수정 가능 - blog:update:any)
+func (s *service) UpdateBlog(ctx context.Context, id int64, authorID string, anyAuthor bool, req *UpdateBlogRequest) (*Blog, error) {
 	// 블로그 존재 확인
-	blog, err := s.repo.FindByID(id)
+	blog, err := s.repo.FindByID(ctx, id)
 	if err != nil {
-		return nil, errors.New("BLOG_NOT_FOUND", "블로그를 찾을 수 없습니다")
+		return nil, err
 	}
 
 	// 작성자 확인
-	if blog.AuthorID != authorID {
-		return nil, errors.New("FORBIDDEN", "본인의 블로그만 수정할 수 있습니다")
+	if !anyAuthor && blog.AuthorID != authorID {
+		return nil, errors.ErrForbidden.WithMessage("본인의 블로그만 수정할 수 있습니다")
 	}
 
 	// 수정 데이터 준비
 	updates := make(map[string]interface{})
 	if req.Title != "" {
-		if len(req.Title) < 2 || len(req.Title) > 200 {
+		if utf8.RuneCountInString(req.Title) < 2 || utf8.RuneCountInString(req.Title) > 200 {
 			return nil, errors.New("TITLE_LENGTH", "제목은 2-200자 사이여야 합니다")
 		}
 		updates["title"] = req.Title
 	}
 	if req.Content != "" {
-		if len(req.Content) > 10000 {
+		if utf8.RuneCountInString(req.Content) > 10000 {
 			return nil, errors.New("CONTENT_LENGTH", "내용은 10000자를 초과할 수 없습니다")
 		}
 		updates["content"] = req.Content
@@ -153,49 +155,49 @@ func (s *service) UpdateBlog(id int64, authorID string, req *UpdateBlogRequest)
 	}
 
 	// 업데이트
-	if err := s.repo.Update(id, updates); err != nil {
-		logger.Error("블로그 수정 실패: %v", err)
+	if err := s.repo.Update(ctx, id, updates); err != nil {
+		logger.FromContext(ctx).Error("블로그 수정 실패: %v", err)
 		return nil, errors.Wrap(err, "BLOG_UPDATE_FAILED", "블로그 수정에 실패했습니다")
 	}
 
 	// 수정된 블로그 조회
-	updatedBlog, err := s.repo.FindByID(id)
+	updatedBlog, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Info("블로그 수정 성공: %d (작성자: %s)", id, authorID)
+	logger.FromContext(ctx).Info("블로그 수정 성공: %d (작성자: %s, 수정: %s)", id, blog.AuthorID, authorID)
 	return updatedBlog, nil
 }
 
-// DeleteBlog 블로그 삭제
-func (s *service) DeleteBlog(id int64, authorID string) error {
+// DeleteBlog 블로그 삭제 (anyAuthor: 다른 사용자의 글도 삭제 가능 - blog:delete:any)
+func (s *service) DeleteBlog(ctx context.Context, id int64, authorID string, anyAuthor bool) error {
 	// 블로그 존재 확인
-	blog, err := s.repo.FindByID(id)
+	blog, err := s.repo.FindByID(ctx, id)
 	if err != nil {
-		return errors.New("BLOG_NOT_FOUND", "블로그를 찾을 수 없습니다")
+		return err
 	}
 
 	// 작성자 확인
-	if blog.AuthorID != authorID {
-		return errors.New("FORBIDDEN", "본인의 블로그만 삭제할 수 있습니다")
+	if !anyAuthor && blog.AuthorID != authorID {
+		return errors.ErrForbidden.WithMessage("본인의 블로그만 삭제할 수 있습니다")
 	}
 
 	// 삭제
-	if err := s.repo.Delete(id); err != nil {
-		logger.Error("블로그 삭제 실패: %v", err)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		logger.FromContext(ctx).Error("블로그 삭제 실패: %v", err)
 		return errors.Wrap(err, "BLOG_DELETE_FAILED", "블로그 삭제에 실패했습니다")
 	}
 
-	logger.Info("블로그 삭제 성공: %d (작성자: %s)", id, authorID)
+	logger.FromContext(ctx).Info("블로그 삭제 성공: %d (작성자: %s, 삭제: %s)", id, blog.AuthorID, authorID)
 	return nil
 }
 
 // ValidateBlogAccess 블로그 접근 권한 검증 (헬퍼 함수)
-func (s *service) ValidateBlogAccess(id int64, authorID string) error {
-	blog, err := s.repo.FindByID(id)
+func (s *service) ValidateBlogAccess(ctx context.Context, id int64, authorID string) error {
+	blog, err := s.repo.FindByID(ctx, id)
 	if err != nil {
-		return errors.New("BLOG_NOT_FOUND", "블로그를 찾을 수 없습니다")
+		return err
 	}
 
 	if blog.AuthorID != authorID {
@@ -0,0 +1,232 @@
+package role
+
+import (
+	"gin_starter/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler 역할, 권한 관리 HTTP 핸들러 (관리자 API)
+type Handler struct {
+	service Service
+}
+
+// NewHandler 역할 핸들러 생성
+func NewHandler(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ListRoles 역할 목록
+// @Summary      역할 목록 (관리자)
+// @Description  역할별 부여된 권한과 사용자 수를 조회합니다
+// @Tags         admin
+// @Produce      json
+// @Success      200 {object} response.Response{data=[]Role}
+// @Failure      403 {object} response.Response "PERMISSION_DENIED"
+// @Security     BearerAuth
+// @Router       /api/admin/roles [get]
+func (h *Handler) ListRoles(c *gin.Context) {
+	roles, err := h.service.ListRoles(c.Request.Context())
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, roles)
+}
+
+// GetRole 역할 상세
+// @Summary      역할 상세 (관리자)
+// @Tags         admin
+// @Produce      json
+// @Param        code path string true "역할 코드"
+// @Success      200 {object} response.Response{data=Role}
+// @Failure      404 {object} response.Response "ROLE_NOT_FOUND"
+// @Security     BearerAuth
+// @Router       /api/admin/roles/{code} [get]
+func (h *Handler) GetRole(c *gin.Context) {
+	role, err := h.service.GetRole(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, role)
+}
+
+// CreateRole 역할 생성
+// @Summary      역할 생성 (관리자)
+// @Description  새 역할을 만들고 권한을 부여합니다. 권한은 권한 목록에 있는 것이나 와일드카드(*, blog:*)만 쓸 수 있습니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request body CreateRoleRequest true "역할 정보"
+// @Success      201 {object} response.Response{data=Role}
+// @Failure      400 {object} response.Response "INVALID_ROLE_CODE, INVALID_PERMISSION"
+// @Failure      409 {object} response.Response "ROLE_EXISTS"
+// @Security     BearerAuth
+// @Router       /api/admin/roles [post]
+func (h *Handler) CreateRole(c *gin.Context) {
+	var req CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "잘못된 요청 형식입니다")
+		return
+	}
+
+	role, err := h.service.CreateRole(c.Request.Context(), &req)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Created(c, role)
+}
+
+// UpdateRole 역할 수정
+// @Summary      역할 수정 (관리자)
+// @Description  역할 이름과 설명을 수정합니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        code path string true "역할 코드"
+// @Param        request body UpdateRoleRequest true "수정할 정보"
+// @Success      200 {object} response.Response{data=Role}
+// @Failure      400 {object} response.Response
+// @Failure      404 {object} response.Response "ROLE_NOT_FOUND"
+// @Security     BearerAuth
+// @Router       /api/admin/roles/{code} [put]
+func (h *Handler) UpdateRole(c *gin.Context) {
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "잘못된 요청 형식입니다")
+		return
+	}
+
+	role, err := h.service.UpdateRole(c.Request.Context(), c.Param("code"), &req)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, role)
+}
+
+// DeleteRole 역할 삭제
+// @Summary      역할 삭제 (관리자)
+// @Description  사용자에게 부여되지 않은 역할을 삭제합니다. 기본 역할(U, A)은 삭제할 수 없습니다
+// @Tags         admin
+// @Produce      json
+// @Param        code path string true "역할 코드"
+// @Success      200 {object} response.Response
+// @Failure      404 {object} response.Response "ROLE_NOT_FOUND"
+// @Failure      409 {object} response.Response "ROLE_PROTECTED, ROLE_IN_USE"
+// @Security     BearerAuth
+// @Router       /api/admin/roles/{code} [delete]
+func (h *Handler) DeleteRole(c *gin.Context) {
+	if err := h.service.DeleteRole(c.Request.Context(), c.Param("code")); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "역할이 삭제되었습니다"})
+}
+
+// SetPermissions 역할 권한 변경
+// @Summary      역할 권한 변경 (관리자)
+// @Description  역할에 부여된 권한 목록 전체를 바꿉니다. 관리자 역할(A)은 바꿀 수 없습니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        code path string true "역할 코드"
+// @Param        request body SetPermissionsRequest true "권한 목록"
+// @Success      200 {object} response.Response{data=Role}
+// @Failure      400 {object} response.Response "INVALID_PERMISSION"
+// @Failure      404 {object} response.Response "ROLE_NOT_FOUND"
+// @Failure      409 {object} response.Response "ROLE_PROTECTED"
+// @Security     BearerAuth
+// @Router       /api/admin/roles/{code}/permissions [put]
+func (h *Handler) SetPermissions(c *gin.Context) {
+	var req SetPermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "잘못된 요청 형식입니다")
+		return
+	}
+
+	role, err := h.service.SetPermissions(c.Request.Context(), c.Param("code"), req.Permissions)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, role)
+}
+
+// ListPermissions 권한 목록
+// @Summary      권한 목록 (관리자)
+// @Description  역할에 부여할 수 있는 권한 목록을 조회합니다
+// @Tags         admin
+// @Produce      json
+// @Success      200 {object} response.Response{data=[]Permission}
+// @Security     BearerAuth
+// @Router       /api/admin/permissions [get]
+func (h *Handler) ListPermissions(c *gin.Context) {
+	permissions, err := h.service.ListPermissions(c.Request.Context())
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, permissions)
+}
+
+// GetUserRoles 사용자 역할 조회
+// @Summary      사용자 역할 (관리자)
+// @Description  사용자에게 부여된 역할과 그에 따른 권한을 조회합니다
+// @Tags         admin
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Success      200 {object} response.Response{data=UserRolesResponse}
+// @Failure      404 {object} response.Response
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/roles [get]
+func (h *Handler) GetUserRoles(c *gin.Context) {
+	result, err := h.service.GetUserRoles(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// SetUserRoles 사용자 역할 변경
+// @Summary      사용자 역할 변경 (관리자)
+// @Description  사용자 역할 전체를 바꿉니다. 첫 번째 역할이 대표 역할(auth_type)이 되고, 사용자의 액세스 토큰은 폐기됩니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "사용자 ID"
+// @Param        request body SetUserRolesRequest true "역할 목록"
+// @Success      200 {object} response.Response{data=UserRolesResponse}
+// @Failure      400 {object} response.Response "ROLES_REQUIRED"
+// @Failure      404 {object} response.Response "USER_NOT_FOUND, ROLE_NOT_FOUND"
+// @Failure      409 {object} response.Response "ROLE_SELF_LOCKOUT"
+// @Security     BearerAuth
+// @Router       /api/admin/users/{id}/roles [put]
+func (h *Handler) SetUserRoles(c *gin.Context) {
+	var req SetUserRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "잘못된 요청 형식입니다")
+		return
+	}
+
+	result, err := h.service.SetUserRoles(c.Request.Context(), c.Param("id"), req.Roles, c.GetString("user_id"))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,57 @@
+package role
+
+import "time"
+
+// 기본 역할 코드 (u_auth_type과 같은 값)
+const (
+	RoleUser  = "U" // 일반 사용자 (가입 시 부여)
+	RoleAdmin = "A" // 관리자 (모든 권한)
+)
+
+// Role 역할
+type Role struct {
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	System      bool      `json:"system"`      // 기본 역할 (삭제, 권한 변경 불가)
+	Permissions []string  `json:"permissions"` // 부여된 권한 (와일드카드 포함)
+	UserCount   int64     `json:"user_count"`  // 이 역할을 가진 사용자 수
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Permission 권한 목록 항목
+type Permission struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// CreateRoleRequest 역할 생성 요청
+type CreateRoleRequest struct {
+	Code        string   `json:"code"` // 영문 대문자, 숫자, _ (최대 10자)
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateRoleRequest 역할 수정 요청 (빈 값은 그대로)
+type UpdateRoleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SetPermissionsRequest 역할 권한 변경 요청 (목록 전체를 바꿈)
+type SetPermissionsRequest struct {
+	Permissions []string `json:"permissions"`
+}
+
+// SetUserRolesRequest 사용자 역할 변경 요청 (첫 번째가 대표 역할)
+type SetUserRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+// UserRolesResponse 사용자 역할과 권한
+type UserRolesResponse struct {
+	UserID      string   `json:"user_id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
@@ -0,0 +1,22 @@
+package role
+
+import (
+	"context"
+	"gin_starter/internal/infrastructure/database"
+	"gin_starter/pkg/rbac"
+)
+
+// policyStore 역할별 권한 DB 저장소 (_role_permissions)
+type policyStore struct {
+	repo Repository
+}
+
+// NewPolicyStore 역할별 권한 캐시(rbac.Policy)가 읽을 저장소 생성
+func NewPolicyStore(db *database.DB) rbac.Store {
+	return &policyStore{repo: NewRepository(db)}
+}
+
+// LoadGrants 역할 코드 -> 부여된 권한 목록
+func (s *policyStore) LoadGrants(ctx context.Context) (map[string][]string, error) {
+	return s.repo.FindGrants(ctx)
+}
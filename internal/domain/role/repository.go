@@ -0,0 +1,285 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"gin_starter/internal/infrastructure/database"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"time"
+)
+
+// Repository 역할, 권한 저장소 인터페이스
+type Repository interface {
+	FindAll(ctx context.Context) ([]Role, error)
+	FindByCode(ctx context.Context, code string) (*Role, error)
+	Create(ctx context.Context, role *Role) error
+	Update(ctx context.Context, code string, updates map[string]interface{}) error
+	Delete(ctx context.Context, code string) error
+	CountUsers(ctx context.Context, code string) (int64, error)
+
+	// 권한 목록, 역할별 권한
+	FindPermissions(ctx context.Context) ([]Permission, error)
+	FindGrants(ctx context.Context) (map[string][]string, error)
+	ReplacePermissions(ctx context.Context, code string, permissions []string, at time.Time) error
+}
+
+type repository struct {
+	db   *database.DB
+	base *database.Repository
+}
+
+// NewRepository 역할 저장소 생성
+func NewRepository(db *database.DB) Repository {
+	return &repository{
+		db:   db,
+		base: database.NewRepository(db),
+	}
+}
+
+// roleColumns 조회 컬럼 (scanRole 순서와 동일)
+var roleColumns = []string{"r_code", "r_name", "r_description", "r_system", "r_regi_date"}
+
+// scanRole 조회 결과를 Role로 변환
+func scanRole(scan func(dest ...interface{}) error) (*Role, error) {
+	var role Role
+	var description sql.NullString
+	if err := scan(&role.Code, &role.Name, &description, &role.System, &role.CreatedAt); err != nil {
+		return nil, err
+	}
+	role.Description = description.String
+	return &role, nil
+}
+
+// FindAll 모든 역할 (권한, 사용자 수 포함)
+func (r *repository) FindAll(ctx context.Context) ([]Role, error) {
+	rows, err := r.base.Select("_roles", roleColumns...).
+		OrderBy("r_system", database.Desc).
+		OrderBy("r_code", database.Asc).
+		Query(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("역할 목록 조회 실패: %v", err)
+		return nil, errors.Wrap(err, "ROLE_LIST_FAILED", "역할 목록 조회에 실패했습니다")
+	}
+	defer rows.Close()
+
+	var roles []Role
+	for rows.Next() {
+		role, err := scanRole(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, *role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	grants, err := r.FindGrants(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := r.countUsersByRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range roles {
+		roles[i].Permissions = grants[roles[i].Code]
+		roles[i].UserCount = counts[roles[i].Code]
+	}
+
+	return roles, nil
+}
+
+// FindByCode 역할 코드로 조회 (권한, 사용자 수 포함)
+func (r *repository) FindByCode(ctx context.Context, code string) (*Role, error) {
+	role, err := scanRole(r.base.Select("_roles", roleColumns...).
+		Where(database.Eq("r_code", code)).
+		QueryRow(ctx).Scan)
+
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrRoleNotFound
+	}
+
+	if err != nil {
+		logger.FromContext(ctx).Error("역할 조회 실패 (%s): %v", code, err)
+		return nil, errors.Wrap(err, "ROLE_FIND_FAILED", "역할 조회에 실패했습니다")
+	}
+
+	grants, err := r.FindGrants(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role.Permissions = grants[code]
+
+	if role.UserCount, err = r.CountUsers(ctx, code); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// Create 역할과 부여할 권한을 하나의 트랜잭션으로 생성
+func (r *repository) Create(ctx context.Context, role *Role) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		data := map[string]interface{}{
+			"r_code":        role.Code,
+			"r_name":        role.Name,
+			"r_description": role.Description,
+			"r_system":      role.System,
+			"r_regi_date":   role.CreatedAt,
+		}
+		if _, err := r.base.Insert(ctx, "_roles", data); err != nil {
+			return err
+		}
+
+		return r.insertPermissions(ctx, role.Code, role.Permissions, role.CreatedAt)
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("역할 생성 실패 (%s): %v", role.Code, err)
+		return errors.Wrap(err, "ROLE_CREATE_FAILED", "역할 생성에 실패했습니다")
+	}
+
+	return nil
+}
+
+// Update 역할 이름, 설명 수정
+func (r *repository) Update(ctx context.Context, code string, updates map[string]interface{}) error {
+	if _, err := r.base.Update(ctx, "_roles", updates, "r_code = ?", code); err != nil {
+		logger.FromContext(ctx).Error("역할 수정 실패 (%s): %v", code, err)
+		return errors.Wrap(err, "ROLE_UPDATE_FAILED", "역할 수정에 실패했습니다")
+	}
+
+	return nil
+}
+
+// Delete 역할과 부여된 권한 삭제
+func (r *repository) Delete(ctx context.Context, code string) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.base.Delete(ctx, "_role_permissions", "rp_role = ?", code); err != nil {
+			return err
+		}
+		_, err := r.base.Delete(ctx, "_roles", "r_code = ?", code)
+		return err
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("역할 삭제 실패 (%s): %v", code, err)
+		return errors.Wrap(err, "ROLE_DELETE_FAILED", "역할 삭제에 실패했습니다")
+	}
+
+	return nil
+}
+
+// CountUsers 역할을 가진 사용자 수
+func (r *repository) CountUsers(ctx context.Context, code string) (int64, error) {
+	count, err := r.base.Select("_user_roles").Where(database.Eq("ur_role", code)).Count(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("역할 사용자 수 조회 실패 (%s): %v", code, err)
+		return 0, errors.Wrap(err, "ROLE_COUNT_FAILED", "역할 사용자 수 조회에 실패했습니다")
+	}
+
+	return count, nil
+}
+
+// countUsersByRole 역할별 사용자 수
+func (r *repository) countUsersByRole(ctx context.Context) (map[string]int64, error) {
+	rows, err := r.base.Query(ctx, "SELECT ur_role, COUNT(*) FROM _user_roles GROUP BY ur_role")
+	if err != nil {
+		logger.FromContext(ctx).Error("역할별 사용자 수 조회 실패: %v", err)
+		return nil, errors.Wrap(err, "ROLE_COUNT_FAILED", "역할 사용자 수 조회에 실패했습니다")
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var code string
+		var count int64
+		if err := rows.Scan(&code, &count); err != nil {
+			return nil, err
+		}
+		counts[code] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// FindPermissions 권한 목록 (마이그레이션으로 등록한 권한)
+func (r *repository) FindPermissions(ctx context.Context) ([]Permission, error) {
+	rows, err := r.base.Select("_permissions", "p_code", "p_description").
+		OrderBy("p_code", database.Asc).
+		Query(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("권한 목록 조회 실패: %v", err)
+		return nil, errors.Wrap(err, "PERMISSION_LIST_FAILED", "권한 목록 조회에 실패했습니다")
+	}
+	defer rows.Close()
+
+	var permissions []Permission
+	for rows.Next() {
+		var p Permission
+		var description sql.NullString
+		if err := rows.Scan(&p.Code, &description); err != nil {
+			return nil, err
+		}
+		p.Description = description.String
+		permissions = append(permissions, p)
+	}
+
+	return permissions, rows.Err()
+}
+
+// FindGrants 역할 코드 -> 부여된 권한 목록
+func (r *repository) FindGrants(ctx context.Context) (map[string][]string, error) {
+	rows, err := r.base.Select("_role_permissions", "rp_role", "rp_permission").
+		OrderBy("rp_role", database.Asc).
+		OrderBy("rp_permission", database.Asc).
+		Query(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("역할 권한 조회 실패: %v", err)
+		return nil, errors.Wrap(err, "ROLE_GRANT_FIND_FAILED", "역할 권한 조회에 실패했습니다")
+	}
+	defer rows.Close()
+
+	grants := make(map[string][]string)
+	for rows.Next() {
+		var role, permission string
+		if err := rows.Scan(&role, &permission); err != nil {
+			return nil, err
+		}
+		grants[role] = append(grants[role], permission)
+	}
+
+	return grants, rows.Err()
+}
+
+// ReplacePermissions 역할의 권한을 모두 바꿈
+func (r *repository) ReplacePermissions(ctx context.Context, code string, permissions []string, at time.Time) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.base.Delete(ctx, "_role_permissions", "rp_role = ?", code); err != nil {
+			return err
+		}
+		return r.insertPermissions(ctx, code, permissions, at)
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("역할 권한 저장 실패 (%s): %v", code, err)
+		return errors.Wrap(err, "ROLE_GRANT_UPDATE_FAILED", "역할 권한 저장에 실패했습니다")
+	}
+
+	return nil
+}
+
+// insertPermissions 역할에 권한 추가 (트랜잭션 안에서 호출)
+func (r *repository) insertPermissions(ctx context.Context, code string, permissions []string, at time.Time) error {
+	for _, permission := range permissions {
+		data := map[string]interface{}{
+			"rp_role":       code,
+			"rp_permission": permission,
+			"rp_regi_date":  at,
+		}
+		if _, err := r.base.Insert(ctx, "_role_permissions", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package role
+
+import (
+	"context"
+	"gin_starter/internal/domain/user"
+	"gin_starter/internal/middleware"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/rbac"
+	"gin_starter/pkg/revocation"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// codePattern 역할 코드 형식 (u_auth_type 컬럼 길이에 맞춰 10자 이하)
+var codePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,9}$`)
+
+// Service 역할, 권한 관리 인터페이스
+type Service interface {
+	ListRoles(ctx context.Context) ([]Role, error)
+	GetRole(ctx context.Context, code string) (*Role, error)
+	CreateRole(ctx context.Context, req *CreateRoleRequest) (*Role, error)
+	UpdateRole(ctx context.Context, code string, req *UpdateRoleRequest) (*Role, error)
+	DeleteRole(ctx context.Context, code string) error
+	SetPermissions(ctx context.Context, code string, permissions []string) (*Role, error)
+	ListPermissions(ctx context.Context) ([]Permission, error)
+
+	// 사용자 역할
+	GetUserRoles(ctx context.Context, userID string) (*UserRolesResponse, error)
+	SetUserRoles(ctx context.Context, userID string, roles []string, adminID string) (*UserRolesResponse, error)
+}
+
+type service struct {
+	repo       Repository
+	userRepo   user.Repository
+	policy     *rbac.Policy
+	principals *middleware.PrincipalCache
+	revoked    *revocation.List
+	accessTTL  time.Duration
+}
+
+// NewService 역할 서비스 생성
+// policy, principals: 역할이나 권한을 바꾸면 캐시를 비워 이 인스턴스에는 바로 반영
+// revoked: 사용자 역할을 바꾸면 이전 역할로 발급된 액세스 토큰 폐기
+func NewService(repo Repository, userRepo user.Repository, policy *rbac.Policy, principals *middleware.PrincipalCache, revoked *revocation.List, accessTTL time.Duration) Service {
+	return &service{
+		repo:       repo,
+		userRepo:   userRepo,
+		policy:     policy,
+		principals: principals,
+		revoked:    revoked,
+		accessTTL:  accessTTL,
+	}
+}
+
+// ListRoles 모든 역할
+func (s *service) ListRoles(ctx context.Context) ([]Role, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// GetRole 역할 상세
+func (s *service) GetRole(ctx context.Context, code string) (*Role, error) {
+	return s.repo.FindByCode(ctx, code)
+}
+
+// CreateRole 역할 생성
+func (s *service) CreateRole(ctx context.Context, req *CreateRoleRequest) (*Role, error) {
+	code := strings.TrimSpace(req.Code)
+	if !codePattern.MatchString(code) {
+		return nil, errors.New("INVALID_ROLE_CODE", "역할 코드는 영문 대문자로 시작하고 대문자, 숫자, _로 10자 이하여야 합니다")
+	}
+	if err := validateName(req.Name); err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.normalizePermissions(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.FindByCode(ctx, code); err == nil {
+		return nil, errors.ErrRoleExists
+	} else if !errors.Is(err, errors.ErrRoleNotFound) {
+		return nil, err
+	}
+
+	role := &Role{
+		Code:        code,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	s.policy.Invalidate()
+
+	logger.FromContext(ctx).Info("역할 생성: %s (권한 %d개)", code, len(permissions))
+	return s.repo.FindByCode(ctx, code)
+}
+
+// UpdateRole 역할 이름, 설명 수정
+func (s *service) UpdateRole(ctx context.Context, code string, req *UpdateRoleRequest) (*Role, error) {
+	if _, err := s.repo.FindByCode(ctx, code); err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		if err := validateName(req.Name); err != nil {
+			return nil, err
+		}
+		updates["r_name"] = strings.TrimSpace(req.Name)
+	}
+	if req.Description != "" {
+		updates["r_description"] = strings.TrimSpace(req.Description)
+	}
+
+	if len(updates) == 0 {
+		return nil, errors.New("NO_UPDATE_DATA", "수정할 내용이 없습니다")
+	}
+
+	if err := s.repo.Update(ctx, code, updates); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("역할 수정: %s", code)
+	return s.repo.FindByCode(ctx, code)
+}
+
+// DeleteRole 역할 삭제 (기본 역할, 사용자에게 부여된 역할은 삭제 불가)
+func (s *service) DeleteRole(ctx context.Context, code string) error {
+	role, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if role.System {
+		return errors.ErrRoleProtected
+	}
+	if role.UserCount > 0 {
+		return errors.ErrRoleInUse.WithMeta("user_count", role.UserCount)
+	}
+
+	if err := s.repo.Delete(ctx, code); err != nil {
+		return err
+	}
+
+	s.policy.Invalidate()
+
+	logger.FromContext(ctx).Info("역할 삭제: %s", code)
+	return nil
+}
+
+// SetPermissions 역할의 권한을 모두 바꿈 (관리자 역할은 항상 모든 권한)
+// 이 역할을 가진 사용자 모두에게 영향이 있으므로 권한 정보 캐시 전체를 비운다
+// 다른 인스턴스에는 AUTH_POLICY_CACHE_TTL + AUTH_CACHE_TTL 안에 반영된다
+func (s *service) SetPermissions(ctx context.Context, code string, permissions []string) (*Role, error) {
+	if _, err := s.repo.FindByCode(ctx, code); err != nil {
+		return nil, err
+	}
+	if code == RoleAdmin {
+		return nil, errors.ErrRoleProtected
+	}
+
+	permissions, err := s.normalizePermissions(ctx, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplacePermissions(ctx, code, permissions, time.Now()); err != nil {
+		return nil, err
+	}
+
+	s.policy.Invalidate()
+	s.principals.InvalidateAll()
+
+	logger.FromContext(ctx).Info("역할 권한 변경: %s (%s)", code, strings.Join(permissions, ", "))
+	return s.repo.FindByCode(ctx, code)
+}
+
+// ListPermissions 부여할 수 있는 권한 목록
+func (s *service) ListPermissions(ctx context.Context) ([]Permission, error) {
+	return s.repo.FindPermissions(ctx)
+}
+
+// GetUserRoles 사용자 역할과 권한
+func (s *service) GetUserRoles(ctx context.Context, userID string) (*UserRolesResponse, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.userRepo.FindRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userRoles(ctx, userID, roles)
+}
+
+// SetUserRoles 사용자 역할을 모두 바꿈 (첫 번째 역할이 대표 역할 u_auth_type)
+// 자신의 역할 관리 권한(role:manage)이 없어지는 변경은 거부한다
+func (s *service) SetUserRoles(ctx context.Context, userID string, roles []string, adminID string) (*UserRolesResponse, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	roles = dedupe(roles)
+	if len(roles) == 0 {
+		return nil, errors.New("ROLES_REQUIRED", "역할을 하나 이상 지정해야 합니다")
+	}
+	for _, code := range roles {
+		if _, err := s.repo.FindByCode(ctx, code); err != nil {
+			if errors.Is(err, errors.ErrRoleNotFound) {
+				return nil, errors.ErrRoleNotFound.WithMeta("role", code)
+			}
+			return nil, err
+		}
+	}
+
+	result, err := s.userRoles(ctx, userID, roles)
+	if err != nil {
+		return nil, err
+	}
+	if userID == adminID && !rbac.Has(result.Permissions, "role:manage") {
+		return nil, errors.ErrRoleSelfLockout
+	}
+
+	if err := s.userRepo.ReplaceRoles(ctx, userID, roles, adminID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// 캐시된 권한 정보 무효화, 이전 역할로 발급된 액세스 토큰 폐기
+	s.principals.Invalidate(userID)
+	s.revoked.RevokeUser(ctx, userID, s.accessTTL)
+
+	logger.FromContext(ctx).Info("사용자 역할 변경: %s (%s, 관리자: %s)", userID, strings.Join(roles, ", "), adminID)
+	return result, nil
+}
+
+// userRoles 역할 목록에 권한을 붙여 응답 생성
+func (s *service) userRoles(ctx context.Context, userID string, roles []string) (*UserRolesResponse, error) {
+	permissions, err := s.policy.Permissions(ctx, roles)
+	if err != nil {
+		logger.FromContext(ctx).Error("역할 권한 조회 실패: %v", err)
+		return nil, errors.Wrap(err, "ROLE_POLICY_FAILED", "역할 권한 조회에 실패했습니다")
+	}
+
+	return &UserRolesResponse{
+		UserID:      userID,
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
+
+// normalizePermissions 공백, 중복 제거 후 확인
+// 권한 목록(_permissions)에 있는 권한이나 형식에 맞는 와일드카드(*, blog:*)만 부여할 수 있다
+func (s *service) normalizePermissions(ctx context.Context, permissions []string) ([]string, error) {
+	permissions = dedupe(permissions)
+	if len(permissions) == 0 {
+		return nil, nil
+	}
+
+	catalog, err := s.repo.FindPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{}, len(catalog))
+	for _, p := range catalog {
+		known[p.Code] = struct{}{}
+	}
+
+	for _, permission := range permissions {
+		_, ok := known[permission]
+		if !ok && !(rbac.Valid(permission) && strings.HasSuffix(permission, rbac.Wildcard)) {
+			return nil, errors.ErrInvalidPermission.WithMeta("permission", permission)
+		}
+	}
+
+	return permissions, nil
+}
+
+// validateName 역할 이름 확인
+func validateName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > 50 {
+		return errors.New("INVALID_ROLE_NAME", "역할 이름은 1-50자 사이여야 합니다")
+	}
+	return nil
+}
+
+// dedupe 공백 제거, 빈 값과 중복 제외 (순서 유지)
+func dedupe(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	var result []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if _, ok := seen[v]; ok || v == "" {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,268 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/mailer"
+	"net/url"
+	"time"
+)
+
+// ForgotPassword 비밀번호 재설정 메일 발송
+// 계정 존재 여부를 알 수 없도록 없는 이메일이어도 성공으로 처리한다
+func (s *service) ForgotPassword(ctx context.Context, req *ForgotPasswordRequest) error {
+	user, err := s.repo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, errors.ErrUserNotFound) {
+			logger.FromContext(ctx).Info("비밀번호 재설정 요청 (없는 이메일)")
+			return nil
+		}
+		return err
+	}
+
+	token, err := s.issueUserToken(ctx, user, TokenPurposeReset, s.config.Account.ResetTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("%s님, 안녕하세요.\n\n비밀번호 재설정 요청을 받았습니다 (아이디: %s).\n", user.Name, user.ID)
+	if s.config.Account.ResetURL != "" {
+		body += fmt.Sprintf("아래 링크에서 새 비밀번호를 설정해주세요.\n%s\n", linkWithToken(s.config.Account.ResetURL, token))
+	} else {
+		body += fmt.Sprintf("아래 재설정 코드로 새 비밀번호를 설정해주세요.\n%s\n", token)
+	}
+	body += fmt.Sprintf("\n%s 동안 한 번만 사용할 수 있습니다.\n직접 요청하지 않았다면 이 메일을 무시하셔도 됩니다. 비밀번호는 바뀌지 않습니다.\n",
+		formatTTL(s.config.Account.ResetTTL))
+
+	s.sendMail(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("[%s] 비밀번호 재설정 안내", s.config.App.ServiceName),
+		Body:    body,
+	})
+
+	logger.FromContext(ctx).Info("비밀번호 재설정 메일 발송: %s", user.ID)
+	return nil
+}
+
+// ResetPassword 메일로 받은 토큰으로 비밀번호 재설정
+// 비밀번호 변경과 같이 모든 세션을 종료하고, 메일 수신으로 본인이 확인됐으므로 로그인 잠금도 풀어준다
+func (s *service) ResetPassword(ctx context.Context, req *ResetPasswordRequest) error {
+	now := time.Now()
+
+	// 정책에 맞지 않으면 토큰을 쓰지 않고 돌려보내 같은 링크로 다시 시도할 수 있게 한다
+	pending, err := s.findUserToken(ctx, req.Token, TokenPurposeReset, now)
+	if err != nil {
+		return err
+	}
+	user, err := s.repo.FindByID(ctx, pending.UserID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkPassword(ctx, req.Password, user.ID, user.Email, user); err != nil {
+		return err
+	}
+	hashedPassword, err := s.hashPassword(ctx, req.Password)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.useUserToken(ctx, req.Token, TokenPurposeReset, now)
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"u_pass":          hashedPassword,
+		"u_login_fails":   0,
+		"u_login_fail_at": nil,
+		"u_locked_until":  nil,
+	}
+	if err := s.repo.Update(ctx, token.UserID, updates); err != nil {
+		return err
+	}
+
+	if err := s.recordPassword(ctx, token.UserID, hashedPassword, now); err != nil {
+		logger.FromContext(ctx).Warn("비밀번호 이력 저장 실패: %s: %v", token.UserID, err)
+	}
+
+	// 함께 발급된 다른 재설정 토큰도 무효화
+	if _, err := s.repo.DeleteUserTokens(ctx, token.UserID, TokenPurposeReset); err != nil {
+		logger.FromContext(ctx).Warn("재설정 토큰 정리 실패: %v", err)
+	}
+
+	if _, err := s.repo.RevokeSessions(ctx, token.UserID, "", SessionRevokePassword, now); err != nil {
+		return err
+	}
+	s.revoked.RevokeUser(ctx, token.UserID, s.config.JWT.AccessTTL())
+
+	logger.FromContext(ctx).Info("비밀번호 재설정 완료, 모든 세션 종료: %s", token.UserID)
+	return nil
+}
+
+// VerifyEmail 메일로 받은 토큰으로 이메일 인증
+func (s *service) VerifyEmail(ctx context.Context, rawToken string) error {
+	now := time.Now()
+
+	token, err := s.useUserToken(ctx, rawToken, TokenPurposeVerify, now)
+	if err != nil {
+		return err
+	}
+
+	verified, err := s.repo.VerifyEmail(ctx, token.UserID, token.Email, now)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		// 메일을 보낸 뒤 이메일을 바꾼 경우 (새 이메일로 다시 보낸 토큰으로 인증해야 함)
+		logger.FromContext(ctx).Warn("이메일 인증 실패 (이메일 변경됨): %s", token.UserID)
+		return errors.ErrInvalidLinkToken
+	}
+	s.principals.Invalidate(token.UserID)
+
+	logger.FromContext(ctx).Info("이메일 인증 완료: %s", token.UserID)
+	return nil
+}
+
+// ResendVerification 이메일 인증 메일 재발송
+// 계정 존재 여부를 알 수 없도록 없는 이메일이어도 성공으로 처리한다
+func (s *service) ResendVerification(ctx context.Context, req *ResendVerificationRequest) error {
+	user, err := s.repo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, errors.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.EmailVerified() {
+		return nil
+	}
+
+	return s.sendVerification(ctx, user)
+}
+
+// sendVerification 이메일 인증 메일 발송 (이전에 보낸 인증 토큰은 무효화)
+func (s *service) sendVerification(ctx context.Context, user *User) error {
+	token, err := s.issueUserToken(ctx, user, TokenPurposeVerify, s.config.Account.VerifyTTL)
+	if err != nil {
+		return err
+	}
+
+	s.sendMail(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: fmt.Sprintf("[%s] 이메일 주소를 인증해주세요", s.config.App.ServiceName),
+		Body: fmt.Sprintf("%s님, 안녕하세요.\n\n아래 링크를 열어 이메일 주소 인증을 완료해주세요.\n%s\n\n링크는 %s 동안 유효합니다.\n직접 가입하지 않았다면 이 메일을 무시하셔도 됩니다.\n",
+			user.Name, linkWithToken(s.config.Account.VerifyURL, token), formatTTL(s.config.Account.VerifyTTL)),
+	})
+
+	logger.FromContext(ctx).Info("이메일 인증 메일 발송: %s", user.ID)
+	return nil
+}
+
+// issueUserToken 일회용 토큰 발급 (같은 용도의 이전 토큰 삭제, DB에는 해시만 저장)
+func (s *service) issueUserToken(ctx context.Context, user *User, purpose string, ttl time.Duration) (string, error) {
+	now := time.Now()
+
+	if _, err := s.repo.DeleteUserTokens(ctx, user.ID, purpose); err != nil {
+		return "", err
+	}
+
+	raw, err := newLinkToken()
+	if err != nil {
+		return "", errors.Wrap(err, "USER_TOKEN_CREATE_FAILED", "토큰 생성에 실패했습니다")
+	}
+
+	token := &UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hashToken(raw),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	if purpose == TokenPurposeVerify {
+		token.Email = user.Email
+	}
+
+	if err := s.repo.CreateUserToken(ctx, token); err != nil {
+		return "", err
+	}
+
+	return raw, nil
+}
+
+// findUserToken 사용할 수 있는 토큰 조회 (없거나, 용도가 다르거나, 사용했거나, 만료됐으면 INVALID_LINK_TOKEN)
+func (s *service) findUserToken(ctx context.Context, raw, purpose string, now time.Time) (*UserToken, error) {
+	token, err := s.repo.FindUserToken(ctx, hashToken(raw))
+	if err != nil {
+		return nil, err
+	}
+	if token.Purpose != purpose || !token.Usable(now) {
+		return nil, errors.ErrInvalidLinkToken
+	}
+	return token, nil
+}
+
+// useUserToken 토큰 확인 후 사용 처리
+func (s *service) useUserToken(ctx context.Context, raw, purpose string, now time.Time) (*UserToken, error) {
+	token, err := s.findUserToken(ctx, raw, purpose, now)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.repo.UseUserToken(ctx, token.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		// 같은 토큰으로 다른 요청이 먼저 사용한 경우
+		return nil, errors.ErrInvalidLinkToken
+	}
+
+	return token, nil
+}
+
+// sendMail 메일 발송 (요청을 기다리게 하지 않고, 응답 시간으로 계정 존재 여부를 알 수 없도록 백그라운드에서 보냄)
+func (s *service) sendMail(ctx context.Context, msg mailer.Message) {
+	if s.mailer == nil {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			logger.FromContext(ctx).Error("메일 발송 실패 (%s): %v", msg.Subject, err)
+		}
+	}()
+}
+
+// newLinkToken 메일 링크용 토큰 (256비트, URL에 그대로 넣을 수 있는 base64url)
+func newLinkToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// linkWithToken 링크 주소에 token 쿼리 추가
+func linkWithToken(link, token string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link + "?token=" + url.QueryEscape(token)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// formatTTL 메일에 표시할 유효 시간 (시간 단위로 나누어떨어지면 시간, 아니면 분)
+func formatTTL(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%d시간", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%d분", int(d/time.Minute))
+}
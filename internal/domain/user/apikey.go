@@ -0,0 +1,276 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"gin_starter/internal/middleware"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/rbac"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxAPIKeyNameLen API 키 이름 최대 길이 (컬럼 크기)
+const maxAPIKeyNameLen = 100
+
+// apiKeyIDLen 키 접두사 중 식별용 무작위 부분 길이 (hex)
+const apiKeyIDLen = 12
+
+// apiKeyTouchInterval 마지막 사용 시각을 다시 기록하는 최소 간격 (요청마다 DB에 쓰지 않도록)
+const apiKeyTouchInterval = time.Minute
+
+// GetAPIKeys 사용자의 API 키 목록 (폐기한 키 제외)
+func (s *service) GetAPIKeys(ctx context.Context, userID string) ([]APIKey, error) {
+	return s.repo.FindAPIKeys(ctx, userID)
+}
+
+// CreateAPIKey API 키 발급
+// 사용 범위는 지금 가진 권한 안에서만 지정할 수 있고, 이후 역할이 바뀌면 바뀐 권한과 겹치는 만큼만 쓸 수 있다
+func (s *service) CreateAPIKey(ctx context.Context, userID string, req *CreateAPIKeyRequest) (*APIKeyCreatedResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxAPIKeyNameLen {
+		return nil, errors.New("INVALID_API_KEY_NAME", "API 키 이름은 1-100자 사이여야 합니다")
+	}
+
+	principal, err := s.principals.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := normalizeScopes(req.Scopes, principal.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	allowIPs, err := normalizeAllowIPs(req.AllowIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt, err := s.apiKeyExpiresAt(req.ExpiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountActiveAPIKeys(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(s.config.APIKey.MaxPerUser) {
+		return nil, errors.ErrAPIKeyLimit.WithMeta("max", s.config.APIKey.MaxPerUser)
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return nil, errors.Wrap(err, "API_KEY_CREATE_FAILED", "API 키 생성에 실패했습니다")
+	}
+	key, prefix, err := newAPIKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "API_KEY_CREATE_FAILED", "API 키 생성에 실패했습니다")
+	}
+
+	apiKey := &APIKey{
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hashToken(key),
+		Scopes:    scopes,
+		AllowIPs:  allowIPs,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if err := s.repo.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("API 키 발급: %s (키: %s, 범위: %s)", userID, prefix, strings.Join(scopes, ", "))
+	return &APIKeyCreatedResponse{APIKey: *apiKey, Key: key}, nil
+}
+
+// RevokeAPIKey 사용자가 자기 API 키 폐기
+func (s *service) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	revoked, err := s.repo.RevokeAPIKey(ctx, userID, keyID, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.ErrAPIKeyNotFound
+	}
+
+	logger.FromContext(ctx).Info("API 키 폐기: %s (키: %s)", userID, keyID)
+	return nil
+}
+
+// apiKeyExpiresAt 만료 시각 (API_KEY_MAX_TTL_DAYS를 넘을 수 없고, 지정하지 않으면 그 기간 뒤)
+func (s *service) apiKeyExpiresAt(requested *time.Time, now time.Time) (*time.Time, error) {
+	maxTTL := s.config.APIKey.MaxTTL
+	if requested == nil {
+		if maxTTL <= 0 {
+			return nil, nil
+		}
+		expiresAt := now.Add(maxTTL)
+		return &expiresAt, nil
+	}
+
+	if !requested.After(now) {
+		return nil, errors.New("INVALID_API_KEY_EXPIRY", "만료 시각은 현재 이후여야 합니다")
+	}
+	if maxTTL > 0 && requested.After(now.Add(maxTTL)) {
+		return nil, errors.New("INVALID_API_KEY_EXPIRY", "API 키 유효 기간이 너무 깁니다").
+			WithMeta("max_days", int(maxTTL/(24*time.Hour)))
+	}
+
+	expiresAt := *requested
+	return &expiresAt, nil
+}
+
+// NewAPIKeyVerifier 인증 미들웨어용 API 키 확인 함수
+// 접두사로 키를 찾아 원문 해시를 비교하고, 폐기, 만료, 허용 IP를 확인한 뒤 마지막 사용을 기록한다
+func NewAPIKeyVerifier(repo Repository) middleware.APIKeyVerifier {
+	return func(ctx context.Context, key, ip string) (*middleware.APIKey, error) {
+		prefix, ok := apiKeyPrefix(key)
+		if !ok {
+			return nil, errors.ErrInvalidAPIKey
+		}
+
+		apiKey, err := repo.FindAPIKeyByPrefix(ctx, prefix)
+		if err != nil {
+			if errors.Is(err, errors.ErrAPIKeyNotFound) {
+				return nil, errors.ErrInvalidAPIKey
+			}
+			return nil, err
+		}
+
+		if apiKey.RevokedAt != nil || subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashToken(key))) != 1 {
+			return nil, errors.ErrInvalidAPIKey
+		}
+
+		now := time.Now()
+		if apiKey.Expired(now) {
+			return nil, errors.ErrAPIKeyExpired
+		}
+		if !allowsIP(apiKey.AllowIPs, ip) {
+			logger.FromContext(ctx).Warn("허용하지 않은 IP에서 API 키 사용: %s (키: %s, IP: %s)", apiKey.UserID, apiKey.Prefix, ip)
+			return nil, errors.ErrAPIKeyIPDenied
+		}
+
+		// 마지막 사용 기록 (실패해도 요청은 계속)
+		if apiKey.LastUsedAt == nil || apiKey.LastIP != ip || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+			if err := repo.TouchAPIKey(ctx, apiKey.ID, ip, now); err != nil {
+				logger.FromContext(ctx).Warn("API 키 사용 기록 실패 (키: %s): %v", apiKey.Prefix, err)
+			}
+		}
+
+		return &middleware.APIKey{
+			ID:     apiKey.ID,
+			UserID: apiKey.UserID,
+			Scopes: apiKey.Scopes,
+		}, nil
+	}
+}
+
+// newAPIKey 무작위 API 키와 접두사 (gsk_ + 식별용 12자 + _ + 43자)
+// 접두사로 키를 찾고 원문 전체의 해시를 비교한다
+func newAPIKey() (string, string, error) {
+	id := make([]byte, apiKeyIDLen/2)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	prefix := middleware.APIKeyPrefix + hex.EncodeToString(id)
+	return prefix + "_" + base64.RawURLEncoding.EncodeToString(secret), prefix, nil
+}
+
+// apiKeyPrefix 키 원문에서 접두사 추출 (형식이 다르면 false)
+func apiKeyPrefix(key string) (string, bool) {
+	n := len(middleware.APIKeyPrefix) + apiKeyIDLen
+	if !strings.HasPrefix(key, middleware.APIKeyPrefix) || len(key) <= n || key[n] != '_' {
+		return "", false
+	}
+	return key[:n], true
+}
+
+// normalizeScopes 공백, 중복 제거 후 확인 (권한 형식에 맞고 지금 가진 권한에 포함되는 것만)
+func normalizeScopes(scopes, granted []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(scopes))
+	var result []string
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if _, ok := seen[scope]; ok || scope == "" {
+			continue
+		}
+		if !rbac.Valid(scope) || !rbac.Has(granted, scope) {
+			return nil, errors.ErrInvalidAPIKeyScope.WithMeta("scope", scope)
+		}
+		seen[scope] = struct{}{}
+		result = append(result, scope)
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("API_KEY_SCOPE_REQUIRED", "사용 범위를 하나 이상 지정해야 합니다")
+	}
+	return result, nil
+}
+
+// normalizeAllowIPs 허용 IP 목록을 CIDR 표기로 정리 (단일 IP는 /32, /128)
+func normalizeAllowIPs(entries []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(entries))
+	var result []string
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		var network *net.IPNet
+		if ip := net.ParseIP(entry); ip != nil {
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			network = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		} else if _, parsed, err := net.ParseCIDR(entry); err == nil {
+			network = parsed
+		} else {
+			return nil, errors.New("INVALID_API_KEY_IP", "잘못된 IP 또는 CIDR입니다").WithMeta("ip", entry)
+		}
+
+		cidr := network.String()
+		if _, ok := seen[cidr]; ok {
+			continue
+		}
+		seen[cidr] = struct{}{}
+		result = append(result, cidr)
+	}
+	return result, nil
+}
+
+// allowsIP 허용 목록(CIDR)에 ip가 있는지 (목록이 비어 있으면 모두 허용)
+func allowsIP(allowIPs []string, ip string) bool {
+	if len(allowIPs) == 0 {
+		return true
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, cidr := range allowIPs {
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
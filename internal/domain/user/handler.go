@@ -1,8 +1,12 @@
 package user
 
 import (
+	stderrors "errors"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/password"
 	"gin_starter/pkg/response"
 	"gin_starter/pkg/validator"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,34 +28,24 @@ func NewHandler(service Service) *Handler {
 // @Produce json
 // @Param body body CreateUserRequest true "회원가입 정보"
 // @Success 201 {object} response.Response
+// @Failure 409 {object} response.Response "USER_EXISTS"
+// @Failure 422 {object} response.Response "VALIDATION_ERROR (user_pass: 비밀번호 정책 위반 항목)"
 // @Router /api/user/register [post]
 func (h *Handler) Register(c *gin.Context) {
 	// 입력값 검증
-	rules := []validator.Rule{
-		{Field: "user_id", Label: "아이디", Required: true, MinLen: 3, MaxLen: 20, Pattern: validator.PatternAlphaNum},
-		{Field: "user_pass", Label: "비밀번호", Required: true, MinLen: 6, MaxLen: 50},
-		{Field: "user_name", Label: "이름", Required: true, MinLen: 2, MaxLen: 50, Pattern: validator.PatternKorEng},
-		{Field: "user_email", Label: "이메일", Required: true, Pattern: validator.PatternEmail},
-	}
-
-	result := validator.Validate(c, rules)
-	if !result.Valid {
+	var req CreateUserRequest
+	if result := validator.Bind(c, &req); !result.Valid {
 		response.ValidationError(c, result.GetErrorMap())
 		return
 	}
 
-	// 요청 데이터 매핑
-	req := &CreateUserRequest{
-		ID:       result.Values["user_id"],
-		Password: result.Values["user_pass"],
-		Name:     result.Values["user_name"],
-		Email:    result.Values["user_email"],
-	}
-
 	// 서비스 호출
-	user, err := h.service.Register(req)
+	user, err := h.service.Register(c.Request.Context(), &req)
 	if err != nil {
-		response.InternalError(c, err.Error())
+		if passwordPolicyError(c, err) {
+			return
+		}
+		response.FromError(c, err)
 		return
 	}
 
@@ -65,27 +59,23 @@ func (h *Handler) Register(c *gin.Context) {
 // @Produce json
 // @Param body body LoginRequest true "로그인 정보"
 // @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response "EMAIL_NOT_VERIFIED (EMAIL_UNVERIFIED_ACCESS=none)"
+// @Failure 429 {object} response.Response "ACCOUNT_LOCKED, LOGIN_THROTTLED (Retry-After)"
 // @Router /api/user/login [post]
 func (h *Handler) Login(c *gin.Context) {
-	rules := []validator.Rule{
-		{Field: "user_id", Label: "아이디", Required: true},
-		{Field: "user_pass", Label: "비밀번호", Required: true},
-	}
-
-	result := validator.Validate(c, rules)
-	if !result.Valid {
+	var req LoginRequest
+	if result := validator.Bind(c, &req); !result.Valid {
 		response.ValidationError(c, result.GetErrorMap())
 		return
 	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
 
-	req := &LoginRequest{
-		ID:       result.Values["user_id"],
-		Password: result.Values["user_pass"],
-	}
-
-	loginResp, err := h.service.Login(req)
+	loginResp, err := h.service.Login(c.Request.Context(), &req)
 	if err != nil {
-		response.Unauthorized(c, err.Error())
+		// 계정 잠금, 실패 후 대기 중이면 429 + Retry-After
+		response.FromError(c, err)
 		return
 	}
 
@@ -106,9 +96,9 @@ func (h *Handler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	user, err := h.service.GetProfile(userID.(string))
+	user, err := h.service.GetProfile(c.Request.Context(), userID.(string))
 	if err != nil {
-		response.InternalError(c, err.Error())
+		response.FromError(c, err)
 		return
 	}
 
@@ -123,6 +113,7 @@ func (h *Handler) GetProfile(c *gin.Context) {
 // @Produce json
 // @Param body body UpdateUserRequest true "수정 정보"
 // @Success 200 {object} response.Response
+// @Failure 422 {object} response.Response "VALIDATION_ERROR (user_pass: 비밀번호 정책 위반 항목)"
 // @Router /api/user/profile [put]
 func (h *Handler) UpdateProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -131,26 +122,17 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	rules := []validator.Rule{
-		{Field: "user_name", Label: "이름", MinLen: 2, MaxLen: 50, Pattern: validator.PatternKorEng},
-		{Field: "user_email", Label: "이메일", Pattern: validator.PatternEmail},
-		{Field: "user_pass", Label: "비밀번호", MinLen: 6, MaxLen: 50},
-	}
-
-	result := validator.Validate(c, rules)
-	if !result.Valid {
+	var req UpdateUserRequest
+	if result := validator.Bind(c, &req); !result.Valid {
 		response.ValidationError(c, result.GetErrorMap())
 		return
 	}
 
-	req := &UpdateUserRequest{
-		Name:     result.Values["user_name"],
-		Email:    result.Values["user_email"],
-		Password: result.Values["user_pass"],
-	}
-
-	if err := h.service.UpdateProfile(userID.(string), req); err != nil {
-		response.InternalError(c, err.Error())
+	if err := h.service.UpdateProfile(c.Request.Context(), userID.(string), &req); err != nil {
+		if passwordPolicyError(c, err) {
+			return
+		}
+		response.FromError(c, err)
 		return
 	}
 
@@ -164,32 +146,27 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 // @Produce json
 // @Param body body RefreshTokenRequest true "리프레시 토큰"
 // @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response "TOKEN_REUSED: 이미 교체된 토큰 재사용 (세션 종료됨)"
 // @Router /api/user/refresh [post]
 func (h *Handler) RefreshToken(c *gin.Context) {
-	rules := []validator.Rule{
-		{Field: "refresh_token", Label: "리프레시 토큰", Required: true},
-	}
-
-	result := validator.Validate(c, rules)
-	if !result.Valid {
+	var req RefreshTokenRequest
+	if result := validator.Bind(c, &req); !result.Valid {
 		response.ValidationError(c, result.GetErrorMap())
 		return
 	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
 
-	req := &RefreshTokenRequest{
-		RefreshToken: result.Values["refresh_token"],
-	}
-
-	tokens, err := h.service.RefreshToken(req)
+	tokens, err := h.service.RefreshToken(c.Request.Context(), &req)
 	if err != nil {
-		response.Unauthorized(c, err.Error())
+		response.FromError(c, err)
 		return
 	}
 
 	response.Success(c, tokens)
 }
 
-// Logout 로그아웃
+// Logout 로그아웃 (현재 기기 세션만 종료, 이 세션의 액세스 토큰은 즉시 사용 불가)
 // @Summary 로그아웃
 // @Tags User
 // @Security Bearer
@@ -203,10 +180,622 @@ func (h *Handler) Logout(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Logout(userID.(string)); err != nil {
-		response.InternalError(c, err.Error())
+	req := &LogoutRequest{
+		UserID:         userID.(string),
+		SessionID:      c.GetString("session_id"),
+		TokenID:        c.GetString("token_id"),
+		TokenExpiresAt: c.GetTime("token_expires_at"),
+	}
+
+	if err := h.service.Logout(c.Request.Context(), req); err != nil {
+		response.FromError(c, err)
 		return
 	}
 
 	response.Success(c, gin.H{"message": "로그아웃되었습니다"})
-}
\ No newline at end of file
+}
+
+// GetSessions 로그인 세션 목록
+// @Summary 로그인 세션 목록 (기기별)
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/user/sessions [get]
+func (h *Handler) GetSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	sessions, err := h.service.GetSessions(c.Request.Context(), userID.(string), c.GetString("session_id"))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"sessions": sessions})
+}
+
+// RevokeSession 세션 종료
+// @Summary 로그인 세션 종료 (해당 기기 로그아웃)
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Param id path string true "세션 ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/user/sessions/{id} [delete]
+func (h *Handler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	if err := h.service.RevokeSession(c.Request.Context(), userID.(string), c.Param("id")); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "세션이 종료되었습니다"})
+}
+
+// RevokeOtherSessions 다른 기기 세션 모두 종료
+// @Summary 다른 기기 로그아웃 (현재 세션 제외)
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/user/sessions [delete]
+func (h *Handler) RevokeOtherSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	count, err := h.service.RevokeOtherSessions(c.Request.Context(), userID.(string), c.GetString("session_id"))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "다른 기기의 세션이 종료되었습니다", "revoked": count})
+}
+
+// ForgotPassword 비밀번호 재설정 메일 요청
+// @Summary 비밀번호 재설정 메일 요청 (가입된 이메일이 아니어도 같은 응답)
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body ForgotPasswordRequest true "가입 이메일"
+// @Success 200 {object} response.Response
+// @Router /api/user/password/forgot [post]
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		response.ValidationError(c, result.GetErrorMap())
+		return
+	}
+
+	if err := h.service.ForgotPassword(c.Request.Context(), &req); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "가입된 이메일이면 비밀번호 재설정 안내 메일이 발송됩니다"})
+}
+
+// ResetPassword 비밀번호 재설정
+// @Summary 비밀번호 재설정 (메일로 받은 토큰, 모든 기기 로그아웃)
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body ResetPasswordRequest true "재설정 토큰과 새 비밀번호"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "INVALID_LINK_TOKEN"
+// @Failure 422 {object} response.Response "VALIDATION_ERROR (user_pass: 비밀번호 정책 위반 항목)"
+// @Router /api/user/password/reset [post]
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		response.ValidationError(c, result.GetErrorMap())
+		return
+	}
+
+	if err := h.service.ResetPassword(c.Request.Context(), &req); err != nil {
+		if passwordPolicyError(c, err) {
+			return
+		}
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "비밀번호가 변경되었습니다. 새 비밀번호로 다시 로그인해주세요"})
+}
+
+// VerifyEmail 이메일 인증
+// @Summary 이메일 인증 (메일 링크의 token, GET 쿼리 또는 POST 바디)
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param token query string false "인증 토큰"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "INVALID_LINK_TOKEN"
+// @Router /api/user/email/verify [get]
+// @Router /api/user/email/verify [post]
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		response.ValidationError(c, result.GetErrorMap())
+		return
+	}
+
+	if err := h.service.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "이메일 인증이 완료되었습니다"})
+}
+
+// ResendVerification 이메일 인증 메일 재발송
+// @Summary 이메일 인증 메일 재발송 (가입된 이메일이 아니어도 같은 응답)
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body ResendVerificationRequest true "가입 이메일"
+// @Success 200 {object} response.Response
+// @Router /api/user/email/verify/resend [post]
+func (h *Handler) ResendVerification(c *gin.Context) {
+	var req ResendVerificationRequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		response.ValidationError(c, result.GetErrorMap())
+		return
+	}
+
+	if err := h.service.ResendVerification(c.Request.Context(), &req); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "인증이 필요한 이메일이면 인증 메일이 다시 발송됩니다"})
+}
+
+// LoginMFA 2단계 인증 로그인
+// @Summary 2단계 인증 로그인 (로그인 응답의 mfa_token과 인증 앱 코드 또는 복구 코드)
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param body body MFALoginRequest true "mfa_token과 코드"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "INVALID_MFA_CODE"
+// @Failure 401 {object} response.Response "MFA_TOKEN_INVALID"
+// @Failure 429 {object} response.Response "ACCOUNT_LOCKED, LOGIN_THROTTLED (Retry-After)"
+// @Router /api/user/login/mfa [post]
+func (h *Handler) LoginMFA(c *gin.Context) {
+	var req MFALoginRequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		response.ValidationError(c, result.GetErrorMap())
+		return
+	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	loginResp, err := h.service.CompleteMFALogin(c.Request.Context(), &req)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, loginResp)
+}
+
+// GetMFA 2단계 인증 상태 조회
+// @Summary 2단계 인증 상태 (사용 여부, 남은 복구 코드 수)
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/user/mfa [get]
+func (h *Handler) GetMFA(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	status, err := h.service.GetMFAStatus(c.Request.Context(), userID.(string))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"mfa": status})
+}
+
+// SetupMFA 2단계 인증 설정 시작
+// @Summary 2단계 인증 설정 시작 (시크릿과 QR 코드용 otpauth URI 발급)
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} response.Response
+// @Failure 409 {object} response.Response "MFA_ALREADY_ENABLED"
+// @Router /api/user/mfa/setup [post]
+func (h *Handler) SetupMFA(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	setup, err := h.service.SetupMFA(c.Request.Context(), userID.(string))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, setup)
+}
+
+// EnableMFA 2단계 인증 사용 시작
+// @Summary 2단계 인증 사용 시작 (인증 앱 코드 확인, 복구 코드 발급, 다른 기기 로그아웃)
+// @Tags User
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body MFACodeRequest true "인증 앱 코드"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "INVALID_MFA_CODE, MFA_SETUP_REQUIRED"
+// @Router /api/user/mfa/enable [post]
+func (h *Handler) EnableMFA(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	var req MFACodeRequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		response.ValidationError(c, result.GetErrorMap())
+		return
+	}
+
+	codes, err := h.service.EnableMFA(c.Request.Context(), userID.(string), c.GetString("session_id"), req.Code)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, codes)
+}
+
+// DisableMFA 2단계 인증 해제
+// @Summary 2단계 인증 해제 (비밀번호와 인증 앱 코드 또는 복구 코드)
+// @Tags User
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body DisableMFARequest true "비밀번호와 코드"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "INVALID_PASSWORD, INVALID_MFA_CODE, MFA_NOT_ENABLED"
+// @Router /api/user/mfa/disable [post]
+func (h *Handler) DisableMFA(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	var req DisableMFARequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		response.ValidationError(c, result.GetErrorMap())
+		return
+	}
+
+	if err := h.service.DisableMFA(c.Request.Context(), userID.(string), &req); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "2단계 인증이 해제되었습니다"})
+}
+
+// RegenerateRecoveryCodes 복구 코드 재발급
+// @Summary 복구 코드 재발급 (인증 앱 코드 확인, 이전 복구 코드는 모두 무효)
+// @Tags User
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body MFACodeRequest true "인증 앱 코드"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "INVALID_MFA_CODE, MFA_NOT_ENABLED"
+// @Router /api/user/mfa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	var req MFACodeRequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		response.ValidationError(c, result.GetErrorMap())
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(c.Request.Context(), userID.(string), req.Code)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, codes)
+}
+
+// GetAPIKeys API 키 목록
+// @Summary API 키 목록 (폐기한 키 제외, 키 원문은 보여주지 않음)
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/user/api-keys [get]
+func (h *Handler) GetAPIKeys(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	keys, err := h.service.GetAPIKeys(c.Request.Context(), userID.(string))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"api_keys": keys})
+}
+
+// CreateAPIKey API 키 발급
+// @Summary API 키 발급 (키 원문은 이 응답에서만 확인 가능)
+// @Description 사용 범위(scopes)는 본인 권한 안에서 지정합니다 (blog:create, blog:* 등). 요청 시 Authorization: Bearer <key> 또는 X-API-Key 헤더로 보냅니다
+// @Tags User
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body CreateAPIKeyRequest true "키 이름, 사용 범위, 허용 IP, 만료 시각"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response "INVALID_API_KEY_SCOPE, INVALID_API_KEY_IP, INVALID_API_KEY_EXPIRY"
+// @Failure 409 {object} response.Response "API_KEY_LIMIT"
+// @Router /api/user/api-keys [post]
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		response.ValidationError(c, result.GetErrorMap())
+		return
+	}
+
+	created, err := h.service.CreateAPIKey(c.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Created(c, created)
+}
+
+// RevokeAPIKey API 키 폐기
+// @Summary API 키 폐기 (이후 이 키로 보낸 요청은 거부)
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Param id path string true "API 키 ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/user/api-keys/{id} [delete]
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(c.Request.Context(), userID.(string), c.Param("id")); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "API 키가 폐기되었습니다"})
+}
+
+// oauthStateCookie 소셜 로그인 state를 콜백까지 보관하는 쿠키 (시작한 브라우저에서만 콜백을 받도록)
+const (
+	oauthStateCookie = "oauth_state"
+	oauthCookiePath  = "/api/user/oauth"
+)
+
+// OAuthProviders 소셜 로그인 제공자 목록
+// @Summary 소셜 로그인 제공자 목록
+// @Tags User
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/user/oauth [get]
+func (h *Handler) OAuthProviders(c *gin.Context) {
+	response.Success(c, gin.H{"providers": h.service.OAuthProviders()})
+}
+
+// OAuthLogin 소셜 로그인 시작 (제공자 로그인 화면으로 이동)
+// @Summary 소셜 로그인 시작
+// @Description state 쿠키를 설정하고 제공자 인가 주소로 302 이동합니다. 로그인을 마치면 제공자가 콜백 주소로 돌려보냅니다
+// @Tags User
+// @Param provider path string true "제공자 이름 (google 등)"
+// @Success 302
+// @Failure 404 {object} response.Response "OAUTH_PROVIDER_NOT_FOUND"
+// @Router /api/user/oauth/{provider} [get]
+func (h *Handler) OAuthLogin(c *gin.Context) {
+	start, err := h.service.StartOAuth(c.Request.Context(), c.Param("provider"), "")
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	setOAuthStateCookie(c, start.State, start.MaxAge, start.Secure)
+	c.Redirect(http.StatusFound, start.URL)
+}
+
+// OAuthLink 로그인한 계정에 소셜 계정 연결 시작
+// @Summary 소셜 계정 연결 시작
+// @Description state 쿠키를 설정하고 제공자 인가 주소(url)를 돌려줍니다. 같은 브라우저에서 url로 이동하면 콜백에서 연결이 완료됩니다
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Param provider path string true "제공자 이름 (google 등)"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response "OAUTH_PROVIDER_NOT_FOUND"
+// @Router /api/user/oauth/{provider}/link [post]
+func (h *Handler) OAuthLink(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	start, err := h.service.StartOAuth(c.Request.Context(), c.Param("provider"), userID.(string))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	setOAuthStateCookie(c, start.State, start.MaxAge, start.Secure)
+	response.Success(c, start)
+}
+
+// OAuthCallback 소셜 로그인 콜백 (제공자가 돌려보내는 주소)
+// @Summary 소셜 로그인 콜백
+// @Description 로그인이면 로그인과 같은 응답(2단계 인증 사용 시 mfa_token), 계정 연결이면 연결한 소셜 계정을 돌려줍니다
+// @Tags User
+// @Produce json
+// @Param provider path string true "제공자 이름"
+// @Param code query string false "인가 코드"
+// @Param state query string true "state"
+// @Param error query string false "제공자 오류 (사용자가 취소한 경우 등)"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response "OAUTH_STATE_INVALID, OAUTH_DENIED"
+// @Failure 401 {object} response.Response "OAUTH_FAILED"
+// @Failure 403 {object} response.Response "OAUTH_NOT_LINKED, OAUTH_EMAIL_REQUIRED, EMAIL_NOT_VERIFIED"
+// @Failure 409 {object} response.Response "OAUTH_ACCOUNT_EXISTS, OAUTH_IDENTITY_LINKED, OAUTH_PROVIDER_LINKED"
+// @Router /api/user/oauth/{provider}/callback [get]
+func (h *Handler) OAuthCallback(c *gin.Context) {
+	cookieState, _ := c.Cookie(oauthStateCookie)
+
+	// state는 한 번만 쓰므로 결과와 관계없이 쿠키 삭제
+	setOAuthStateCookie(c, "", -1, c.Request.TLS != nil)
+
+	req := &OAuthCallbackRequest{
+		Provider:    c.Param("provider"),
+		Code:        c.Query("code"),
+		State:       c.Query("state"),
+		CookieState: cookieState,
+		Error:       c.Query("error"),
+		IP:          c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	}
+
+	result, err := h.service.CompleteOAuth(c.Request.Context(), req)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	if result.Linked != nil {
+		response.Success(c, gin.H{"message": "소셜 계정이 연결되었습니다", "identity": result.Linked})
+		return
+	}
+	response.Success(c, result.Login)
+}
+
+// GetIdentities 연결된 소셜 계정 목록
+// @Summary 연결된 소셜 계정 목록
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} response.Response
+// @Router /api/user/identities [get]
+func (h *Handler) GetIdentities(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	identities, err := h.service.GetIdentities(c.Request.Context(), userID.(string))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"identities": identities})
+}
+
+// UnlinkIdentity 소셜 계정 연결 해제
+// @Summary 소셜 계정 연결 해제 (비밀번호가 없는 계정은 마지막 연결을 해제할 수 없음)
+// @Tags User
+// @Security Bearer
+// @Produce json
+// @Param provider path string true "제공자 이름"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response "IDENTITY_NOT_FOUND"
+// @Failure 409 {object} response.Response "OAUTH_LAST_LOGIN"
+// @Router /api/user/identities/{provider} [delete]
+func (h *Handler) UnlinkIdentity(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Unauthorized(c, "인증 정보가 없습니다")
+		return
+	}
+
+	if err := h.service.UnlinkIdentity(c.Request.Context(), userID.(string), c.Param("provider")); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "소셜 계정 연결이 해제되었습니다"})
+}
+
+// setOAuthStateCookie state 쿠키 설정 (maxAge가 음수면 삭제)
+// 제공자에서 돌아오는 최상위 이동에도 쿠키가 오도록 SameSite=Lax
+func setOAuthStateCookie(c *gin.Context, state string, maxAge int, secure bool) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, maxAge, oauthCookiePath, "", secure, true)
+}
+
+// passwordPolicyError 비밀번호 정책 위반을 user_pass 필드 검증 에러로 응답 (처리했으면 true)
+// 첫 위반 항목을 code, message에 넣고 전체 항목은 violations로 보낸다
+func passwordPolicyError(c *gin.Context, err error) bool {
+	var appErr *errors.AppError
+	if !stderrors.As(err, &appErr) || appErr.Code != errors.ErrPasswordPolicy.Code {
+		return false
+	}
+
+	violations, ok := appErr.Meta["violations"].([]password.Violation)
+	if !ok || len(violations) == 0 {
+		return false
+	}
+
+	response.ValidationError(c, map[string]interface{}{
+		"user_pass": map[string]interface{}{
+			"code":       violations[0].Code,
+			"message":    violations[0].Message,
+			"violations": violations,
+		},
+	})
+	return true
+}
@@ -0,0 +1,183 @@
+package user
+
+import (
+	"context"
+	"gin_starter/internal/config"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"math"
+	"sync"
+	"time"
+)
+
+// loginGuard 로그인 무차별 대입 방지
+// 계정 실패 횟수와 잠금은 DB(_user)에, IP별 실패 횟수는 메모리에 둔다 (IP는 많고 금방 바뀌므로)
+type loginGuard struct {
+	cfg  config.LoginConfig
+	repo Repository
+
+	mu        sync.Mutex
+	ips       map[string]*ipFailures
+	lastSweep time.Time
+}
+
+// ipFailures IP 하나의 실패 기록
+type ipFailures struct {
+	count       int
+	since       time.Time // 이번 기간 첫 실패 시각
+	lockedUntil time.Time
+}
+
+func newLoginGuard(cfg config.LoginConfig, repo Repository) *loginGuard {
+	return &loginGuard{
+		cfg:       cfg,
+		repo:      repo,
+		ips:       make(map[string]*ipFailures),
+		lastSweep: time.Now(),
+	}
+}
+
+// check 로그인 시도 가능 여부 (user는 없는 계정이면 nil)
+// 잠겨 있거나 대기 시간 중이면 retry_after(초)를 담은 에러 반환
+func (g *loginGuard) check(user *User, ip string, now time.Time) error {
+	if wait := g.ipWait(ip, now); wait > 0 {
+		return retryAfter(errors.ErrLoginThrottled, wait)
+	}
+	if user == nil {
+		return nil
+	}
+
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return retryAfter(errors.ErrAccountLocked, user.LockedUntil.Sub(now))
+	}
+	if user.LoginFails > 0 && user.LoginFailAt != nil {
+		if wait := user.LoginFailAt.Add(g.backoff(user.LoginFails)).Sub(now); wait > 0 {
+			return retryAfter(errors.ErrLoginThrottled, wait)
+		}
+	}
+	return nil
+}
+
+// failed 로그인 실패 기록 (user는 없는 계정이면 nil)
+// 계정이 잠기면 ACCOUNT_LOCKED, 아니면 INVALID_CREDENTIALS 반환
+func (g *loginGuard) failed(ctx context.Context, user *User, ip string, now time.Time) error {
+	if count, locked := g.ipFailed(ip, now); locked {
+		logger.FromContext(ctx).Warn("로그인 실패 반복으로 IP 잠금: %s (%d회)", ip, count)
+		g.record(ctx, &Lockout{Scope: LockoutScopeIP, IP: ip, FailCount: count, LockedUntil: now.Add(g.cfg.LockDuration), CreatedAt: now})
+	}
+	if user == nil {
+		return errors.ErrInvalidCredentials
+	}
+
+	// 마지막 실패가 잠금 시간보다 오래됐으면 처음부터 다시 센다
+	restart := user.LoginFailAt == nil || now.Sub(*user.LoginFailAt) > g.cfg.LockDuration
+	fails, err := g.repo.RecordLoginFailure(ctx, user.ID, restart, now)
+	if err != nil {
+		return errors.ErrInvalidCredentials
+	}
+	logger.FromContext(ctx).Warn("로그인 실패 (잘못된 비밀번호): %s (%d회)", user.ID, fails)
+
+	if g.cfg.MaxFailures <= 0 || fails < g.cfg.MaxFailures {
+		return errors.ErrInvalidCredentials
+	}
+
+	until := now.Add(g.cfg.LockDuration)
+	if err := g.repo.LockLogin(ctx, user.ID, until); err != nil {
+		return errors.ErrInvalidCredentials
+	}
+	logger.FromContext(ctx).Warn("로그인 실패 반복으로 계정 잠금: %s (%d회, %s까지)", user.ID, fails, until.Format(time.RFC3339))
+	g.record(ctx, &Lockout{Scope: LockoutScopeAccount, UserID: user.ID, IP: ip, FailCount: fails, LockedUntil: until, CreatedAt: now})
+
+	return retryAfter(errors.ErrAccountLocked, g.cfg.LockDuration)
+}
+
+// succeeded 로그인 성공 시 실패 기록 초기화 (IP 기록은 다른 계정 실패가 섞여 있으므로 유지)
+func (g *loginGuard) succeeded(ctx context.Context, user *User) error {
+	if user.LoginFails == 0 && user.LockedUntil == nil {
+		return nil
+	}
+	return g.repo.ResetLoginFailures(ctx, user.ID)
+}
+
+// backoff fails번 연속 실패한 뒤 다음 시도까지 대기 시간 (BackoffBase * 2^(fails-1), 상한 BackoffMax)
+func (g *loginGuard) backoff(fails int) time.Duration {
+	if g.cfg.BackoffBase <= 0 || fails <= 0 {
+		return 0
+	}
+
+	wait := g.cfg.BackoffBase
+	for i := 1; i < fails; i++ {
+		wait *= 2
+		if g.cfg.BackoffMax > 0 && wait >= g.cfg.BackoffMax {
+			return g.cfg.BackoffMax
+		}
+	}
+	if g.cfg.BackoffMax > 0 && wait > g.cfg.BackoffMax {
+		return g.cfg.BackoffMax
+	}
+	return wait
+}
+
+// ipWait IP 잠금 남은 시간
+func (g *loginGuard) ipWait(ip string, now time.Time) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if f, ok := g.ips[ip]; ok && now.Before(f.lockedUntil) {
+		return f.lockedUntil.Sub(now)
+	}
+	return 0
+}
+
+// ipFailed IP 실패 횟수 증가 (IPWindow 안에서 IPMaxFailures에 닿으면 잠금)
+func (g *loginGuard) ipFailed(ip string, now time.Time) (int, bool) {
+	if ip == "" || g.cfg.IPMaxFailures <= 0 {
+		return 0, false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if now.Sub(g.lastSweep) >= g.cfg.IPWindow {
+		g.sweep(now)
+	}
+
+	f, ok := g.ips[ip]
+	if !ok || now.Sub(f.since) > g.cfg.IPWindow {
+		f = &ipFailures{since: now}
+		g.ips[ip] = f
+	}
+	f.count++
+
+	if f.count < g.cfg.IPMaxFailures {
+		return f.count, false
+	}
+
+	count := f.count
+	f.count = 0
+	f.since = now
+	f.lockedUntil = now.Add(g.cfg.LockDuration)
+	return count, true
+}
+
+// sweep 기간이 지나고 잠금도 끝난 IP 기록 제거 (잠금 상태에서 호출)
+func (g *loginGuard) sweep(now time.Time) {
+	for ip, f := range g.ips {
+		if now.Sub(f.since) > g.cfg.IPWindow && !now.Before(f.lockedUntil) {
+			delete(g.ips, ip)
+		}
+	}
+	g.lastSweep = now
+}
+
+// record 잠금 기록 저장 (실패해도 로그인 처리는 계속)
+func (g *loginGuard) record(ctx context.Context, lockout *Lockout) {
+	if err := g.repo.CreateLockout(ctx, lockout); err != nil {
+		logger.FromContext(ctx).Error("로그인 잠금 기록 실패: %v", err)
+	}
+}
+
+// retryAfter 대기 시간(초, 올림)을 retry_after 메타로 담은 에러
+func retryAfter(base *errors.AppError, wait time.Duration) error {
+	return base.WithMeta("retry_after", int(math.Ceil(wait.Seconds())))
+}
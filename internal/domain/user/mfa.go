@@ -0,0 +1,347 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"gin_starter/internal/middleware"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/totp"
+	"strings"
+	"time"
+)
+
+// mfaSkew 시계 차이를 감안해 앞뒤로 허용하는 TOTP 구간 수
+const mfaSkew = 1
+
+// recoveryEncoding 복구 코드 문자 (Crockford base32 소문자 - 숫자와 혼동되는 i, l, o와 u 제외)
+var recoveryEncoding = base32.NewEncoding("0123456789abcdefghjkmnpqrstvwxyz").WithPadding(base32.NoPadding)
+
+// recoveryConfusables 입력에서 숫자로 읽는 문자 (Crockford base32 디코딩 규칙)
+var recoveryConfusables = strings.NewReplacer("o", "0", "i", "1", "l", "1")
+
+// GetMFAStatus 2단계 인증 상태
+func (s *service) GetMFAStatus(ctx context.Context, userID string) (*MFAStatus, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MFAStatus{Enabled: user.MFAEnabled(), EnabledAt: user.MFAEnabledAt}
+	if status.Enabled {
+		if status.RecoveryCodesLeft, err = s.repo.CountRecoveryCodes(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// SetupMFA 2단계 인증 설정 시작 (새 시크릿 발급, EnableMFA로 코드를 확인해야 사용 시작)
+func (s *service) SetupMFA(ctx context.Context, userID string) (*MFASetupResponse, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled() {
+		return nil, errors.ErrMFAEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "MFA_SETUP_FAILED", "2단계 인증 설정에 실패했습니다")
+	}
+	sealed, err := middleware.SealSecret(s.config.JWT.TokenKeys, secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "MFA_SETUP_FAILED", "2단계 인증 설정에 실패했습니다")
+	}
+
+	if err := s.repo.Update(ctx, userID, map[string]interface{}{"u_mfa_secret": sealed, "u_mfa_last_step": 0}); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("2단계 인증 설정 시작: %s", userID)
+	return &MFASetupResponse{
+		Secret: secret,
+		URI:    totp.URI(s.config.MFA.Issuer, user.ID, secret),
+	}, nil
+}
+
+// EnableMFA 인증 앱 코드를 확인하고 2단계 인증 사용 시작 (복구 코드 발급)
+// 설정 전에 로그인한 다른 기기의 세션은 종료한다
+func (s *service) EnableMFA(ctx context.Context, userID, sessionID, code string) (*RecoveryCodesResponse, error) {
+	now := time.Now()
+
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled() {
+		return nil, errors.ErrMFAEnabled
+	}
+	if user.MFASecret == "" {
+		return nil, errors.ErrMFASetupRequired
+	}
+
+	if err := s.checkTOTP(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.issueRecoveryCodes(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, userID, map[string]interface{}{"u_mfa_enabled_at": now}); err != nil {
+		return nil, err
+	}
+	s.principals.Invalidate(userID)
+
+	if _, err := s.revokeOtherSessions(ctx, userID, sessionID, SessionRevokeMFA, now); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("2단계 인증 사용 시작: %s", userID)
+	return &RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableMFA 2단계 인증 해제 (비밀번호와 인증 앱 코드 또는 복구 코드 확인)
+func (s *service) DisableMFA(ctx context.Context, userID string, req *DisableMFARequest) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled() {
+		return errors.ErrMFANotEnabled
+	}
+
+	if !s.verifyPassword(ctx, user, req.Password) {
+		return errors.ErrInvalidPassword
+	}
+	if err := s.checkSecondFactor(ctx, user, req.Code, time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.clearMFA(ctx, userID); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("2단계 인증 해제: %s", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes 복구 코드 재발급 (이전 코드는 모두 무효)
+func (s *service) RegenerateRecoveryCodes(ctx context.Context, userID, code string) (*RecoveryCodesResponse, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled() {
+		return nil, errors.ErrMFANotEnabled
+	}
+
+	if err := s.checkTOTP(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.issueRecoveryCodes(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("복구 코드 재발급: %s", userID)
+	return &RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// CompleteMFALogin 2단계 인증 로그인 완료 (로그인 1단계에서 받은 mfa_token과 코드)
+// 틀린 코드는 비밀번호 실패와 같이 계정 실패 횟수에 들어가 잠금 대상이 된다
+func (s *service) CompleteMFALogin(ctx context.Context, req *MFALoginRequest) (*LoginResponse, error) {
+	now := time.Now()
+
+	pending, err := s.repo.FindUserToken(ctx, hashToken(req.MFAToken))
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalidLinkToken) {
+			return nil, errors.ErrMFATokenInvalid
+		}
+		return nil, err
+	}
+	if pending.Purpose != TokenPurposeMFA || !pending.Usable(now) {
+		return nil, errors.ErrMFATokenInvalid
+	}
+
+	user, err := s.repo.FindByID(ctx, pending.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled() {
+		return nil, errors.ErrMFATokenInvalid
+	}
+
+	if err := s.guard.check(user, req.IP, now); err != nil {
+		logger.FromContext(ctx).Warn("2단계 인증 거부 (잠금/대기 중): %s", user.ID)
+		return nil, err
+	}
+
+	if err := s.checkSecondFactor(ctx, user, req.Code, now); err != nil {
+		if !errors.Is(err, errors.ErrInvalidMFACode) {
+			return nil, err
+		}
+		if failed := s.guard.failed(ctx, user, req.IP, now); !errors.Is(failed, errors.ErrInvalidCredentials) {
+			return nil, failed
+		}
+		return nil, err
+	}
+
+	used, err := s.repo.UseUserToken(ctx, pending.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		// 같은 mfa_token으로 다른 요청이 먼저 로그인한 경우
+		return nil, errors.ErrMFATokenInvalid
+	}
+
+	if err := s.guard.succeeded(ctx, user); err != nil {
+		return nil, err
+	}
+
+	session, accessToken, refreshToken, err := s.startSession(ctx, user.ID, req.IP, req.UserAgent, now)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("로그인 성공 (2단계 인증): %s (세션: %s)", user.ID, session.ID)
+
+	return &LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         s.withAccess(ctx, user.ToPublic()),
+	}, nil
+}
+
+// startMFALogin 비밀번호 확인 후 2단계 인증 대기 토큰 발급
+func (s *service) startMFALogin(ctx context.Context, user *User) (*LoginResponse, error) {
+	token, err := s.issueUserToken(ctx, user, TokenPurposeMFA, s.config.MFA.LoginTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("로그인 2단계 인증 대기: %s", user.ID)
+	return &LoginResponse{MFARequired: true, MFAToken: token}, nil
+}
+
+// checkSecondFactor 인증 앱 코드(숫자 6자리) 또는 복구 코드 확인
+func (s *service) checkSecondFactor(ctx context.Context, user *User, code string, now time.Time) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return s.checkTOTP(ctx, user, code)
+	}
+
+	used, err := s.repo.UseRecoveryCode(ctx, user.ID, hashToken(normalizeRecoveryCode(code)), now)
+	if err != nil {
+		return err
+	}
+	// Crockford 문자로 바꾸기 전에 발급된 코드 (i, l, o가 그대로 해시됨)
+	if legacy := legacyRecoveryCode(code); !used && legacy != normalizeRecoveryCode(code) {
+		if used, err = s.repo.UseRecoveryCode(ctx, user.ID, hashToken(legacy), now); err != nil {
+			return err
+		}
+	}
+	if !used {
+		return errors.ErrInvalidMFACode
+	}
+
+	logger.FromContext(ctx).Warn("복구 코드로 2단계 인증: %s", user.ID)
+	return nil
+}
+
+// checkTOTP 인증 앱 코드 확인 (이미 사용한 코드는 거부)
+// 이전 키로 암호화된 시크릿은 확인에 성공하면 현재 키로 다시 암호화한다 (키 교체)
+func (s *service) checkTOTP(ctx context.Context, user *User, code string) error {
+	secret, err := middleware.OpenSecret(s.config.JWT.TokenKeys, user.MFASecret)
+	if err != nil {
+		logger.FromContext(ctx).Error("2단계 인증 시크릿 복호화 실패 (ID: %s): %v", user.ID, err)
+		return errors.Wrap(err, "MFA_SECRET_INVALID", "2단계 인증 정보를 읽을 수 없습니다. 관리자에게 문의해주세요")
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now(), mfaSkew)
+	if !ok || step <= user.MFALastStep {
+		return errors.ErrInvalidMFACode
+	}
+
+	used, err := s.repo.UseMFAStep(ctx, user.ID, step)
+	if err != nil {
+		return err
+	}
+	if !used {
+		// 같은 코드로 다른 요청이 먼저 인증한 경우
+		return errors.ErrInvalidMFACode
+	}
+	user.MFALastStep = step
+
+	if !strings.HasPrefix(user.MFASecret, s.config.JWT.TokenKeys.Primary+".") {
+		if sealed, err := middleware.SealSecret(s.config.JWT.TokenKeys, secret); err == nil {
+			if err := s.repo.Update(ctx, user.ID, map[string]interface{}{"u_mfa_secret": sealed}); err != nil {
+				logger.FromContext(ctx).Warn("2단계 인증 시크릿 재암호화 실패: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// issueRecoveryCodes 복구 코드 발급 (DB에는 해시만 저장하고 원문은 한 번만 돌려준다)
+func (s *service) issueRecoveryCodes(ctx context.Context, userID string, now time.Time) ([]string, error) {
+	codes := make([]string, s.config.MFA.RecoveryCodes)
+	hashes := make([]string, len(codes))
+	for i := range codes {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, errors.Wrap(err, "RECOVERY_CODE_CREATE_FAILED", "복구 코드 발급에 실패했습니다")
+		}
+		codes[i] = code
+		hashes[i] = hashToken(normalizeRecoveryCode(code))
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes, now); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// clearMFA 2단계 인증 정보 삭제 (시크릿, 복구 코드)
+func (s *service) clearMFA(ctx context.Context, userID string) error {
+	updates := map[string]interface{}{
+		"u_mfa_secret":     nil,
+		"u_mfa_enabled_at": nil,
+		"u_mfa_last_step":  0,
+	}
+	if err := s.repo.Update(ctx, userID, updates); err != nil {
+		return err
+	}
+	if _, err := s.repo.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+
+	s.principals.Invalidate(userID)
+	return nil
+}
+
+// newRecoveryCode 복구 코드 (50비트, xxxxx-xxxxx)
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := recoveryEncoding.EncodeToString(b)[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode 입력한 복구 코드 정규화 (대소문자, 하이픈, 밑줄, 공백 무시, o는 0, i/l은 1로 읽음)
+func normalizeRecoveryCode(code string) string {
+	return recoveryConfusables.Replace(legacyRecoveryCode(code))
+}
+
+// legacyRecoveryCode 혼동 문자를 바꾸지 않는 정규화 (이전 알파벳으로 발급된 코드 확인용)
+func legacyRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(code)
+}
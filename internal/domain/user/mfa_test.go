@@ -0,0 +1,101 @@
+package user_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"gin_starter/internal/domain/user"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/totp"
+)
+
+// enableMFA 인증 앱 등록과 2단계 인증 사용 시작
+func (e *oauthEnv) enableMFA(t *testing.T, id string) {
+	t.Helper()
+	ctx := context.Background()
+
+	setup, err := e.service.SetupMFA(ctx, id)
+	if err != nil {
+		t.Fatalf("2단계 인증 설정 실패: %v", err)
+	}
+	code, err := totp.Code(setup.Secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.service.EnableMFA(ctx, id, "", code); err != nil {
+		t.Fatalf("2단계 인증 사용 시작 실패: %v", err)
+	}
+}
+
+// setRecoveryCodes 복구 코드를 정해진 원문으로 교체 (DB에는 원문의 SHA-256 hex만 저장된다)
+func (e *oauthEnv) setRecoveryCodes(t *testing.T, id string, codes ...string) {
+	t.Helper()
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		sum := sha256.Sum256([]byte(code))
+		hashes[i] = hex.EncodeToString(sum[:])
+	}
+	if err := e.repo.ReplaceRecoveryCodes(context.Background(), id, hashes, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// completeMFA 비밀번호 로그인 후 2단계 인증 코드 입력
+func (e *oauthEnv) completeMFA(t *testing.T, id, code string) error {
+	t.Helper()
+
+	data := loginData(t, e.passwordLogin(t, id))
+	if !data.MFARequired || data.MFAToken == "" {
+		t.Fatalf("2단계 인증 대기 응답이 아님: %+v", data)
+	}
+
+	_, err := e.service.CompleteMFALogin(context.Background(), &user.MFALoginRequest{MFAToken: data.MFAToken, Code: code})
+	return err
+}
+
+func TestRecoveryCodeConfusableCharacters(t *testing.T) {
+	env := newOAuthEnv(t, nil)
+	env.register(t, "mfauser", "mfa@example.com", true)
+	env.enableMFA(t, "mfauser")
+
+	// 발급 코드 "10a0b-1c1d0"을 O(0), I/l(1)로 잘못 읽고 대문자, 공백으로 입력
+	env.setRecoveryCodes(t, "mfauser", "10a0b1c1d0")
+	if err := env.completeMFA(t, "mfauser", " lOA0B IC1DO "); err != nil {
+		t.Fatalf("혼동 문자 복구 코드 거부: %v", err)
+	}
+
+	// 한 번 쓴 코드는 다시 쓸 수 없다
+	if err := env.completeMFA(t, "mfauser", "10a0b-1c1d0"); !errors.Is(err, errors.ErrInvalidMFACode) {
+		t.Errorf("사용한 복구 코드 재사용 = %v", err)
+	}
+}
+
+func TestRecoveryCodeLegacyAlphabet(t *testing.T) {
+	ctx := context.Background()
+	env := newOAuthEnv(t, nil)
+	env.register(t, "mfauser", "mfa@example.com", true)
+	env.enableMFA(t, "mfauser")
+
+	// Crockford 문자로 바꾸기 전 발급된 코드는 i, l, o가 그대로 해시되어 있다
+	env.setRecoveryCodes(t, "mfauser", "ilo23u5678", "abcde23456")
+	if err := env.completeMFA(t, "mfauser", "ILO23-U5678"); err != nil {
+		t.Fatalf("이전 알파벳 복구 코드 거부: %v", err)
+	}
+
+	left, err := env.repo.CountRecoveryCodes(ctx, "mfauser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if left != 1 {
+		t.Errorf("남은 복구 코드 = %d, want 1", left)
+	}
+
+	// 사용 처리되어 같은 입력이 다시 통과하지 않는다
+	if err := env.completeMFA(t, "mfauser", "ilo23-u5678"); !errors.Is(err, errors.ErrInvalidMFACode) {
+		t.Errorf("사용한 이전 복구 코드 재사용 = %v", err)
+	}
+}
@@ -6,47 +6,248 @@ import (
 
 // User 사용자 모델
 type User struct {
-	ID           string    `json:"id" db:"u_id"`
-	Password     string    `json:"-" db:"u_pass"` // JSON 응답에서 제외
-	Name         string    `json:"name" db:"u_name"`
-	Email        string    `json:"email" db:"u_email"`
-	AuthType     string    `json:"auth_type" db:"u_auth_type"`
-	AuthLevel    int       `json:"auth_level" db:"u_auth_level"`
-	RefreshToken string    `json:"-" db:"u_re_token"` // JSON 응답에서 제외
-	CreatedAt    time.Time `json:"created_at" db:"u_regi_date"`
+	ID        string    `json:"id" db:"u_id"`
+	Password  string    `json:"-" db:"u_pass"` // JSON 응답에서 제외
+	Name      string    `json:"name" db:"u_name"`
+	Email     string    `json:"email" db:"u_email"`
+	AuthType  string    `json:"auth_type" db:"u_auth_type"`
+	AuthLevel int       `json:"auth_level" db:"u_auth_level"`
+	CreatedAt time.Time `json:"created_at" db:"u_regi_date"`
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at" db:"u_email_verified_at"` // nil이면 이메일 미인증
+
+	// 역할과 권한 (_user_roles, 로그인과 프로필 응답에만 포함)
+	Roles       []string `json:"roles,omitempty" db:"-"`
+	Permissions []string `json:"permissions,omitempty" db:"-"`
+
+	// 2단계 인증 (TOTP)
+	MFASecret    string     `json:"-" db:"u_mfa_secret"` // 암호화된 시크릿 (설정 중이거나 사용 중)
+	MFAEnabledAt *time.Time `json:"mfa_enabled_at" db:"u_mfa_enabled_at"`
+	MFALastStep  int64      `json:"-" db:"u_mfa_last_step"` // 마지막으로 사용한 코드의 시간 구간
+
+	// 로그인 실패 추적 (관리자 조회용, ToPublic에서 제외)
+	LoginFails  int        `json:"login_fails,omitempty" db:"u_login_fails"`
+	LoginFailAt *time.Time `json:"-" db:"u_login_fail_at"`
+	LockedUntil *time.Time `json:"locked_until,omitempty" db:"u_locked_until"`
+}
+
+// 로그인 잠금 범위
+const (
+	LockoutScopeAccount = "account" // 계정 연속 실패
+	LockoutScopeIP      = "ip"      // 같은 IP에서 여러 계정 실패
+)
+
+// Lockout 로그인 잠금 기록 (관리자 대시보드의 의심 활동)
+type Lockout struct {
+	ID          int64      `json:"id" db:"ll_idx"`
+	Scope       string     `json:"scope" db:"ll_scope"`
+	UserID      string     `json:"user_id,omitempty" db:"ll_user_id"`
+	IP          string     `json:"ip,omitempty" db:"ll_ip"`
+	FailCount   int        `json:"fail_count" db:"ll_fail_count"`
+	LockedUntil time.Time  `json:"locked_until" db:"ll_locked_until"`
+	UnlockedBy  string     `json:"unlocked_by,omitempty" db:"ll_unlocked_by"`
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty" db:"ll_unlocked_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"ll_regi_date"`
+}
+
+// 세션 종료 사유
+const (
+	SessionRevokeLogout   = "logout"   // 로그아웃
+	SessionRevokeReuse    = "reuse"    // 이미 교체된 리프레시 토큰 재사용 (탈취 의심)
+	SessionRevokeUser     = "user"     // 사용자가 다른 기기 세션 종료
+	SessionRevokeAdmin    = "admin"    // 관리자가 종료
+	SessionRevokePassword = "password" // 비밀번호 변경
+	SessionRevokeMFA      = "mfa"      // 2단계 인증 설정 (설정 전에 로그인한 다른 기기)
+)
+
+// Session 기기별 로그인 세션
+// 리프레시 토큰은 갱신할 때마다 교체되고, 세션에는 현재 토큰의 해시만 남는다 (같은 세션의 토큰들이 하나의 토큰 패밀리)
+type Session struct {
+	ID           string     `json:"id" db:"us_id"`
+	UserID       string     `json:"user_id" db:"us_user_id"`
+	TokenHash    string     `json:"-" db:"us_token_hash"`
+	UserAgent    string     `json:"user_agent" db:"us_user_agent"`
+	IP           string     `json:"ip" db:"us_ip"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty" db:"us_last_used"`
+	ExpiresAt    time.Time  `json:"expires_at" db:"us_expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty" db:"us_revoked_at"`
+	RevokeReason string     `json:"revoke_reason,omitempty" db:"us_revoke_reason"`
+	CreatedAt    time.Time  `json:"created_at" db:"us_regi_date"`
+	Current      bool       `json:"current,omitempty" db:"-"` // 요청한 토큰의 세션
+}
+
+// Active 종료되지 않았고 만료 전인 세션
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// APIKey 서버 간 호출용 API 키 (배치 작업, 외부 연동)
+// 키 원문은 발급할 때 한 번만 보여주고, DB에는 식별용 접두사와 원문 해시만 남긴다
+type APIKey struct {
+	ID         string     `json:"id" db:"ak_id"`
+	UserID     string     `json:"user_id" db:"ak_user_id"`
+	Name       string     `json:"name" db:"ak_name"`
+	Prefix     string     `json:"prefix" db:"ak_prefix"` // 키 앞부분 (어떤 키인지 알아보는 용도)
+	KeyHash    string     `json:"-" db:"ak_key_hash"`
+	Scopes     []string   `json:"scopes" db:"ak_scopes"`                 // 사용 범위 권한 (와일드카드 포함)
+	AllowIPs   []string   `json:"allow_ips,omitempty" db:"ak_allow_ips"` // 허용 IP/CIDR (비어 있으면 제한 없음)
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"ak_expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"ak_last_used"`
+	LastIP     string     `json:"last_ip,omitempty" db:"ak_last_ip"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"ak_revoked_at"`
+	RevokedBy  string     `json:"revoked_by,omitempty" db:"ak_revoked_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"ak_regi_date"`
+}
+
+// Expired 만료된 키
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// Identity 사용자 계정에 연결된 소셜 로그인 계정 (제공자마다 하나)
+type Identity struct {
+	ID          int64      `json:"-" db:"ui_idx"`
+	UserID      string     `json:"user_id" db:"ui_user_id"`
+	Provider    string     `json:"provider" db:"ui_provider"`
+	Subject     string     `json:"subject" db:"ui_subject"` // 제공자의 사용자 ID (sub)
+	Email       string     `json:"email,omitempty" db:"ui_email"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty" db:"ui_last_login"`
+	CreatedAt   time.Time  `json:"created_at" db:"ui_regi_date"`
+}
+
+// OAuthState 소셜 로그인 진행 중 정보 (제공자로 보낸 뒤 콜백까지, DB에는 state 해시만 저장)
+type OAuthState struct {
+	StateHash string    `db:"os_state_hash"`
+	Provider  string    `db:"os_provider"`
+	Verifier  string    `db:"os_verifier"` // PKCE code_verifier
+	Nonce     string    `db:"os_nonce"`
+	UserID    string    `db:"os_user_id"` // 계정 연결을 요청한 사용자 (비어 있으면 로그인)
+	ExpiresAt time.Time `db:"os_expires_at"`
+	CreatedAt time.Time `db:"os_regi_date"`
+}
+
+// EmailVerified 이메일 인증 여부
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// MFAEnabled 2단계 인증 사용 여부
+func (u *User) MFAEnabled() bool {
+	return u.MFAEnabledAt != nil
+}
+
+// HasPassword 비밀번호로 로그인할 수 있는 계정 (소셜 로그인으로 가입한 계정은 비밀번호가 없음)
+func (u *User) HasPassword() bool {
+	return u.Password != ""
+}
+
+// 일회용 토큰 용도
+const (
+	TokenPurposeVerify = "verify" // 이메일 인증
+	TokenPurposeReset  = "reset"  // 비밀번호 재설정
+	TokenPurposeMFA    = "mfa"    // 비밀번호 확인 후 2단계 인증 대기
+)
+
+// UserToken 메일로 보내는 일회용 토큰 (DB에는 해시만 저장)
+type UserToken struct {
+	ID        int64      `json:"id" db:"ut_idx"`
+	UserID    string     `json:"user_id" db:"ut_user_id"`
+	Purpose   string     `json:"purpose" db:"ut_purpose"`
+	TokenHash string     `json:"-" db:"ut_token_hash"`
+	Email     string     `json:"email,omitempty" db:"ut_email"` // 인증 대상 이메일 (그 사이 이메일을 바꿨으면 인증하지 않음)
+	ExpiresAt time.Time  `json:"expires_at" db:"ut_expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"ut_used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"ut_regi_date"`
+}
+
+// Usable 사용하지 않았고 만료 전인 토큰
+func (t *UserToken) Usable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
 }
 
 // CreateUserRequest 회원가입 요청
 type CreateUserRequest struct {
-	ID       string `json:"user_id" binding:"required"`
-	Password string `json:"user_pass" binding:"required"`
-	Name     string `json:"user_name" binding:"required"`
-	Email    string `json:"user_email" binding:"required,email"`
+	ID       string `json:"user_id" label:"아이디" validate:"required,min=3,max=20,pattern=alphanum"`
+	Password string `json:"user_pass" label:"비밀번호" validate:"required"`
+	Name     string `json:"user_name" label:"이름" validate:"required,min=2,max=50,pattern=koreng"`
+	Email    string `json:"user_email" label:"이메일" validate:"required,pattern=email"`
 }
 
 // UpdateUserRequest 회원정보 수정 요청
 type UpdateUserRequest struct {
-	Password string `json:"user_pass,omitempty"`
-	Name     string `json:"user_name,omitempty"`
-	Email    string `json:"user_email,omitempty"`
+	Password string `json:"user_pass,omitempty" label:"비밀번호"`
+	Name     string `json:"user_name,omitempty" label:"이름" validate:"min=2,max=50,pattern=koreng"`
+	Email    string `json:"user_email,omitempty" label:"이메일" validate:"pattern=email"`
 }
 
 // LoginRequest 로그인 요청
 type LoginRequest struct {
-	ID       string `json:"user_id" binding:"required"`
-	Password string `json:"user_pass" binding:"required"`
+	ID        string `json:"user_id" label:"아이디" validate:"required"`
+	Password  string `json:"user_pass" label:"비밀번호" validate:"required"`
+	IP        string `json:"-"` // 접속 IP (IP별 실패 추적, 세션 기록용, 핸들러가 채움)
+	UserAgent string `json:"-"` // 세션 기록용, 핸들러가 채움
 }
 
 // LoginResponse 로그인 응답
+// 2단계 인증 사용자는 토큰 대신 MFARequired와 MFAToken을 받고 /login/mfa로 로그인을 마친다
 type LoginResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	User         *User  `json:"user"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	User         *User  `json:"user,omitempty"`
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
+}
+
+// MFALoginRequest 2단계 인증 로그인 (인증 앱 코드 또는 복구 코드)
+type MFALoginRequest struct {
+	MFAToken  string `json:"mfa_token" label:"인증 토큰" validate:"required,max=100"`
+	Code      string `json:"code" label:"인증 코드" validate:"required,max=20"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// MFAStatus 2단계 인증 상태
+type MFAStatus struct {
+	Enabled           bool       `json:"enabled"`
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesLeft int64      `json:"recovery_codes_left"`
+}
+
+// MFASetupResponse 2단계 인증 설정 시작 (인증 앱 등록 정보)
+type MFASetupResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"` // QR 코드로 보여줄 값
+}
+
+// MFACodeRequest 인증 앱 코드 확인 요청 (설정 완료, 복구 코드 재발급)
+type MFACodeRequest struct {
+	Code string `json:"code" label:"인증 코드" validate:"required,min=6,max=6,pattern=number"`
+}
+
+// DisableMFARequest 2단계 인증 해제 요청
+type DisableMFARequest struct {
+	Password string `json:"user_pass" label:"비밀번호" validate:"required,max=50"`
+	Code     string `json:"code" label:"인증 코드" validate:"required,max=20"` // 인증 앱 코드 또는 복구 코드
+}
+
+// RecoveryCodesResponse 새로 발급한 복구 코드 (이때만 원문을 보여준다)
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 // RefreshTokenRequest 토큰 갱신 요청
 type RefreshTokenRequest struct {
-	RefreshToken string `json:"refresh_token" binding:"required"`
+	RefreshToken string `json:"refresh_token" label:"리프레시 토큰" validate:"required"`
+	IP           string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+// LogoutRequest 로그아웃 대상 (인증 미들웨어가 컨텍스트에 넣은 토큰 정보)
+type LogoutRequest struct {
+	UserID         string
+	SessionID      string
+	TokenID        string    // 요청에 사용한 액세스 토큰 jti
+	TokenExpiresAt time.Time // 요청에 사용한 액세스 토큰 만료 시각
 }
 
 // RefreshTokenResponse 토큰 갱신 응답
@@ -55,14 +256,78 @@ type RefreshTokenResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// ForgotPasswordRequest 비밀번호 재설정 메일 요청
+type ForgotPasswordRequest struct {
+	Email string `json:"user_email" label:"이메일" validate:"required,pattern=email"`
+}
+
+// ResetPasswordRequest 비밀번호 재설정 (메일로 받은 토큰)
+type ResetPasswordRequest struct {
+	Token    string `json:"token" label:"재설정 토큰" validate:"required,max=100"`
+	Password string `json:"user_pass" label:"비밀번호" validate:"required"`
+}
+
+// VerifyEmailRequest 이메일 인증 (메일 링크의 쿼리 또는 바디의 토큰)
+type VerifyEmailRequest struct {
+	Token string `json:"token" label:"인증 토큰" validate:"required,max=100"`
+}
+
+// ResendVerificationRequest 이메일 인증 메일 재발송 요청
+type ResendVerificationRequest struct {
+	Email string `json:"user_email" label:"이메일" validate:"required,pattern=email"`
+}
+
+// CreateAPIKeyRequest API 키 발급 요청
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" label:"이름" validate:"required"`
+	Scopes    []string   `json:"scopes" label:"사용 범위" validate:"required"` // 사용 범위 권한 (본인 권한 안에서, blog:* 같은 와일드카드 가능)
+	AllowIPs  []string   `json:"allow_ips" label:"허용 IP"`                  // 허용 IP/CIDR (비우면 제한 없음)
+	ExpiresAt *time.Time `json:"expires_at" label:"만료 시각"`                 // 만료 시각 (비우면 API_KEY_MAX_TTL_DAYS 뒤, 0이면 만료 없음)
+}
+
+// APIKeyCreatedResponse 발급한 API 키 (이때만 원문을 보여준다)
+type APIKeyCreatedResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// OAuthStart 소셜 로그인 시작 (URL로 사용자를 보내고, State는 콜백을 받을 브라우저 쿠키에 둔다)
+type OAuthStart struct {
+	URL    string `json:"url"`
+	State  string `json:"-"`
+	Secure bool   `json:"-"` // 콜백 주소가 https면 Secure 쿠키
+	MaxAge int    `json:"-"` // 쿠키 유효 시간 (초, OAUTH_STATE_TTL)
+}
+
+// OAuthCallbackRequest 제공자 콜백 (쿼리의 code, state와 시작할 때 저장한 쿠키의 state)
+type OAuthCallbackRequest struct {
+	Provider    string
+	Code        string
+	State       string
+	CookieState string
+	Error       string // 사용자가 거부하는 등 제공자가 보낸 error
+	IP          string
+	UserAgent   string
+}
+
+// OAuthResult 콜백 결과 (로그인이면 Login, 계정 연결이면 Linked)
+type OAuthResult struct {
+	Login  *LoginResponse
+	Linked *Identity
+}
+
 // ToPublic 비밀번호와 토큰 제거 후 반환
 func (u *User) ToPublic() *User {
 	return &User{
-		ID:        u.ID,
-		Name:      u.Name,
-		Email:     u.Email,
-		AuthType:  u.AuthType,
-		AuthLevel: u.AuthLevel,
-		CreatedAt: u.CreatedAt,
+		ID:              u.ID,
+		Name:            u.Name,
+		Email:           u.Email,
+		AuthType:        u.AuthType,
+		AuthLevel:       u.AuthLevel,
+		Roles:           u.Roles,
+		Permissions:     u.Permissions,
+		CreatedAt:       u.CreatedAt,
+		EmailVerifiedAt: u.EmailVerifiedAt,
+		MFAEnabledAt:    u.MFAEnabledAt,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,376 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"gin_starter/internal/config"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/oauth"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxOAuthNameLen 소셜 로그인으로 가입할 때 이름 최대 길이 (u_name 컬럼 크기)
+const maxOAuthNameLen = 50
+
+// newOAuthProviders 설정한 제공자 (OAUTH_PROVIDERS 순서의 이름 목록과 함께)
+func newOAuthProviders(cfg config.OAuthConfig) (map[string]*oauth.Provider, []string) {
+	providers := make(map[string]*oauth.Provider, len(cfg.Providers))
+	names := make([]string, 0, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		providers[p.Name] = oauth.New(oauth.Config{
+			Name:         p.Name,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			Issuer:       p.Issuer,
+			AuthURL:      p.AuthURL,
+			TokenURL:     p.TokenURL,
+			UserInfoURL:  p.UserInfoURL,
+			JWKSURL:      p.JWKSURL,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+		})
+		names = append(names, p.Name)
+	}
+	return providers, names
+}
+
+// OAuthProviders 사용할 수 있는 소셜 로그인 제공자 이름
+func (s *service) OAuthProviders() []string {
+	return s.oauthNames
+}
+
+// StartOAuth 소셜 로그인 시작 (userID가 있으면 그 계정에 소셜 계정 연결)
+// state, nonce, PKCE verifier를 만들어 저장하고 제공자 인가 주소를 돌려준다
+func (s *service) StartOAuth(ctx context.Context, provider, userID string) (*OAuthStart, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return nil, errors.ErrOAuthProviderNotFound
+	}
+
+	now := time.Now()
+
+	// 콜백까지 오지 않은 시도 정리 (실패해도 로그인은 계속)
+	if _, err := s.repo.DeleteExpiredOAuthStates(ctx, now); err != nil {
+		logger.FromContext(ctx).Warn("만료된 소셜 로그인 state 정리 실패: %v", err)
+	}
+
+	state, err := oauth.RandomString(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "OAUTH_STATE_CREATE_FAILED", "소셜 로그인 시작에 실패했습니다")
+	}
+	nonce, err := oauth.RandomString(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "OAUTH_STATE_CREATE_FAILED", "소셜 로그인 시작에 실패했습니다")
+	}
+	verifier, err := oauth.NewVerifier()
+	if err != nil {
+		return nil, errors.Wrap(err, "OAUTH_STATE_CREATE_FAILED", "소셜 로그인 시작에 실패했습니다")
+	}
+
+	// 디스커버리가 실패하면 state를 저장하지 않음
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		logger.FromContext(ctx).Error("소셜 로그인 시작 실패 (%s): %v", provider, err)
+		return nil, errors.ErrOAuthFailed.Wrap(err)
+	}
+
+	if err := s.repo.CreateOAuthState(ctx, &OAuthState{
+		StateHash: hashToken(state),
+		Provider:  provider,
+		Verifier:  verifier,
+		Nonce:     nonce,
+		UserID:    userID,
+		ExpiresAt: now.Add(s.config.OAuth.StateTTL),
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &OAuthStart{
+		URL:    authURL,
+		State:  state,
+		Secure: strings.HasPrefix(p.RedirectURL(), "https://"),
+		MaxAge: int(s.config.OAuth.StateTTL / time.Second),
+	}, nil
+}
+
+// CompleteOAuth 제공자 콜백 처리
+// 쿠키의 state와 쿼리의 state가 같고 저장된 state가 유효해야 코드를 교환한다 (한 번만 사용)
+// 계정 연결로 시작했으면 연결한 소셜 계정을, 로그인이면 로그인 결과(2단계 인증 대기 포함)를 돌려준다
+func (s *service) CompleteOAuth(ctx context.Context, req *OAuthCallbackRequest) (*OAuthResult, error) {
+	p, ok := s.providers[req.Provider]
+	if !ok {
+		return nil, errors.ErrOAuthProviderNotFound
+	}
+
+	if req.State == "" || subtle.ConstantTimeCompare([]byte(req.State), []byte(req.CookieState)) != 1 {
+		return nil, errors.ErrOAuthStateInvalid
+	}
+
+	now := time.Now()
+	state, err := s.repo.UseOAuthState(ctx, hashToken(req.State))
+	if err != nil {
+		return nil, err
+	}
+	if state.Provider != req.Provider || !now.Before(state.ExpiresAt) {
+		return nil, errors.ErrOAuthStateInvalid
+	}
+
+	if req.Error != "" {
+		logger.FromContext(ctx).Info("소셜 로그인 취소 (%s): %s", req.Provider, req.Error)
+		return nil, errors.ErrOAuthDenied.WithMeta("error", req.Error)
+	}
+	if req.Code == "" {
+		return nil, errors.ErrOAuthStateInvalid
+	}
+
+	token, err := p.Exchange(ctx, req.Code, state.Verifier)
+	if err != nil {
+		logger.FromContext(ctx).Warn("소셜 로그인 실패 (%s): %v", req.Provider, err)
+		return nil, errors.ErrOAuthFailed.Wrap(err)
+	}
+	identity, err := p.Identity(ctx, token, state.Nonce)
+	if err != nil {
+		logger.FromContext(ctx).Warn("소셜 로그인 실패 (%s): %v", req.Provider, err)
+		return nil, errors.ErrOAuthFailed.Wrap(err)
+	}
+
+	// 로그인한 사용자가 시작한 계정 연결
+	if state.UserID != "" {
+		if _, err := s.repo.FindByID(ctx, state.UserID); err != nil {
+			return nil, err
+		}
+		linked, err := s.linkIdentity(ctx, state.UserID, req.Provider, identity, now)
+		if err != nil {
+			return nil, err
+		}
+		return &OAuthResult{Linked: linked}, nil
+	}
+
+	user, err := s.oauthUser(ctx, req.Provider, identity, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 비밀번호 로그인과 같이 잠금, 이메일 미인증, 2단계 인증 확인
+	if err := s.guard.check(user, req.IP, now); err != nil {
+		logger.FromContext(ctx).Warn("소셜 로그인 거부 (잠금/대기 중): %s", user.ID)
+		return nil, err
+	}
+
+	if s.config.Account.UnverifiedAccess == config.UnverifiedNone && !user.EmailVerified() {
+		logger.FromContext(ctx).Info("소셜 로그인 거부 (이메일 미인증): %s", user.ID)
+		return nil, errors.ErrEmailNotVerified
+	}
+
+	if user.MFAEnabled() {
+		login, err := s.startMFALogin(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &OAuthResult{Login: login}, nil
+	}
+
+	session, accessToken, refreshToken, err := s.startSession(ctx, user.ID, req.IP, req.UserAgent, now)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("소셜 로그인 성공 (%s): %s (세션: %s)", req.Provider, user.ID, session.ID)
+
+	return &OAuthResult{Login: &LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         s.withAccess(ctx, user.ToPublic()),
+	}}, nil
+}
+
+// GetIdentities 사용자에게 연결된 소셜 계정
+func (s *service) GetIdentities(ctx context.Context, userID string) ([]Identity, error) {
+	return s.repo.FindIdentities(ctx, userID)
+}
+
+// UnlinkIdentity 소셜 계정 연결 해제
+// 비밀번호가 없는 계정은 로그인할 방법이 남도록 마지막 소셜 계정을 해제할 수 없다
+func (s *service) UnlinkIdentity(ctx context.Context, userID, provider string) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	identities, err := s.repo.FindIdentities(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	linked := false
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			linked = true
+			break
+		}
+	}
+	if !linked {
+		return errors.ErrIdentityNotFound
+	}
+	if !user.HasPassword() && len(identities) == 1 {
+		return errors.ErrOAuthLastLogin
+	}
+
+	deleted, err := s.repo.DeleteIdentity(ctx, userID, provider)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.ErrIdentityNotFound
+	}
+
+	logger.FromContext(ctx).Info("소셜 계정 연결 해제: %s (%s)", userID, provider)
+	return nil
+}
+
+// oauthUser 소셜 계정으로 로그인할 사용자
+// 연결된 계정 → 인증된 같은 이메일의 계정에 자동 연결(OAUTH_LINK_BY_EMAIL) → 새 계정 생성(OAUTH_AUTO_REGISTER) 순서
+func (s *service) oauthUser(ctx context.Context, provider string, identity *oauth.Identity, now time.Time) (*User, error) {
+	linked, err := s.repo.FindIdentity(ctx, provider, identity.Subject)
+	if err == nil {
+		// 로그인 기록 (실패해도 로그인은 계속)
+		if err := s.repo.TouchIdentity(ctx, linked.ID, identity.Email, now); err != nil {
+			logger.FromContext(ctx).Warn("소셜 계정 로그인 기록 실패 (ID: %s): %v", linked.UserID, err)
+		}
+		return s.repo.FindByID(ctx, linked.UserID)
+	}
+	if !errors.Is(err, errors.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	// 같은 이메일의 계정이 있으면 새로 만들지 않음
+	// 양쪽 모두 인증된 이메일일 때만 자동 연결 (남의 이메일로 가입해 둔 계정을 가로채지 않도록)
+	if identity.Email != "" {
+		user, err := s.repo.FindByEmail(ctx, identity.Email)
+		if err == nil {
+			if !s.config.OAuth.LinkByEmail || !identity.EmailVerified || !user.EmailVerified() {
+				logger.FromContext(ctx).Info("소셜 로그인 거부 (같은 이메일의 계정 있음, %s): %s", provider, user.ID)
+				return nil, errors.ErrOAuthAccountExists
+			}
+			if _, err := s.linkIdentity(ctx, user.ID, provider, identity, now); err != nil {
+				if errors.Is(err, errors.ErrOAuthProviderLinked) {
+					return nil, errors.ErrOAuthAccountExists
+				}
+				return nil, err
+			}
+			return user, nil
+		}
+		if !errors.Is(err, errors.ErrUserNotFound) {
+			return nil, err
+		}
+	}
+
+	if !s.config.OAuth.AutoRegister {
+		return nil, errors.ErrOAuthNotLinked
+	}
+	if identity.Email == "" {
+		return nil, errors.ErrOAuthEmailRequired
+	}
+
+	return s.registerOAuthUser(ctx, provider, identity, now)
+}
+
+// registerOAuthUser 소셜 계정으로 새 사용자 생성 (비밀번호 없음, 아이디는 <제공자>_<무작위 hex>)
+// 일반 가입 아이디는 영문, 숫자만 쓰므로 겹치지 않는다
+func (s *service) registerOAuthUser(ctx context.Context, provider string, identity *oauth.Identity, now time.Time) (*User, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, errors.Wrap(err, "USER_CREATE_FAILED", "사용자 생성에 실패했습니다")
+	}
+
+	user := &User{
+		ID:        provider + "_" + hex.EncodeToString(suffix),
+		Name:      oauthUserName(identity),
+		Email:     identity.Email,
+		AuthType:  "U", // 일반 사용자
+		AuthLevel: 1,   // 기본 레벨
+		CreatedAt: now,
+	}
+	if identity.EmailVerified {
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := s.repo.CreateOAuthUser(ctx, user, &Identity{
+		UserID:      user.ID,
+		Provider:    provider,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+		CreatedAt:   now,
+	}); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("소셜 로그인으로 새 사용자 등록 (%s): %s", provider, user.ID)
+
+	// 제공자가 인증하지 않은 이메일이면 인증 메일 (실패해도 가입은 완료 - 재발송 가능)
+	if !user.EmailVerified() {
+		if err := s.sendVerification(ctx, user); err != nil {
+			logger.FromContext(ctx).Warn("이메일 인증 메일 발송 실패: %s: %v", user.ID, err)
+		}
+	}
+
+	return user, nil
+}
+
+// linkIdentity 사용자 계정에 소셜 계정 연결 (이미 이 계정에 연결된 소셜 계정이면 그대로 반환)
+func (s *service) linkIdentity(ctx context.Context, userID, provider string, identity *oauth.Identity, now time.Time) (*Identity, error) {
+	existing, err := s.repo.FindIdentity(ctx, provider, identity.Subject)
+	if err == nil {
+		if existing.UserID != userID {
+			return nil, errors.ErrOAuthIdentityLinked
+		}
+		return existing, nil
+	}
+	if !errors.Is(err, errors.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	identities, err := s.repo.FindIdentities(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range identities {
+		if i.Provider == provider {
+			return nil, errors.ErrOAuthProviderLinked
+		}
+	}
+
+	linked := &Identity{
+		UserID:      userID,
+		Provider:    provider,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+		CreatedAt:   now,
+	}
+	if err := s.repo.CreateIdentity(ctx, linked); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("소셜 계정 연결: %s (%s)", userID, provider)
+	return linked, nil
+}
+
+// oauthUserName 가입할 때 쓸 이름 (제공자의 이름, 없으면 이메일 앞부분)
+func oauthUserName(identity *oauth.Identity) string {
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+	if utf8.RuneCountInString(name) > maxOAuthNameLen {
+		name = string([]rune(name)[:maxOAuthNameLen])
+	}
+	return name
+}
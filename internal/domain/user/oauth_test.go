@@ -0,0 +1,486 @@
+package user_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"gin_starter/internal/config"
+	"gin_starter/internal/domain/role"
+	"gin_starter/internal/domain/user"
+	"gin_starter/internal/infrastructure/database"
+	"gin_starter/internal/middleware"
+	"gin_starter/pkg/oauth/oauthtest"
+	"gin_starter/pkg/rbac"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	testProvider = "test"
+	testClientID = "test-client"
+	testPassword = "Passw0rd!x"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+
+	// config.Load는 한 번만 읽으므로 공통 값만 환경변수로 두고 DB, 제공자는 테스트마다 바꾼다
+	for key, value := range map[string]string{
+		"DB_DRIVER":               "sqlite",
+		"DB_NAME":                 ":memory:",
+		"JWT_SECRET":              strings.Repeat("a", 32),
+		"JWT_REFRESH_SECRET":      strings.Repeat("b", 32),
+		"JWT_TOKEN_SECRET":        strings.Repeat("c", 32),
+		"MAIL_DRIVER":             "memory",
+		"EMAIL_UNVERIFIED_ACCESS": "full",
+		"LOG_LEVEL":               "error",
+	} {
+		os.Setenv(key, value)
+	}
+	config.Load()
+
+	os.Exit(m.Run())
+}
+
+// oauthEnv 가짜 OIDC 제공자와 SQLite DB로 만든 사용자 라우트
+type oauthEnv struct {
+	idp     *oauthtest.Server
+	cfg     *config.Config
+	repo    user.Repository
+	service user.Service
+	router  *gin.Engine
+}
+
+// newOAuthEnv 테스트 환경 생성 (configure로 OAuth 설정 변경)
+func newOAuthEnv(t *testing.T, configure func(cfg *config.Config)) *oauthEnv {
+	t.Helper()
+	ctx := context.Background()
+
+	idp := oauthtest.NewServer(t, testClientID)
+
+	cfg := *config.Get()
+	cfg.Database.Database = filepath.Join(t.TempDir(), "test.db")
+	cfg.OAuth.Providers = []config.OAuthProviderConfig{{
+		Name:        testProvider,
+		ClientID:    testClientID,
+		Issuer:      idp.URL,
+		RedirectURL: "http://localhost/api/user/oauth/" + testProvider + "/callback",
+		Scopes:      []string{"openid", "email", "profile"},
+	}}
+	if configure != nil {
+		configure(&cfg)
+	}
+
+	db, err := database.Connect(&cfg)
+	if err != nil {
+		t.Fatalf("DB 연결 실패: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator := database.NewMigrator(db, database.MigrationsDir("../../../migrations", db))
+	migrator.Out = io.Discard
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("마이그레이션 실패: %v", err)
+	}
+
+	repo := user.NewRepository(db)
+	policy := rbac.NewPolicy(role.NewPolicyStore(db), time.Minute)
+	principals := middleware.NewPrincipalCache(user.NewPrincipalLoader(repo, policy), time.Minute)
+	service := user.NewService(repo, &cfg, nil, nil, principals)
+	handler := user.NewHandler(service)
+
+	router := gin.New()
+	g := router.Group("/api/user")
+	g.POST("/login", handler.Login)
+	g.GET("/oauth/:provider", handler.OAuthLogin)
+	g.GET("/oauth/:provider/callback", handler.OAuthCallback)
+
+	return &oauthEnv{idp: idp, cfg: &cfg, repo: repo, service: service, router: router}
+}
+
+// register 비밀번호로 가입 (verified면 이메일 인증 처리)
+func (e *oauthEnv) register(t *testing.T, id, email string, verified bool) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := e.service.Register(ctx, &user.CreateUserRequest{ID: id, Password: testPassword, Name: "Tester", Email: email}); err != nil {
+		t.Fatalf("가입 실패: %v", err)
+	}
+	if verified {
+		if ok, err := e.repo.VerifyEmail(ctx, id, email, time.Now()); err != nil || !ok {
+			t.Fatalf("이메일 인증 실패: %v", err)
+		}
+	}
+}
+
+// start 소셜 로그인 시작 (제공자 인가 주소와 state 쿠키)
+func (e *oauthEnv) start(t *testing.T) (string, *http.Cookie) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/user/oauth/"+testProvider, nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("시작 응답 %d: %s", rec.Code, rec.Body)
+	}
+
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == "oauth_state" {
+			return rec.Header().Get("Location"), cookie
+		}
+	}
+	t.Fatal("state 쿠키가 없음")
+	return "", nil
+}
+
+// authorize 제공자에서 로그인을 마친 콜백 주소
+func (e *oauthEnv) authorize(t *testing.T, authURL string, idpUser oauthtest.User) string {
+	t.Helper()
+
+	callback, err := e.idp.Authorize(authURL, idpUser)
+	if err != nil {
+		t.Fatalf("인가 실패: %v", err)
+	}
+	return callback
+}
+
+// callback 제공자가 돌려보낸 콜백 요청 (cookie가 nil이면 쿠키 없이)
+func (e *oauthEnv) callback(t *testing.T, callbackURL string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	t.Helper()
+
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodGet, u.RequestURI(), nil)
+	if cookie != nil {
+		req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// login 시작부터 콜백까지
+func (e *oauthEnv) login(t *testing.T, idpUser oauthtest.User) *httptest.ResponseRecorder {
+	t.Helper()
+
+	authURL, cookie := e.start(t)
+	return e.callback(t, e.authorize(t, authURL, idpUser), cookie)
+}
+
+// passwordLogin 비밀번호 로그인
+func (e *oauthEnv) passwordLogin(t *testing.T, id string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"user_id": id, "user_pass": testPassword})
+	req := httptest.NewRequest(http.MethodPost, "/api/user/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// apiResponse 공통 응답
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Error   struct {
+		Code string `json:"code"`
+	} `json:"error"`
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) apiResponse {
+	t.Helper()
+
+	var resp apiResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("응답 해석 실패: %v: %s", err, rec.Body)
+	}
+	return resp
+}
+
+// expectError 에러 응답의 상태와 코드 확인
+func expectError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+
+	resp := decode(t, rec)
+	if rec.Code != status || resp.Error.Code != code {
+		t.Fatalf("응답 %d %s, want %d %s: %s", rec.Code, resp.Error.Code, status, code, rec.Body)
+	}
+}
+
+// loginData 로그인 성공 응답의 data
+func loginData(t *testing.T, rec *httptest.ResponseRecorder) user.LoginResponse {
+	t.Helper()
+
+	resp := decode(t, rec)
+	if rec.Code != http.StatusOK || !resp.Success {
+		t.Fatalf("로그인 실패 %d: %s", rec.Code, rec.Body)
+	}
+
+	var data user.LoginResponse
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestOAuthCallbackReturnsLoginTokens(t *testing.T) {
+	e := newOAuthEnv(t, nil)
+	e.register(t, "tester1", "tester1@example.com", true)
+
+	passwordRec := e.passwordLogin(t, "tester1")
+	oauthRec := e.login(t, oauthtest.User{Subject: "sub-1", Email: "tester1@example.com", EmailVerified: true})
+
+	// 응답 형식이 같아야 함 (클라이언트가 같은 코드로 처리)
+	keys := func(rec *httptest.ResponseRecorder) []string {
+		var data map[string]json.RawMessage
+		json.Unmarshal(decode(t, rec).Data, &data)
+		var names []string
+		for name := range data {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	if got, want := keys(oauthRec), keys(passwordRec); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("콜백 응답 필드 %v, 로그인 응답 필드 %v", got, want)
+	}
+
+	passwordLogin, oauthLogin := loginData(t, passwordRec), loginData(t, oauthRec)
+
+	passwordUser, _ := json.Marshal(passwordLogin.User)
+	oauthUser, _ := json.Marshal(oauthLogin.User)
+	if !bytes.Equal(passwordUser, oauthUser) {
+		t.Errorf("사용자 정보가 다름\n로그인: %s\n콜백:   %s", passwordUser, oauthUser)
+	}
+
+	// 두 토큰 모두 같은 방식으로 검증되고 갱신된다 (세션은 각각)
+	var sessions []string
+	for name, login := range map[string]user.LoginResponse{"로그인": passwordLogin, "콜백": oauthLogin} {
+		claims, err := middleware.ValidateToken(login.AccessToken, e.cfg.JWT.AccessKeys, e.cfg.JWT.TokenKeys)
+		if err != nil {
+			t.Fatalf("%s 액세스 토큰 검증 실패: %v", name, err)
+		}
+		if claims.UserID != "tester1" || claims.SessionID == "" {
+			t.Errorf("%s 액세스 토큰 클레임 %+v", name, claims)
+		}
+		sessions = append(sessions, claims.SessionID)
+
+		if _, err := e.service.RefreshToken(context.Background(), &user.RefreshTokenRequest{RefreshToken: login.RefreshToken}); err != nil {
+			t.Errorf("%s 리프레시 토큰 갱신 실패: %v", name, err)
+		}
+	}
+	if sessions[0] == sessions[1] {
+		t.Error("로그인과 콜백이 같은 세션을 씀")
+	}
+}
+
+func TestOAuthCallbackStateCookie(t *testing.T) {
+	e := newOAuthEnv(t, nil)
+	idpUser := oauthtest.User{Subject: "sub-1", Email: "new@example.com", EmailVerified: true}
+
+	authURL, cookie := e.start(t)
+	if cookie.Path != "/api/user/oauth" || !cookie.HttpOnly || cookie.SameSite != http.SameSiteLaxMode {
+		t.Errorf("state 쿠키 속성 %+v", cookie)
+	}
+	if u, _ := url.Parse(authURL); u.Query().Get("state") != cookie.Value {
+		t.Fatal("인가 주소의 state와 쿠키가 다름")
+	}
+	callback := e.authorize(t, authURL, idpUser)
+
+	t.Run("쿠키 없음", func(t *testing.T) {
+		expectError(t, e.callback(t, callback, nil), http.StatusBadRequest, "OAUTH_STATE_INVALID")
+	})
+
+	t.Run("다른 로그인 시도의 쿠키", func(t *testing.T) {
+		_, other := e.start(t)
+		expectError(t, e.callback(t, callback, other), http.StatusBadRequest, "OAUTH_STATE_INVALID")
+	})
+
+	t.Run("쿠키와 state 일치", func(t *testing.T) {
+		// 쿠키가 맞지 않던 요청은 state를 소모하지 않음
+		rec := e.callback(t, callback, cookie)
+		loginData(t, rec)
+
+		deleted := false
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == "oauth_state" && c.MaxAge < 0 {
+				deleted = true
+			}
+		}
+		if !deleted {
+			t.Error("콜백 후 state 쿠키를 지우지 않음")
+		}
+	})
+}
+
+func TestOAuthStateOneTimeUse(t *testing.T) {
+	t.Run("재사용", func(t *testing.T) {
+		e := newOAuthEnv(t, nil)
+
+		authURL, cookie := e.start(t)
+		callback := e.authorize(t, authURL, oauthtest.User{Subject: "sub-1", Email: "new@example.com", EmailVerified: true})
+
+		loginData(t, e.callback(t, callback, cookie))
+		expectError(t, e.callback(t, callback, cookie), http.StatusBadRequest, "OAUTH_STATE_INVALID")
+	})
+
+	t.Run("제공자 오류도 state 소모", func(t *testing.T) {
+		e := newOAuthEnv(t, nil)
+
+		authURL, cookie := e.start(t)
+		u, _ := url.Parse(authURL)
+		denied := e.cfg.OAuth.Providers[0].RedirectURL + "?error=access_denied&state=" + url.QueryEscape(u.Query().Get("state"))
+
+		expectError(t, e.callback(t, denied, cookie), http.StatusBadRequest, "OAUTH_DENIED")
+		expectError(t, e.callback(t, denied, cookie), http.StatusBadRequest, "OAUTH_STATE_INVALID")
+	})
+
+	t.Run("만료", func(t *testing.T) {
+		e := newOAuthEnv(t, func(cfg *config.Config) { cfg.OAuth.StateTTL = time.Millisecond })
+
+		authURL, cookie := e.start(t)
+		callback := e.authorize(t, authURL, oauthtest.User{Subject: "sub-1", Email: "new@example.com", EmailVerified: true})
+		time.Sleep(10 * time.Millisecond)
+
+		expectError(t, e.callback(t, callback, cookie), http.StatusBadRequest, "OAUTH_STATE_INVALID")
+	})
+}
+
+func TestOAuthIDTokenChecks(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(claims jwt.MapClaims)
+	}{
+		{"nonce 다름", func(c jwt.MapClaims) { c["nonce"] = "other-nonce" }},
+		{"aud 다름", func(c jwt.MapClaims) { c["aud"] = "other-client" }},
+		{"iss 다름", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{"만료", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newOAuthEnv(t, nil)
+			e.idp.IDTokenClaims = tt.modify
+
+			rec := e.login(t, oauthtest.User{Subject: "sub-1", Email: "new@example.com", EmailVerified: true})
+			expectError(t, rec, http.StatusUnauthorized, "OAUTH_FAILED")
+		})
+	}
+}
+
+func TestOAuthLinkByEmail(t *testing.T) {
+	tests := []struct {
+		name          string
+		linkByEmail   bool
+		autoRegister  bool
+		localVerified bool
+		existing      bool // 같은 제공자의 다른 소셜 계정이 이미 연결됨
+		idpUser       oauthtest.User
+		status        int
+		code          string
+		userID        string // 성공 시 로그인한 사용자 ("test_"면 새로 가입)
+	}{
+		{
+			name: "양쪽 모두 인증된 이메일이면 연결", linkByEmail: true, localVerified: true,
+			idpUser: oauthtest.User{Subject: "sub-1", Email: "tester1@example.com", EmailVerified: true},
+			status:  http.StatusOK, userID: "tester1",
+		},
+		{
+			name: "제공자가 인증하지 않은 이메일", linkByEmail: true, localVerified: true,
+			idpUser: oauthtest.User{Subject: "sub-1", Email: "tester1@example.com", EmailVerified: false},
+			status:  http.StatusConflict, code: "OAUTH_ACCOUNT_EXISTS",
+		},
+		{
+			name: "우리 쪽에서 인증하지 않은 이메일", linkByEmail: true, localVerified: false,
+			idpUser: oauthtest.User{Subject: "sub-1", Email: "tester1@example.com", EmailVerified: true},
+			status:  http.StatusConflict, code: "OAUTH_ACCOUNT_EXISTS",
+		},
+		{
+			name: "OAUTH_LINK_BY_EMAIL=false", linkByEmail: false, localVerified: true,
+			idpUser: oauthtest.User{Subject: "sub-1", Email: "tester1@example.com", EmailVerified: true},
+			status:  http.StatusConflict, code: "OAUTH_ACCOUNT_EXISTS",
+		},
+		{
+			name: "같은 제공자의 다른 소셜 계정이 연결됨", linkByEmail: true, localVerified: true, existing: true,
+			idpUser: oauthtest.User{Subject: "sub-2", Email: "tester1@example.com", EmailVerified: true},
+			status:  http.StatusConflict, code: "OAUTH_ACCOUNT_EXISTS",
+		},
+		{
+			name: "이메일이 다르면 새로 가입", linkByEmail: true, autoRegister: true, localVerified: true,
+			idpUser: oauthtest.User{Subject: "sub-1", Email: "other@example.com", EmailVerified: true},
+			status:  http.StatusOK, userID: "test_",
+		},
+		{
+			name: "이메일이 다르고 자동 가입 안 함", linkByEmail: true, localVerified: true,
+			idpUser: oauthtest.User{Subject: "sub-1", Email: "other@example.com", EmailVerified: true},
+			status:  http.StatusForbidden, code: "OAUTH_NOT_LINKED",
+		},
+		{
+			name: "이메일을 알려주지 않으면 가입 불가", linkByEmail: true, autoRegister: true, localVerified: true,
+			idpUser: oauthtest.User{Subject: "sub-1"},
+			status:  http.StatusForbidden, code: "OAUTH_EMAIL_REQUIRED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newOAuthEnv(t, func(cfg *config.Config) {
+				cfg.OAuth.LinkByEmail = tt.linkByEmail
+				cfg.OAuth.AutoRegister = tt.autoRegister
+			})
+			e.register(t, "tester1", "tester1@example.com", tt.localVerified)
+			if tt.existing {
+				if err := e.repo.CreateIdentity(context.Background(), &user.Identity{
+					UserID: "tester1", Provider: testProvider, Subject: "sub-1", CreatedAt: time.Now(),
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			rec := e.login(t, tt.idpUser)
+			if tt.status != http.StatusOK {
+				expectError(t, rec, tt.status, tt.code)
+
+				// 거부한 경우 연결하지 않음
+				identities, err := e.repo.FindIdentities(context.Background(), "tester1")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := map[bool]int{true: 1, false: 0}[tt.existing]; len(identities) != want {
+					t.Errorf("연결된 소셜 계정 %d개, want %d", len(identities), want)
+				}
+				return
+			}
+
+			login := loginData(t, rec)
+			if !strings.HasPrefix(login.User.ID, tt.userID) {
+				t.Fatalf("로그인한 사용자 %s, want %s", login.User.ID, tt.userID)
+			}
+			if tt.userID == "test_" && (login.User.Email != tt.idpUser.Email || login.User.EmailVerifiedAt == nil) {
+				t.Errorf("새 사용자 이메일 %s (인증 %v)", login.User.Email, login.User.EmailVerifiedAt)
+			}
+
+			// 연결된 뒤에는 이메일 없이 sub만으로 같은 사용자
+			again := e.login(t, oauthtest.User{Subject: tt.idpUser.Subject})
+			if got := loginData(t, again).User.ID; got != login.User.ID {
+				t.Errorf("다시 로그인한 사용자 %s, want %s", got, login.User.ID)
+			}
+		})
+	}
+}
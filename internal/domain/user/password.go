@@ -0,0 +1,129 @@
+package user
+
+import (
+	"context"
+	"gin_starter/internal/config"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/password"
+	"time"
+)
+
+// bcryptMaxBytes bcrypt가 사용하는 최대 입력 길이 (넘으면 해싱 실패)
+const bcryptMaxBytes = 72
+
+// newPasswordHasher PASSWORD_HASH 설정으로 해셔 생성
+func newPasswordHasher(cfg config.PasswordConfig) *password.Hasher {
+	return password.NewHasher(cfg.Algorithm, cfg.BcryptCost, password.Argon2Params{
+		Memory:  uint32(cfg.Argon2Memory),
+		Time:    uint32(cfg.Argon2Time),
+		Threads: uint8(cfg.Argon2Threads),
+	})
+}
+
+// newPasswordPolicy PASSWORD_* 설정으로 정책 생성
+func newPasswordPolicy(cfg config.PasswordConfig) *password.Policy {
+	rules := password.Rules{
+		MinLength:        cfg.MinLength,
+		MaxLength:        cfg.MaxLength,
+		MinClasses:       cfg.MinClasses,
+		DisallowIdentity: cfg.DisallowIdentity,
+		Blocklist:        cfg.Blocklist,
+	}
+	if cfg.Algorithm == password.AlgBcrypt {
+		rules.MaxBytes = bcryptMaxBytes
+	}
+	return password.NewPolicy(rules)
+}
+
+// checkPassword 새 비밀번호 정책 검사 (user가 있으면 현재, 최근 비밀번호 재사용도 확인)
+// 위반하면 details.violations에 항목을 담은 PASSWORD_POLICY
+func (s *service) checkPassword(ctx context.Context, plain, userID, email string, user *User) error {
+	violations := s.policy.Check(plain, userID, email)
+
+	if len(violations) == 0 && user != nil && s.config.Password.History > 0 {
+		reused, err := s.passwordReused(ctx, user, plain)
+		if err != nil {
+			return err
+		}
+		if reused {
+			violations = append(violations, password.Reused(s.config.Password.History))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.ErrPasswordPolicy.WithMeta("violations", violations)
+}
+
+// passwordReused 현재 비밀번호나 최근 PASSWORD_HISTORY개 비밀번호와 같은지
+func (s *service) passwordReused(ctx context.Context, user *User, plain string) (bool, error) {
+	hashes, err := s.repo.FindPasswordHistory(ctx, user.ID, s.config.Password.History)
+	if err != nil {
+		return false, err
+	}
+
+	for _, hash := range append([]string{user.Password}, hashes...) {
+		if ok, _ := s.hasher.Verify(hash, plain); ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hashPassword 현재 설정으로 비밀번호 해싱
+func (s *service) hashPassword(ctx context.Context, plain string) (string, error) {
+	hashed, err := s.hasher.Hash(plain)
+	if err != nil {
+		logger.FromContext(ctx).Error("비밀번호 해싱 실패: %v", err)
+		return "", errors.Wrap(err, "PASSWORD_HASH_FAILED", "비밀번호 처리에 실패했습니다")
+	}
+	return hashed, nil
+}
+
+// recordPassword 새 비밀번호 해시를 이력에 추가 (PASSWORD_HISTORY가 0이면 저장 안 함)
+func (s *service) recordPassword(ctx context.Context, userID, hash string, at time.Time) error {
+	if s.config.Password.History <= 0 {
+		return nil
+	}
+	return s.repo.AddPasswordHistory(ctx, userID, hash, s.config.Password.History, at)
+}
+
+// verifyPassword 비밀번호 확인 (소셜 로그인으로 가입해 비밀번호가 없는 계정은 항상 실패)
+func (s *service) verifyPassword(ctx context.Context, user *User, plain string) bool {
+	if !user.HasPassword() {
+		return false
+	}
+
+	ok, err := s.hasher.Verify(user.Password, plain)
+	if err != nil {
+		logger.FromContext(ctx).Error("비밀번호 확인 실패 (ID: %s): %v", user.ID, err)
+	}
+	return ok
+}
+
+// upgradePassword 로그인 성공 시 해싱 설정(알고리즘, cost)이 바뀌었으면 다시 해싱
+// 실패해도 로그인은 계속하고 다음 로그인에서 다시 시도한다
+func (s *service) upgradePassword(ctx context.Context, user *User, plain string) {
+	if !s.hasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashed, err := s.hasher.Hash(plain)
+	if err != nil {
+		logger.FromContext(ctx).Warn("비밀번호 재해싱 실패 (ID: %s): %v", user.ID, err)
+		return
+	}
+
+	// 그 사이 비밀번호가 바뀌었으면 덮어쓰지 않음
+	updated, err := s.repo.UpdatePasswordHash(ctx, user.ID, user.Password, hashed)
+	if err != nil {
+		logger.FromContext(ctx).Warn("비밀번호 재해싱 저장 실패 (ID: %s): %v", user.ID, err)
+		return
+	}
+	if updated {
+		user.Password = hashed
+		logger.FromContext(ctx).Info("비밀번호 해시 갱신 (%s): %s", s.config.Password.Algorithm, user.ID)
+	}
+}
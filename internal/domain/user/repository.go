@@ -1,39 +1,107 @@
 package user
 
 import (
+	"context"
 	"database/sql"
 	"gin_starter/internal/infrastructure/database"
 	"gin_starter/pkg/errors"
 	"gin_starter/pkg/logger"
+	"strings"
+	"time"
 )
 
 // Repository 사용자 리포지토리 인터페이스
 type Repository interface {
-	Create(user *User) error
-	CreateTx(tx *sql.Tx, user *User) error
-	FindByID(id string) (*User, error)
-	FindByEmail(email string) (*User, error)
-	Update(id string, updates map[string]interface{}) error
-	UpdateTx(tx *sql.Tx, id string, updates map[string]interface{}) error
-	Delete(id string) error
-	Exists(id string) (bool, error)
-	UpdateRefreshToken(id string, refreshToken string) error
-	UpdateRefreshTokenTx(tx *sql.Tx, id string, refreshToken string) error
+	Create(ctx context.Context, user *User) error
+	CreateTx(ctx context.Context, tx *sql.Tx, user *User) error
+	FindByID(ctx context.Context, id string) (*User, error)
+	FindByEmail(ctx context.Context, email string) (*User, error)
+	Update(ctx context.Context, id string, updates map[string]interface{}) error
+	UpdateTx(ctx context.Context, tx *sql.Tx, id string, updates map[string]interface{}) error
+	Delete(ctx context.Context, id string) error
+	Exists(ctx context.Context, id string) (bool, error)
+
+	// 로그인 실패 추적, 잠금
+	RecordLoginFailure(ctx context.Context, id string, restart bool, at time.Time) (int, error)
+	LockLogin(ctx context.Context, id string, until time.Time) error
+	ResetLoginFailures(ctx context.Context, id string) error
+	CreateLockout(ctx context.Context, lockout *Lockout) error
+	ReleaseLockouts(ctx context.Context, userID, unlockedBy string, at time.Time) (int64, error)
+	FindLockouts(ctx context.Context, page, limit int) ([]Lockout, int64, error)
+
+	// 로그인 세션 (기기별 리프레시 토큰)
+	CreateSession(ctx context.Context, session *Session) error
+	FindSession(ctx context.Context, id string) (*Session, error)
+	FindActiveSessions(ctx context.Context, userID string, now time.Time) ([]Session, error)
+	RotateSession(ctx context.Context, id, oldHash, newHash string, expiresAt time.Time, ip, userAgent string, at time.Time) (bool, error)
+	RevokeSession(ctx context.Context, userID, id, reason string, at time.Time) (bool, error)
+	RevokeSessions(ctx context.Context, userID, exceptID, reason string, at time.Time) (int64, error)
+	DeleteExpiredSessions(ctx context.Context, userID string, now time.Time) (int64, error)
+	DeleteSessions(ctx context.Context, userID string) (int64, error)
+
+	// 이메일 인증, 비밀번호 재설정 일회용 토큰
+	CreateUserToken(ctx context.Context, token *UserToken) error
+	FindUserToken(ctx context.Context, tokenHash string) (*UserToken, error)
+	UseUserToken(ctx context.Context, id int64, at time.Time) (bool, error)
+	DeleteUserTokens(ctx context.Context, userID, purpose string) (int64, error)
+	VerifyEmail(ctx context.Context, id, email string, at time.Time) (bool, error)
+
+	// 2단계 인증
+	UseMFAStep(ctx context.Context, id string, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string, at time.Time) error
+	UseRecoveryCode(ctx context.Context, userID, hash string, at time.Time) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID string) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, userID string) (int64, error)
+
+	// 비밀번호 변경 이력, 재해싱
+	UpdatePasswordHash(ctx context.Context, id, oldHash, newHash string) (bool, error)
+	FindPasswordHistory(ctx context.Context, userID string, limit int) ([]string, error)
+	AddPasswordHistory(ctx context.Context, userID, hash string, keep int, at time.Time) error
+	DeletePasswordHistory(ctx context.Context, userID string) (int64, error)
+
+	// 사용자 역할
+	FindRoles(ctx context.Context, userID string) ([]string, error)
+	ReplaceRoles(ctx context.Context, userID string, roles []string, grantedBy string, at time.Time) error
+	DeleteRoles(ctx context.Context, userID string) (int64, error)
+
+	// API 키
+	CreateAPIKey(ctx context.Context, key *APIKey) error
+	FindAPIKeyByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	FindAPIKeys(ctx context.Context, userID string) ([]APIKey, error)
+	CountActiveAPIKeys(ctx context.Context, userID string, now time.Time) (int64, error)
+	TouchAPIKey(ctx context.Context, id, ip string, at time.Time) error
+	RevokeAPIKey(ctx context.Context, userID, id, revokedBy string, at time.Time) (bool, error)
+	RevokeAPIKeys(ctx context.Context, userID, revokedBy string, at time.Time) (int64, error)
+	DeleteAPIKeys(ctx context.Context, userID string) (int64, error)
+
+	// 소셜 로그인
+	CreateOAuthUser(ctx context.Context, user *User, identity *Identity) error
+	CreateIdentity(ctx context.Context, identity *Identity) error
+	FindIdentity(ctx context.Context, provider, subject string) (*Identity, error)
+	FindIdentities(ctx context.Context, userID string) ([]Identity, error)
+	TouchIdentity(ctx context.Context, id int64, email string, at time.Time) error
+	DeleteIdentity(ctx context.Context, userID, provider string) (bool, error)
+	DeleteIdentities(ctx context.Context, userID string) (int64, error)
+	CreateOAuthState(ctx context.Context, state *OAuthState) error
+	UseOAuthState(ctx context.Context, stateHash string) (*OAuthState, error)
+	DeleteExpiredOAuthStates(ctx context.Context, now time.Time) (int64, error)
 }
 
 type repository struct {
+	db   *database.DB
 	base *database.Repository
 }
 
 // NewRepository 리포지토리 생성자
 func NewRepository(db *database.DB) Repository {
 	return &repository{
+		db:   db,
 		base: database.NewRepository(db),
 	}
 }
 
-// Create 사용자 생성
-func (r *repository) Create(user *User) error {
+// Create 사용자 생성 (u_auth_type과 같은 역할도 함께 부여)
+func (r *repository) Create(ctx context.Context, user *User) error {
 	data := map[string]interface{}{
 		"u_id":         user.ID,
 		"u_pass":       user.Password,
@@ -43,17 +111,27 @@ func (r *repository) Create(user *User) error {
 		"u_auth_level": user.AuthLevel,
 	}
 
-	_, err := r.base.Insert("_user", data)
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.base.Insert(ctx, "_user", data); err != nil {
+			return err
+		}
+		_, err := r.base.Insert(ctx, "_user_roles", map[string]interface{}{
+			"ur_user_id":   user.ID,
+			"ur_role":      user.AuthType,
+			"ur_regi_date": time.Now(),
+		})
+		return err
+	})
 	if err != nil {
-		logger.Error("사용자 생성 실패: %v", err)
+		logger.FromContext(ctx).Error("사용자 생성 실패: %v", err)
 		return errors.Wrap(err, "USER_CREATE_FAILED", "사용자 생성에 실패했습니다")
 	}
 
 	return nil
 }
 
-// CreateTx 트랜잭션 내에서 사용자 생성
-func (r *repository) CreateTx(tx *sql.Tx, user *User) error {
+// CreateTx 트랜잭션 내에서 사용자 생성 (u_auth_type과 같은 역할도 함께 부여)
+func (r *repository) CreateTx(ctx context.Context, tx *sql.Tx, user *User) error {
 	data := map[string]interface{}{
 		"u_id":         user.ID,
 		"u_pass":       user.Password,
@@ -63,9 +141,16 @@ func (r *repository) CreateTx(tx *sql.Tx, user *User) error {
 		"u_auth_level": user.AuthLevel,
 	}
 
-	_, err := r.base.InsertTx(tx, "_user", data)
+	_, err := r.base.InsertTx(ctx, tx, "_user", data)
+	if err == nil {
+		_, err = r.base.InsertTx(ctx, tx, "_user_roles", map[string]interface{}{
+			"ur_user_id":   user.ID,
+			"ur_role":      user.AuthType,
+			"ur_regi_date": time.Now(),
+		})
+	}
 	if err != nil {
-		logger.Error("사용자 생성 실패 (TX): %v", err)
+		logger.FromContext(ctx).Error("사용자 생성 실패 (TX): %v", err)
 		return errors.Wrap(err, "USER_CREATE_FAILED", "사용자 생성에 실패했습니다")
 	}
 
@@ -73,14 +158,20 @@ func (r *repository) CreateTx(tx *sql.Tx, user *User) error {
 }
 
 // FindByID ID로 사용자 조회
-func (r *repository) FindByID(id string) (*User, error) {
-	query := `SELECT u_id, u_pass, u_name, u_email, u_auth_type, u_auth_level, u_re_token, u_regi_date
+func (r *repository) FindByID(ctx context.Context, id string) (*User, error) {
+	query := `SELECT u_id, u_pass, u_name, u_email, u_auth_type, u_auth_level, u_regi_date,
+	                 u_login_fails, u_login_fail_at, u_locked_until, u_email_verified_at,
+	                 u_mfa_secret, u_mfa_enabled_at, u_mfa_last_step
 	          FROM _user WHERE u_id = ?`
 
 	user := &User{}
-	err := r.base.QueryRow(query, id).Scan(
+	var failAt, lockedUntil, verifiedAt, mfaEnabledAt sql.NullTime
+	var mfaSecret sql.NullString
+	err := r.base.QueryRow(ctx, query, id).Scan(
 		&user.ID, &user.Password, &user.Name, &user.Email,
-		&user.AuthType, &user.AuthLevel, &user.RefreshToken, &user.CreatedAt,
+		&user.AuthType, &user.AuthLevel, &user.CreatedAt,
+		&user.LoginFails, &failAt, &lockedUntil, &verifiedAt,
+		&mfaSecret, &mfaEnabledAt, &user.MFALastStep,
 	)
 
 	if err == sql.ErrNoRows {
@@ -88,22 +179,33 @@ func (r *repository) FindByID(id string) (*User, error) {
 	}
 
 	if err != nil {
-		logger.Error("사용자 조회 실패 (ID: %s): %v", id, err)
+		logger.FromContext(ctx).Error("사용자 조회 실패 (ID: %s): %v", id, err)
 		return nil, errors.Wrap(err, "USER_FIND_FAILED", "사용자 조회에 실패했습니다")
 	}
+	user.LoginFailAt = nullTime(failAt)
+	user.LockedUntil = nullTime(lockedUntil)
+	user.EmailVerifiedAt = nullTime(verifiedAt)
+	user.MFASecret = mfaSecret.String
+	user.MFAEnabledAt = nullTime(mfaEnabledAt)
 
 	return user, nil
 }
 
 // FindByEmail 이메일로 사용자 조회
-func (r *repository) FindByEmail(email string) (*User, error) {
-	query := `SELECT u_id, u_pass, u_name, u_email, u_auth_type, u_auth_level, u_re_token, u_regi_date
+func (r *repository) FindByEmail(ctx context.Context, email string) (*User, error) {
+	query := `SELECT u_id, u_pass, u_name, u_email, u_auth_type, u_auth_level, u_regi_date,
+	                 u_login_fails, u_login_fail_at, u_locked_until, u_email_verified_at,
+	                 u_mfa_secret, u_mfa_enabled_at, u_mfa_last_step
 	          FROM _user WHERE u_email = ?`
 
 	user := &User{}
-	err := r.base.QueryRow(query, email).Scan(
+	var failAt, lockedUntil, verifiedAt, mfaEnabledAt sql.NullTime
+	var mfaSecret sql.NullString
+	err := r.base.QueryRow(ctx, query, email).Scan(
 		&user.ID, &user.Password, &user.Name, &user.Email,
-		&user.AuthType, &user.AuthLevel, &user.RefreshToken, &user.CreatedAt,
+		&user.AuthType, &user.AuthLevel, &user.CreatedAt,
+		&user.LoginFails, &failAt, &lockedUntil, &verifiedAt,
+		&mfaSecret, &mfaEnabledAt, &user.MFALastStep,
 	)
 
 	if err == sql.ErrNoRows {
@@ -111,18 +213,23 @@ func (r *repository) FindByEmail(email string) (*User, error) {
 	}
 
 	if err != nil {
-		logger.Error("사용자 조회 실패 (Email: %s): %v", email, err)
+		logger.FromContext(ctx).Error("사용자 조회 실패 (Email: %s): %v", email, err)
 		return nil, errors.Wrap(err, "USER_FIND_FAILED", "사용자 조회에 실패했습니다")
 	}
+	user.LoginFailAt = nullTime(failAt)
+	user.LockedUntil = nullTime(lockedUntil)
+	user.EmailVerifiedAt = nullTime(verifiedAt)
+	user.MFASecret = mfaSecret.String
+	user.MFAEnabledAt = nullTime(mfaEnabledAt)
 
 	return user, nil
 }
 
 // Update 사용자 정보 수정
-func (r *repository) Update(id string, updates map[string]interface{}) error {
-	affected, err := r.base.Update("_user", updates, "u_id = ?", id)
+func (r *repository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+	affected, err := r.base.Update(ctx, "_user", updates, "u_id = ?", id)
 	if err != nil {
-		logger.Error("사용자 수정 실패 (ID: %s): %v", id, err)
+		logger.FromContext(ctx).Error("사용자 수정 실패 (ID: %s): %v", id, err)
 		return errors.Wrap(err, "USER_UPDATE_FAILED", "사용자 수정에 실패했습니다")
 	}
 
@@ -134,10 +241,10 @@ func (r *repository) Update(id string, updates map[string]interface{}) error {
 }
 
 // UpdateTx 트랜잭션 내에서 사용자 정보 수정
-func (r *repository) UpdateTx(tx *sql.Tx, id string, updates map[string]interface{}) error {
-	affected, err := r.base.UpdateTx(tx, "_user", updates, "u_id = ?", id)
+func (r *repository) UpdateTx(ctx context.Context, tx *sql.Tx, id string, updates map[string]interface{}) error {
+	affected, err := r.base.UpdateTx(ctx, tx, "_user", updates, "u_id = ?", id)
 	if err != nil {
-		logger.Error("사용자 수정 실패 (TX, ID: %s): %v", id, err)
+		logger.FromContext(ctx).Error("사용자 수정 실패 (TX, ID: %s): %v", id, err)
 		return errors.Wrap(err, "USER_UPDATE_FAILED", "사용자 수정에 실패했습니다")
 	}
 
@@ -149,10 +256,10 @@ func (r *repository) UpdateTx(tx *sql.Tx, id string, updates map[string]interfac
 }
 
 // Delete 사용자 삭제
-func (r *repository) Delete(id string) error {
-	affected, err := r.base.Delete("_user", "u_id = ?", id)
+func (r *repository) Delete(ctx context.Context, id string) error {
+	affected, err := r.base.Delete(ctx, "_user", "u_id = ?", id)
 	if err != nil {
-		logger.Error("사용자 삭제 실패 (ID: %s): %v", id, err)
+		logger.FromContext(ctx).Error("사용자 삭제 실패 (ID: %s): %v", id, err)
 		return errors.Wrap(err, "USER_DELETE_FAILED", "사용자 삭제에 실패했습니다")
 	}
 
@@ -164,30 +271,1028 @@ func (r *repository) Delete(id string) error {
 }
 
 // Exists 사용자 존재 여부 확인
-func (r *repository) Exists(id string) (bool, error) {
-	exists, err := r.base.Exists("_user", "u_id = ?", id)
+func (r *repository) Exists(ctx context.Context, id string) (bool, error) {
+	exists, err := r.base.Exists(ctx, "_user", "u_id = ?", id)
 	if err != nil {
-		logger.Error("사용자 존재 확인 실패 (ID: %s): %v", id, err)
+		logger.FromContext(ctx).Error("사용자 존재 확인 실패 (ID: %s): %v", id, err)
 		return false, errors.Wrap(err, "USER_EXISTS_CHECK_FAILED", "사용자 존재 확인에 실패했습니다")
 	}
 
 	return exists, nil
 }
 
-// UpdateRefreshToken 리프레시 토큰 업데이트
-func (r *repository) UpdateRefreshToken(id string, refreshToken string) error {
+// RecordLoginFailure 로그인 실패 횟수 증가 후 현재 횟수 반환
+// restart면 이전 실패를 잊고 1부터 다시 센다 (마지막 실패가 오래된 경우)
+func (r *repository) RecordLoginFailure(ctx context.Context, id string, restart bool, at time.Time) (int, error) {
+	query := "UPDATE _user SET u_login_fails = u_login_fails + 1, u_login_fail_at = ? WHERE u_id = ?"
+	if restart {
+		query = "UPDATE _user SET u_login_fails = 1, u_login_fail_at = ? WHERE u_id = ?"
+	}
+
+	if _, err := r.base.Exec(ctx, query, at, id); err != nil {
+		logger.FromContext(ctx).Error("로그인 실패 기록 실패 (ID: %s): %v", id, err)
+		return 0, errors.Wrap(err, "USER_UPDATE_FAILED", "로그인 실패 기록에 실패했습니다")
+	}
+
+	var fails int
+	if err := r.base.QueryRow(ctx, "SELECT u_login_fails FROM _user WHERE u_id = ?", id).Scan(&fails); err != nil {
+		return 0, errors.Wrap(err, "USER_FIND_FAILED", "로그인 실패 횟수 조회에 실패했습니다")
+	}
+
+	return fails, nil
+}
+
+// LockLogin until까지 로그인 잠금 (실패 횟수는 잠금 기록으로 넘기고 초기화)
+func (r *repository) LockLogin(ctx context.Context, id string, until time.Time) error {
+	updates := map[string]interface{}{
+		"u_login_fails":  0,
+		"u_locked_until": until,
+	}
+
+	return r.Update(ctx, id, updates)
+}
+
+// ResetLoginFailures 로그인 실패 횟수와 잠금 초기화 (로그인 성공, 관리자 잠금 해제)
+// 이미 초기화된 사용자도 성공으로 처리한다 (MySQL은 값이 바뀐 행만 세므로 0행을 사용자 없음으로 보지 않음)
+func (r *repository) ResetLoginFailures(ctx context.Context, id string) error {
 	updates := map[string]interface{}{
-		"u_re_token": refreshToken,
+		"u_login_fails":   0,
+		"u_login_fail_at": nil,
+		"u_locked_until":  nil,
+	}
+
+	if _, err := r.base.Update(ctx, "_user", updates, "u_id = ?", id); err != nil {
+		logger.FromContext(ctx).Error("로그인 실패 횟수 초기화 실패 (ID: %s): %v", id, err)
+		return errors.Wrap(err, "USER_UPDATE_FAILED", "사용자 수정에 실패했습니다")
+	}
+	return nil
+}
+
+// CreateLockout 로그인 잠금 기록 추가
+func (r *repository) CreateLockout(ctx context.Context, lockout *Lockout) error {
+	data := map[string]interface{}{
+		"ll_scope":        lockout.Scope,
+		"ll_user_id":      nullString(lockout.UserID),
+		"ll_ip":           nullString(lockout.IP),
+		"ll_fail_count":   lockout.FailCount,
+		"ll_locked_until": lockout.LockedUntil,
+		"ll_regi_date":    lockout.CreatedAt,
 	}
 
-	return r.Update(id, updates)
+	id, err := r.base.Insert(ctx, "_login_lockouts", data)
+	if err != nil {
+		logger.FromContext(ctx).Error("로그인 잠금 기록 실패: %v", err)
+		return errors.Wrap(err, "LOCKOUT_CREATE_FAILED", "로그인 잠금 기록에 실패했습니다")
+	}
+
+	lockout.ID = id
+	return nil
 }
 
-// UpdateRefreshTokenTx 트랜잭션 내에서 리프레시 토큰 업데이트
-func (r *repository) UpdateRefreshTokenTx(tx *sql.Tx, id string, refreshToken string) error {
+// ReleaseLockouts 계정의 해제되지 않은 잠금 기록에 해제자와 시각 기록
+func (r *repository) ReleaseLockouts(ctx context.Context, userID, unlockedBy string, at time.Time) (int64, error) {
 	updates := map[string]interface{}{
-		"u_re_token": refreshToken,
+		"ll_unlocked_by": unlockedBy,
+		"ll_unlocked_at": at,
+	}
+
+	affected, err := r.base.Update(ctx, "_login_lockouts", updates,
+		"ll_scope = ? AND ll_user_id = ? AND ll_unlocked_at IS NULL", LockoutScopeAccount, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("로그인 잠금 해제 기록 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "LOCKOUT_UPDATE_FAILED", "로그인 잠금 해제 기록에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// FindLockouts 최근 로그인 잠금 기록 (최신순)
+func (r *repository) FindLockouts(ctx context.Context, page, limit int) ([]Lockout, int64, error) {
+	total, err := r.base.Select("_login_lockouts").Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.base.Select("_login_lockouts",
+		"ll_idx", "ll_scope", "ll_user_id", "ll_ip", "ll_fail_count",
+		"ll_locked_until", "ll_unlocked_by", "ll_unlocked_at", "ll_regi_date").
+		OrderBy("ll_idx", database.Desc).
+		Page(page, limit).
+		Query(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	lockouts := []Lockout{}
+	for rows.Next() {
+		var l Lockout
+		var userID, ip, unlockedBy sql.NullString
+		var unlockedAt sql.NullTime
+		if err := rows.Scan(&l.ID, &l.Scope, &userID, &ip, &l.FailCount,
+			&l.LockedUntil, &unlockedBy, &unlockedAt, &l.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		l.UserID, l.IP, l.UnlockedBy = userID.String, ip.String, unlockedBy.String
+		l.UnlockedAt = nullTime(unlockedAt)
+		lockouts = append(lockouts, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
 	}
 
-	return r.UpdateTx(tx, id, updates)
-}
\ No newline at end of file
+	return lockouts, total, nil
+}
+
+// sessionColumns 세션 조회 컬럼 (scanSession 순서)
+var sessionColumns = []string{
+	"us_id", "us_user_id", "us_token_hash", "us_user_agent", "us_ip",
+	"us_last_used", "us_expires_at", "us_revoked_at", "us_revoke_reason", "us_regi_date",
+}
+
+// scanSession 세션 한 행 스캔
+func scanSession(scan func(dest ...interface{}) error) (*Session, error) {
+	var s Session
+	var userAgent, ip, reason sql.NullString
+	var lastUsed, revokedAt sql.NullTime
+	if err := scan(&s.ID, &s.UserID, &s.TokenHash, &userAgent, &ip,
+		&lastUsed, &s.ExpiresAt, &revokedAt, &reason, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+	s.UserAgent, s.IP, s.RevokeReason = userAgent.String, ip.String, reason.String
+	s.LastUsedAt = nullTime(lastUsed)
+	s.RevokedAt = nullTime(revokedAt)
+	return &s, nil
+}
+
+// CreateSession 로그인 세션 추가
+func (r *repository) CreateSession(ctx context.Context, session *Session) error {
+	data := map[string]interface{}{
+		"us_id":         session.ID,
+		"us_user_id":    session.UserID,
+		"us_token_hash": session.TokenHash,
+		"us_user_agent": nullString(session.UserAgent),
+		"us_ip":         nullString(session.IP),
+		"us_last_used":  session.LastUsedAt,
+		"us_expires_at": session.ExpiresAt,
+		"us_regi_date":  session.CreatedAt,
+	}
+
+	if _, err := r.base.Insert(ctx, "_user_sessions", data); err != nil {
+		logger.FromContext(ctx).Error("세션 생성 실패 (ID: %s): %v", session.UserID, err)
+		return errors.Wrap(err, "SESSION_CREATE_FAILED", "세션 생성에 실패했습니다")
+	}
+
+	return nil
+}
+
+// FindSession 세션 ID로 조회 (종료된 세션 포함)
+func (r *repository) FindSession(ctx context.Context, id string) (*Session, error) {
+	session, err := scanSession(r.base.Select("_user_sessions", sessionColumns...).
+		Where(database.Eq("us_id", id)).
+		QueryRow(ctx).Scan)
+
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrSessionNotFound
+	}
+
+	if err != nil {
+		logger.FromContext(ctx).Error("세션 조회 실패 (ID: %s): %v", id, err)
+		return nil, errors.Wrap(err, "SESSION_FIND_FAILED", "세션 조회에 실패했습니다")
+	}
+
+	return session, nil
+}
+
+// FindActiveSessions 사용자의 종료되지 않은 세션 (최근 로그인순)
+func (r *repository) FindActiveSessions(ctx context.Context, userID string, now time.Time) ([]Session, error) {
+	rows, err := r.base.Select("_user_sessions", sessionColumns...).
+		Where(database.Eq("us_user_id", userID), database.IsNull("us_revoked_at"), database.Gt("us_expires_at", now)).
+		OrderBy("us_regi_date", database.Desc).
+		Query(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []Session{}
+	for rows.Next() {
+		session, err := scanSession(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// RotateSession 리프레시 토큰 교체
+// 현재 토큰 해시가 oldHash인 경우에만 바꾸므로, 같은 토큰으로 동시에 갱신하면 한 요청만 성공한다
+func (r *repository) RotateSession(ctx context.Context, id, oldHash, newHash string, expiresAt time.Time, ip, userAgent string, at time.Time) (bool, error) {
+	updates := map[string]interface{}{
+		"us_token_hash": newHash,
+		"us_expires_at": expiresAt,
+		"us_ip":         nullString(ip),
+		"us_user_agent": nullString(userAgent),
+		"us_last_used":  at,
+	}
+
+	affected, err := r.base.Update(ctx, "_user_sessions", updates,
+		"us_id = ? AND us_token_hash = ? AND us_revoked_at IS NULL", id, oldHash)
+	if err != nil {
+		logger.FromContext(ctx).Error("세션 토큰 교체 실패 (세션: %s): %v", id, err)
+		return false, errors.Wrap(err, "SESSION_UPDATE_FAILED", "세션 갱신에 실패했습니다")
+	}
+
+	return affected > 0, nil
+}
+
+// RevokeSession 사용자의 세션 하나 종료 (없거나 이미 종료됐으면 false)
+func (r *repository) RevokeSession(ctx context.Context, userID, id, reason string, at time.Time) (bool, error) {
+	updates := map[string]interface{}{
+		"us_revoked_at":    at,
+		"us_revoke_reason": reason,
+	}
+
+	affected, err := r.base.Update(ctx, "_user_sessions", updates,
+		"us_id = ? AND us_user_id = ? AND us_revoked_at IS NULL", id, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("세션 종료 실패 (세션: %s): %v", id, err)
+		return false, errors.Wrap(err, "SESSION_UPDATE_FAILED", "세션 종료에 실패했습니다")
+	}
+
+	return affected > 0, nil
+}
+
+// RevokeSessions 사용자의 모든 세션 종료 (exceptID가 있으면 그 세션은 유지)
+func (r *repository) RevokeSessions(ctx context.Context, userID, exceptID, reason string, at time.Time) (int64, error) {
+	updates := map[string]interface{}{
+		"us_revoked_at":    at,
+		"us_revoke_reason": reason,
+	}
+
+	affected, err := r.base.Update(ctx, "_user_sessions", updates,
+		"us_user_id = ? AND us_id <> ? AND us_revoked_at IS NULL", userID, exceptID)
+	if err != nil {
+		logger.FromContext(ctx).Error("세션 일괄 종료 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "SESSION_UPDATE_FAILED", "세션 종료에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// DeleteExpiredSessions 만료된 세션 정리 (종료된 세션도 만료 시각까지는 재사용 감지를 위해 남겨 둔다)
+func (r *repository) DeleteExpiredSessions(ctx context.Context, userID string, now time.Time) (int64, error) {
+	affected, err := r.base.Delete(ctx, "_user_sessions", "us_user_id = ? AND us_expires_at <= ?", userID, now)
+	if err != nil {
+		logger.FromContext(ctx).Error("만료 세션 정리 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "SESSION_DELETE_FAILED", "만료된 세션 정리에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// DeleteSessions 사용자의 세션 전체 삭제 (사용자 삭제 시)
+func (r *repository) DeleteSessions(ctx context.Context, userID string) (int64, error) {
+	affected, err := r.base.Delete(ctx, "_user_sessions", "us_user_id = ?", userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("세션 삭제 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "SESSION_DELETE_FAILED", "세션 삭제에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// CreateUserToken 일회용 토큰 추가
+func (r *repository) CreateUserToken(ctx context.Context, token *UserToken) error {
+	data := map[string]interface{}{
+		"ut_user_id":    token.UserID,
+		"ut_purpose":    token.Purpose,
+		"ut_token_hash": token.TokenHash,
+		"ut_email":      nullString(token.Email),
+		"ut_expires_at": token.ExpiresAt,
+		"ut_regi_date":  token.CreatedAt,
+	}
+
+	id, err := r.base.Insert(ctx, "_user_tokens", data)
+	if err != nil {
+		logger.FromContext(ctx).Error("일회용 토큰 생성 실패 (ID: %s): %v", token.UserID, err)
+		return errors.Wrap(err, "USER_TOKEN_CREATE_FAILED", "토큰 생성에 실패했습니다")
+	}
+
+	token.ID = id
+	return nil
+}
+
+// FindUserToken 토큰 해시로 조회 (사용했거나 만료된 토큰 포함)
+func (r *repository) FindUserToken(ctx context.Context, tokenHash string) (*UserToken, error) {
+	var t UserToken
+	var email sql.NullString
+	var usedAt sql.NullTime
+	err := r.base.Select("_user_tokens",
+		"ut_idx", "ut_user_id", "ut_purpose", "ut_token_hash", "ut_email",
+		"ut_expires_at", "ut_used_at", "ut_regi_date").
+		Where(database.Eq("ut_token_hash", tokenHash)).
+		QueryRow(ctx).
+		Scan(&t.ID, &t.UserID, &t.Purpose, &t.TokenHash, &email, &t.ExpiresAt, &usedAt, &t.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrInvalidLinkToken
+	}
+
+	if err != nil {
+		logger.FromContext(ctx).Error("일회용 토큰 조회 실패: %v", err)
+		return nil, errors.Wrap(err, "USER_TOKEN_FIND_FAILED", "토큰 조회에 실패했습니다")
+	}
+	t.Email = email.String
+	t.UsedAt = nullTime(usedAt)
+
+	return &t, nil
+}
+
+// UseUserToken 토큰 사용 처리
+// 아직 사용하지 않은 경우에만 바꾸므로, 같은 토큰으로 동시에 요청하면 한 요청만 성공한다
+func (r *repository) UseUserToken(ctx context.Context, id int64, at time.Time) (bool, error) {
+	affected, err := r.base.Update(ctx, "_user_tokens", map[string]interface{}{"ut_used_at": at},
+		"ut_idx = ? AND ut_used_at IS NULL", id)
+	if err != nil {
+		logger.FromContext(ctx).Error("일회용 토큰 사용 처리 실패 (%d): %v", id, err)
+		return false, errors.Wrap(err, "USER_TOKEN_UPDATE_FAILED", "토큰 처리에 실패했습니다")
+	}
+
+	return affected > 0, nil
+}
+
+// DeleteUserTokens 사용자의 일회용 토큰 삭제 (purpose가 비어 있으면 전체 - 사용자 삭제 시)
+func (r *repository) DeleteUserTokens(ctx context.Context, userID, purpose string) (int64, error) {
+	where, args := "ut_user_id = ?", []interface{}{userID}
+	if purpose != "" {
+		where, args = where+" AND ut_purpose = ?", append(args, purpose)
+	}
+
+	affected, err := r.base.Delete(ctx, "_user_tokens", where, args...)
+	if err != nil {
+		logger.FromContext(ctx).Error("일회용 토큰 삭제 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "USER_TOKEN_DELETE_FAILED", "토큰 삭제에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// VerifyEmail 이메일 인증 처리 (현재 이메일이 email인 경우에만, 그 사이 바뀌었으면 false)
+func (r *repository) VerifyEmail(ctx context.Context, id, email string, at time.Time) (bool, error) {
+	affected, err := r.base.Update(ctx, "_user", map[string]interface{}{"u_email_verified_at": at},
+		"u_id = ? AND u_email = ?", id, email)
+	if err != nil {
+		logger.FromContext(ctx).Error("이메일 인증 처리 실패 (ID: %s): %v", id, err)
+		return false, errors.Wrap(err, "USER_UPDATE_FAILED", "이메일 인증 처리에 실패했습니다")
+	}
+
+	return affected > 0, nil
+}
+
+// UseMFAStep TOTP 코드 사용 기록 (이미 같거나 이후 구간의 코드를 썼으면 false - 코드 재사용 방지)
+func (r *repository) UseMFAStep(ctx context.Context, id string, step int64) (bool, error) {
+	affected, err := r.base.Update(ctx, "_user", map[string]interface{}{"u_mfa_last_step": step},
+		"u_id = ? AND u_mfa_last_step < ?", id, step)
+	if err != nil {
+		logger.FromContext(ctx).Error("2단계 인증 코드 기록 실패 (ID: %s): %v", id, err)
+		return false, errors.Wrap(err, "USER_UPDATE_FAILED", "2단계 인증 처리에 실패했습니다")
+	}
+
+	return affected > 0, nil
+}
+
+// ReplaceRecoveryCodes 복구 코드를 새로 발급한 코드로 교체 (이전 코드는 모두 무효)
+func (r *repository) ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string, at time.Time) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.base.Delete(ctx, "_user_recovery_codes", "rc_user_id = ?", userID); err != nil {
+			return err
+		}
+
+		for _, hash := range hashes {
+			data := map[string]interface{}{
+				"rc_user_id":   userID,
+				"rc_code_hash": hash,
+				"rc_regi_date": at,
+			}
+			if _, err := r.base.Insert(ctx, "_user_recovery_codes", data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("복구 코드 발급 실패 (ID: %s): %v", userID, err)
+		return errors.Wrap(err, "RECOVERY_CODE_CREATE_FAILED", "복구 코드 발급에 실패했습니다")
+	}
+
+	return nil
+}
+
+// UseRecoveryCode 복구 코드 사용 처리 (없거나 이미 사용했으면 false)
+func (r *repository) UseRecoveryCode(ctx context.Context, userID, hash string, at time.Time) (bool, error) {
+	affected, err := r.base.Update(ctx, "_user_recovery_codes", map[string]interface{}{"rc_used_at": at},
+		"rc_user_id = ? AND rc_code_hash = ? AND rc_used_at IS NULL", userID, hash)
+	if err != nil {
+		logger.FromContext(ctx).Error("복구 코드 사용 처리 실패 (ID: %s): %v", userID, err)
+		return false, errors.Wrap(err, "RECOVERY_CODE_UPDATE_FAILED", "복구 코드 처리에 실패했습니다")
+	}
+
+	return affected > 0, nil
+}
+
+// CountRecoveryCodes 사용하지 않은 복구 코드 수
+func (r *repository) CountRecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	return r.base.Select("_user_recovery_codes").
+		Where(database.Eq("rc_user_id", userID), database.IsNull("rc_used_at")).
+		Count(ctx)
+}
+
+// DeleteRecoveryCodes 사용자의 복구 코드 전체 삭제 (2단계 인증 해제, 사용자 삭제 시)
+func (r *repository) DeleteRecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	affected, err := r.base.Delete(ctx, "_user_recovery_codes", "rc_user_id = ?", userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("복구 코드 삭제 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "RECOVERY_CODE_DELETE_FAILED", "복구 코드 삭제에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// UpdatePasswordHash 비밀번호 해시 교체 (현재 해시가 oldHash인 경우만 - 재해싱 중 비밀번호 변경 보호)
+func (r *repository) UpdatePasswordHash(ctx context.Context, id, oldHash, newHash string) (bool, error) {
+	affected, err := r.base.Update(ctx, "_user", map[string]interface{}{"u_pass": newHash},
+		"u_id = ? AND u_pass = ?", id, oldHash)
+	if err != nil {
+		logger.FromContext(ctx).Error("비밀번호 해시 갱신 실패 (ID: %s): %v", id, err)
+		return false, errors.Wrap(err, "USER_UPDATE_FAILED", "비밀번호 해시 갱신에 실패했습니다")
+	}
+
+	return affected > 0, nil
+}
+
+// FindPasswordHistory 최근 비밀번호 해시 (최신순 limit개)
+func (r *repository) FindPasswordHistory(ctx context.Context, userID string, limit int) ([]string, error) {
+	rows, err := r.base.Select("_user_password_history", "ph_hash").
+		Where(database.Eq("ph_user_id", userID)).
+		OrderBy("ph_idx", database.Desc).
+		Limit(limit).
+		Query(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := []string{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+// AddPasswordHistory 비밀번호 이력 추가 (최신 keep개만 남기고 정리)
+func (r *repository) AddPasswordHistory(ctx context.Context, userID, hash string, keep int, at time.Time) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		data := map[string]interface{}{
+			"ph_user_id":   userID,
+			"ph_hash":      hash,
+			"ph_regi_date": at,
+		}
+		if _, err := r.base.Insert(ctx, "_user_password_history", data); err != nil {
+			return err
+		}
+
+		// keep번째 이력보다 오래된 것 삭제 (MySQL은 IN 서브쿼리에 LIMIT을 쓸 수 없어 경계 idx를 먼저 조회)
+		var oldest int64
+		err := r.base.Select("_user_password_history", "ph_idx").
+			Where(database.Eq("ph_user_id", userID)).
+			OrderBy("ph_idx", database.Desc).
+			Limit(1).
+			Offset(keep - 1).
+			QueryRow(ctx).
+			Scan(&oldest)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = r.base.Delete(ctx, "_user_password_history", "ph_user_id = ? AND ph_idx < ?", userID, oldest)
+		return err
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("비밀번호 이력 저장 실패 (ID: %s): %v", userID, err)
+		return errors.Wrap(err, "PASSWORD_HISTORY_FAILED", "비밀번호 이력 저장에 실패했습니다")
+	}
+
+	return nil
+}
+
+// DeletePasswordHistory 사용자의 비밀번호 이력 전체 삭제 (사용자 삭제 시)
+func (r *repository) DeletePasswordHistory(ctx context.Context, userID string) (int64, error) {
+	affected, err := r.base.Delete(ctx, "_user_password_history", "ph_user_id = ?", userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("비밀번호 이력 삭제 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "PASSWORD_HISTORY_DELETE_FAILED", "비밀번호 이력 삭제에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// FindRoles 사용자에게 부여된 역할 코드
+func (r *repository) FindRoles(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.base.Select("_user_roles", "ur_role").
+		Where(database.Eq("ur_user_id", userID)).
+		OrderBy("ur_role", database.Asc).
+		Query(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("사용자 역할 조회 실패 (ID: %s): %v", userID, err)
+		return nil, errors.Wrap(err, "USER_ROLE_FIND_FAILED", "사용자 역할 조회에 실패했습니다")
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// ReplaceRoles 사용자 역할을 모두 바꿈
+// 첫 번째 역할을 대표 역할로 u_auth_type에도 기록한다 (역할 도입 전 코드, 통계와 호환)
+func (r *repository) ReplaceRoles(ctx context.Context, userID string, roles []string, grantedBy string, at time.Time) error {
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.base.Delete(ctx, "_user_roles", "ur_user_id = ?", userID); err != nil {
+			return err
+		}
+
+		for _, role := range roles {
+			data := map[string]interface{}{
+				"ur_user_id":    userID,
+				"ur_role":       role,
+				"ur_granted_by": nullString(grantedBy),
+				"ur_regi_date":  at,
+			}
+			if _, err := r.base.Insert(ctx, "_user_roles", data); err != nil {
+				return err
+			}
+		}
+
+		if len(roles) == 0 {
+			return nil
+		}
+		_, err := r.base.Update(ctx, "_user", map[string]interface{}{"u_auth_type": roles[0]}, "u_id = ?", userID)
+		return err
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("사용자 역할 저장 실패 (ID: %s): %v", userID, err)
+		return errors.Wrap(err, "USER_ROLE_UPDATE_FAILED", "사용자 역할 저장에 실패했습니다")
+	}
+
+	return nil
+}
+
+// DeleteRoles 사용자의 역할 전체 삭제 (사용자 삭제 시)
+func (r *repository) DeleteRoles(ctx context.Context, userID string) (int64, error) {
+	affected, err := r.base.Delete(ctx, "_user_roles", "ur_user_id = ?", userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("사용자 역할 삭제 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "USER_ROLE_DELETE_FAILED", "사용자 역할 삭제에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// apiKeyColumns API 키 조회 컬럼 (scanAPIKey 순서)
+var apiKeyColumns = []string{
+	"ak_id", "ak_user_id", "ak_name", "ak_prefix", "ak_key_hash", "ak_scopes", "ak_allow_ips",
+	"ak_expires_at", "ak_last_used", "ak_last_ip", "ak_revoked_at", "ak_revoked_by", "ak_regi_date",
+}
+
+// scanAPIKey API 키 한 행 스캔
+func scanAPIKey(scan func(dest ...interface{}) error) (*APIKey, error) {
+	var k APIKey
+	var scopes string
+	var allowIPs, lastIP, revokedBy sql.NullString
+	var expiresAt, lastUsed, revokedAt sql.NullTime
+	if err := scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.KeyHash, &scopes, &allowIPs,
+		&expiresAt, &lastUsed, &lastIP, &revokedAt, &revokedBy, &k.CreatedAt); err != nil {
+		return nil, err
+	}
+	k.Scopes = splitList(scopes)
+	k.AllowIPs = splitList(allowIPs.String)
+	k.LastIP, k.RevokedBy = lastIP.String, revokedBy.String
+	k.ExpiresAt = nullTime(expiresAt)
+	k.LastUsedAt = nullTime(lastUsed)
+	k.RevokedAt = nullTime(revokedAt)
+	return &k, nil
+}
+
+// CreateAPIKey API 키 추가
+func (r *repository) CreateAPIKey(ctx context.Context, key *APIKey) error {
+	data := map[string]interface{}{
+		"ak_id":         key.ID,
+		"ak_user_id":    key.UserID,
+		"ak_name":       key.Name,
+		"ak_prefix":     key.Prefix,
+		"ak_key_hash":   key.KeyHash,
+		"ak_scopes":     strings.Join(key.Scopes, ","),
+		"ak_allow_ips":  nullString(strings.Join(key.AllowIPs, ",")),
+		"ak_expires_at": key.ExpiresAt,
+		"ak_regi_date":  key.CreatedAt,
+	}
+
+	if _, err := r.base.Insert(ctx, "_api_keys", data); err != nil {
+		logger.FromContext(ctx).Error("API 키 생성 실패 (ID: %s): %v", key.UserID, err)
+		return errors.Wrap(err, "API_KEY_CREATE_FAILED", "API 키 생성에 실패했습니다")
+	}
+
+	return nil
+}
+
+// FindAPIKeyByPrefix 접두사로 API 키 조회 (폐기, 만료된 키 포함)
+func (r *repository) FindAPIKeyByPrefix(ctx context.Context, prefix string) (*APIKey, error) {
+	key, err := scanAPIKey(r.base.Select("_api_keys", apiKeyColumns...).
+		Where(database.Eq("ak_prefix", prefix)).
+		QueryRow(ctx).Scan)
+
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrAPIKeyNotFound
+	}
+
+	if err != nil {
+		logger.FromContext(ctx).Error("API 키 조회 실패 (%s): %v", prefix, err)
+		return nil, errors.Wrap(err, "API_KEY_FIND_FAILED", "API 키 조회에 실패했습니다")
+	}
+
+	return key, nil
+}
+
+// FindAPIKeys 사용자의 폐기하지 않은 API 키 (만료된 키 포함, 최근 발급순)
+func (r *repository) FindAPIKeys(ctx context.Context, userID string) ([]APIKey, error) {
+	rows, err := r.base.Select("_api_keys", apiKeyColumns...).
+		Where(database.Eq("ak_user_id", userID), database.IsNull("ak_revoked_at")).
+		OrderBy("ak_regi_date", database.Desc).
+		Query(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("API 키 목록 조회 실패 (ID: %s): %v", userID, err)
+		return nil, errors.Wrap(err, "API_KEY_FIND_FAILED", "API 키 조회에 실패했습니다")
+	}
+	defer rows.Close()
+
+	keys := []APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// CountActiveAPIKeys 사용자의 유효한 API 키 수 (폐기, 만료된 키 제외)
+func (r *repository) CountActiveAPIKeys(ctx context.Context, userID string, now time.Time) (int64, error) {
+	count, err := r.base.Select("_api_keys").
+		Where(
+			database.Eq("ak_user_id", userID),
+			database.IsNull("ak_revoked_at"),
+			database.Or(database.IsNull("ak_expires_at"), database.Gt("ak_expires_at", now)),
+		).
+		Count(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("API 키 수 조회 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "API_KEY_FIND_FAILED", "API 키 조회에 실패했습니다")
+	}
+
+	return count, nil
+}
+
+// TouchAPIKey API 키 마지막 사용 시각, IP 기록
+func (r *repository) TouchAPIKey(ctx context.Context, id, ip string, at time.Time) error {
+	updates := map[string]interface{}{
+		"ak_last_used": at,
+		"ak_last_ip":   nullString(ip),
+	}
+
+	if _, err := r.base.Update(ctx, "_api_keys", updates, "ak_id = ?", id); err != nil {
+		return errors.Wrap(err, "API_KEY_UPDATE_FAILED", "API 키 사용 기록에 실패했습니다")
+	}
+
+	return nil
+}
+
+// RevokeAPIKey 사용자의 API 키 하나 폐기 (없거나 이미 폐기했으면 false)
+func (r *repository) RevokeAPIKey(ctx context.Context, userID, id, revokedBy string, at time.Time) (bool, error) {
+	updates := map[string]interface{}{
+		"ak_revoked_at": at,
+		"ak_revoked_by": nullString(revokedBy),
+	}
+
+	affected, err := r.base.Update(ctx, "_api_keys", updates,
+		"ak_id = ? AND ak_user_id = ? AND ak_revoked_at IS NULL", id, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("API 키 폐기 실패 (키: %s): %v", id, err)
+		return false, errors.Wrap(err, "API_KEY_UPDATE_FAILED", "API 키 폐기에 실패했습니다")
+	}
+
+	return affected > 0, nil
+}
+
+// RevokeAPIKeys 사용자의 API 키 전체 폐기
+func (r *repository) RevokeAPIKeys(ctx context.Context, userID, revokedBy string, at time.Time) (int64, error) {
+	updates := map[string]interface{}{
+		"ak_revoked_at": at,
+		"ak_revoked_by": nullString(revokedBy),
+	}
+
+	affected, err := r.base.Update(ctx, "_api_keys", updates,
+		"ak_user_id = ? AND ak_revoked_at IS NULL", userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("API 키 일괄 폐기 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "API_KEY_UPDATE_FAILED", "API 키 폐기에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// DeleteAPIKeys 사용자의 API 키 전체 삭제 (사용자 삭제 시)
+func (r *repository) DeleteAPIKeys(ctx context.Context, userID string) (int64, error) {
+	affected, err := r.base.Delete(ctx, "_api_keys", "ak_user_id = ?", userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("API 키 삭제 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "API_KEY_DELETE_FAILED", "API 키 삭제에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// CreateOAuthUser 소셜 로그인으로 가입한 사용자 생성 (역할, 연결 계정을 하나의 트랜잭션으로)
+// 비밀번호는 비워 두고, 제공자가 확인한 이메일이면 인증된 것으로 저장한다
+func (r *repository) CreateOAuthUser(ctx context.Context, user *User, identity *Identity) error {
+	data := map[string]interface{}{
+		"u_id":                user.ID,
+		"u_pass":              user.Password,
+		"u_name":              user.Name,
+		"u_email":             user.Email,
+		"u_auth_type":         user.AuthType,
+		"u_auth_level":        user.AuthLevel,
+		"u_email_verified_at": user.EmailVerifiedAt,
+		"u_regi_date":         user.CreatedAt,
+	}
+
+	err := r.db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := r.base.Insert(ctx, "_user", data); err != nil {
+			return err
+		}
+		if _, err := r.base.Insert(ctx, "_user_roles", map[string]interface{}{
+			"ur_user_id":   user.ID,
+			"ur_role":      user.AuthType,
+			"ur_regi_date": user.CreatedAt,
+		}); err != nil {
+			return err
+		}
+		_, err := r.base.Insert(ctx, "_user_identities", identityData(identity))
+		return err
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("소셜 로그인 사용자 생성 실패 (%s): %v", identity.Provider, err)
+		return errors.Wrap(err, "USER_CREATE_FAILED", "사용자 생성에 실패했습니다")
+	}
+
+	return nil
+}
+
+// identityColumns 연결 계정 조회 컬럼 (scanIdentity 순서)
+var identityColumns = []string{
+	"ui_idx", "ui_user_id", "ui_provider", "ui_subject", "ui_email", "ui_last_login", "ui_regi_date",
+}
+
+// scanIdentity 연결 계정 한 행 스캔
+func scanIdentity(scan func(dest ...interface{}) error) (*Identity, error) {
+	var i Identity
+	var email sql.NullString
+	var lastLogin sql.NullTime
+	if err := scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &email, &lastLogin, &i.CreatedAt); err != nil {
+		return nil, err
+	}
+	i.Email = email.String
+	i.LastLoginAt = nullTime(lastLogin)
+	return &i, nil
+}
+
+// identityData 연결 계정 저장 값
+func identityData(identity *Identity) map[string]interface{} {
+	return map[string]interface{}{
+		"ui_user_id":    identity.UserID,
+		"ui_provider":   identity.Provider,
+		"ui_subject":    identity.Subject,
+		"ui_email":      nullString(identity.Email),
+		"ui_last_login": identity.LastLoginAt,
+		"ui_regi_date":  identity.CreatedAt,
+	}
+}
+
+// CreateIdentity 기존 사용자에 소셜 계정 연결
+func (r *repository) CreateIdentity(ctx context.Context, identity *Identity) error {
+	if _, err := r.base.Insert(ctx, "_user_identities", identityData(identity)); err != nil {
+		logger.FromContext(ctx).Error("소셜 계정 연결 실패 (ID: %s, %s): %v", identity.UserID, identity.Provider, err)
+		return errors.Wrap(err, "IDENTITY_CREATE_FAILED", "소셜 계정 연결에 실패했습니다")
+	}
+
+	return nil
+}
+
+// FindIdentity 제공자와 제공자 사용자 ID로 연결 계정 조회
+func (r *repository) FindIdentity(ctx context.Context, provider, subject string) (*Identity, error) {
+	identity, err := scanIdentity(r.base.Select("_user_identities", identityColumns...).
+		Where(database.Eq("ui_provider", provider), database.Eq("ui_subject", subject)).
+		QueryRow(ctx).Scan)
+
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrIdentityNotFound
+	}
+
+	if err != nil {
+		logger.FromContext(ctx).Error("소셜 계정 조회 실패 (%s): %v", provider, err)
+		return nil, errors.Wrap(err, "IDENTITY_FIND_FAILED", "소셜 계정 조회에 실패했습니다")
+	}
+
+	return identity, nil
+}
+
+// FindIdentities 사용자에게 연결된 소셜 계정 (연결한 순서)
+func (r *repository) FindIdentities(ctx context.Context, userID string) ([]Identity, error) {
+	rows, err := r.base.Select("_user_identities", identityColumns...).
+		Where(database.Eq("ui_user_id", userID)).
+		OrderBy("ui_idx", database.Asc).
+		Query(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("소셜 계정 목록 조회 실패 (ID: %s): %v", userID, err)
+		return nil, errors.Wrap(err, "IDENTITY_FIND_FAILED", "소셜 계정 조회에 실패했습니다")
+	}
+	defer rows.Close()
+
+	identities := []Identity{}
+	for rows.Next() {
+		identity, err := scanIdentity(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, *identity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+// TouchIdentity 소셜 로그인 시각과 제공자 이메일 기록
+func (r *repository) TouchIdentity(ctx context.Context, id int64, email string, at time.Time) error {
+	updates := map[string]interface{}{
+		"ui_last_login": at,
+		"ui_email":      nullString(email),
+	}
+
+	if _, err := r.base.Update(ctx, "_user_identities", updates, "ui_idx = ?", id); err != nil {
+		return errors.Wrap(err, "IDENTITY_UPDATE_FAILED", "소셜 계정 로그인 기록에 실패했습니다")
+	}
+
+	return nil
+}
+
+// DeleteIdentity 사용자의 소셜 계정 연결 해제 (연결된 계정이 없으면 false)
+func (r *repository) DeleteIdentity(ctx context.Context, userID, provider string) (bool, error) {
+	affected, err := r.base.Delete(ctx, "_user_identities", "ui_user_id = ? AND ui_provider = ?", userID, provider)
+	if err != nil {
+		logger.FromContext(ctx).Error("소셜 계정 연결 해제 실패 (ID: %s, %s): %v", userID, provider, err)
+		return false, errors.Wrap(err, "IDENTITY_DELETE_FAILED", "소셜 계정 연결 해제에 실패했습니다")
+	}
+
+	return affected > 0, nil
+}
+
+// DeleteIdentities 사용자의 소셜 계정 연결 전체 삭제 (사용자 삭제 시)
+func (r *repository) DeleteIdentities(ctx context.Context, userID string) (int64, error) {
+	affected, err := r.base.Delete(ctx, "_user_identities", "ui_user_id = ?", userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("소셜 계정 삭제 실패 (ID: %s): %v", userID, err)
+		return 0, errors.Wrap(err, "IDENTITY_DELETE_FAILED", "소셜 계정 삭제에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// CreateOAuthState 소셜 로그인 진행 정보 저장
+func (r *repository) CreateOAuthState(ctx context.Context, state *OAuthState) error {
+	data := map[string]interface{}{
+		"os_state_hash": state.StateHash,
+		"os_provider":   state.Provider,
+		"os_verifier":   state.Verifier,
+		"os_nonce":      state.Nonce,
+		"os_user_id":    nullString(state.UserID),
+		"os_expires_at": state.ExpiresAt,
+		"os_regi_date":  state.CreatedAt,
+	}
+
+	if _, err := r.base.Insert(ctx, "_oauth_states", data); err != nil {
+		logger.FromContext(ctx).Error("소셜 로그인 state 저장 실패 (%s): %v", state.Provider, err)
+		return errors.Wrap(err, "OAUTH_STATE_CREATE_FAILED", "소셜 로그인 시작에 실패했습니다")
+	}
+
+	return nil
+}
+
+// UseOAuthState state 조회 후 삭제 (한 번만 사용, 없거나 다른 요청이 먼저 사용했으면 OAUTH_STATE_INVALID)
+func (r *repository) UseOAuthState(ctx context.Context, stateHash string) (*OAuthState, error) {
+	var state OAuthState
+	var userID sql.NullString
+	err := r.base.Select("_oauth_states",
+		"os_state_hash", "os_provider", "os_verifier", "os_nonce", "os_user_id", "os_expires_at", "os_regi_date").
+		Where(database.Eq("os_state_hash", stateHash)).
+		QueryRow(ctx).
+		Scan(&state.StateHash, &state.Provider, &state.Verifier, &state.Nonce, &userID, &state.ExpiresAt, &state.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrOAuthStateInvalid
+	}
+
+	if err != nil {
+		logger.FromContext(ctx).Error("소셜 로그인 state 조회 실패: %v", err)
+		return nil, errors.Wrap(err, "OAUTH_STATE_FIND_FAILED", "소셜 로그인 정보 조회에 실패했습니다")
+	}
+	state.UserID = userID.String
+
+	affected, err := r.base.Delete(ctx, "_oauth_states", "os_state_hash = ?", stateHash)
+	if err != nil {
+		logger.FromContext(ctx).Error("소셜 로그인 state 삭제 실패: %v", err)
+		return nil, errors.Wrap(err, "OAUTH_STATE_DELETE_FAILED", "소셜 로그인 정보 처리에 실패했습니다")
+	}
+	if affected == 0 {
+		return nil, errors.ErrOAuthStateInvalid
+	}
+
+	return &state, nil
+}
+
+// DeleteExpiredOAuthStates 만료된 소셜 로그인 state 정리 (콜백까지 오지 않은 시도)
+func (r *repository) DeleteExpiredOAuthStates(ctx context.Context, now time.Time) (int64, error) {
+	affected, err := r.base.Delete(ctx, "_oauth_states", "os_expires_at <= ?", now)
+	if err != nil {
+		logger.FromContext(ctx).Error("만료된 소셜 로그인 state 삭제 실패: %v", err)
+		return 0, errors.Wrap(err, "OAUTH_STATE_DELETE_FAILED", "소셜 로그인 정보 정리에 실패했습니다")
+	}
+
+	return affected, nil
+}
+
+// nullTime NULL이면 nil
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// nullString 빈 문자열은 NULL로 저장
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// splitList 쉼표로 구분한 값 목록 (빈 값이면 nil)
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
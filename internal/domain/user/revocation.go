@@ -0,0 +1,65 @@
+package user
+
+import (
+	"context"
+	"gin_starter/internal/infrastructure/database"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/revocation"
+	"time"
+)
+
+// revocationStore 액세스 토큰 폐기 기록 DB 저장소 (_token_revocations)
+type revocationStore struct {
+	base *database.Repository
+}
+
+// NewRevocationStore 토큰 폐기 기록 저장소 생성
+func NewRevocationStore(db *database.DB) revocation.Store {
+	return &revocationStore{base: database.NewRepository(db)}
+}
+
+// Save 폐기 기록 추가
+func (r *revocationStore) Save(ctx context.Context, entry revocation.Entry) error {
+	data := map[string]interface{}{
+		"tr_kind":       entry.Kind,
+		"tr_subject":    entry.Subject,
+		"tr_revoked_at": entry.RevokedAt,
+		"tr_expires_at": entry.ExpiresAt,
+	}
+
+	if _, err := r.base.Insert(ctx, "_token_revocations", data); err != nil {
+		return errors.Wrap(err, "REVOCATION_SAVE_FAILED", "토큰 폐기 기록에 실패했습니다")
+	}
+
+	return nil
+}
+
+// LoadSince since 이후 폐기되고 아직 만료되지 않은 기록
+func (r *revocationStore) LoadSince(ctx context.Context, since, now time.Time) ([]revocation.Entry, error) {
+	rows, err := r.base.Select("_token_revocations", "tr_kind", "tr_subject", "tr_revoked_at", "tr_expires_at").
+		Where(database.Gte("tr_revoked_at", since), database.Gt("tr_expires_at", now)).
+		Query(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []revocation.Entry
+	for rows.Next() {
+		var e revocation.Entry
+		if err := rows.Scan(&e.Kind, &e.Subject, &e.RevokedAt, &e.ExpiresAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// DeleteExpired 대상 토큰이 모두 만료된 기록 삭제
+func (r *revocationStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return r.base.Delete(ctx, "_token_revocations", "tr_expires_at <= ?", now)
+}
@@ -1,42 +1,99 @@
 package user
 
 import (
+	"context"
+	"crypto/subtle"
 	"gin_starter/internal/config"
 	"gin_starter/internal/middleware"
 	"gin_starter/pkg/errors"
 	"gin_starter/pkg/logger"
+	"gin_starter/pkg/mailer"
+	"gin_starter/pkg/oauth"
+	"gin_starter/pkg/password"
+	"gin_starter/pkg/rbac"
+	"gin_starter/pkg/revocation"
 	"time"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 // Service 사용자 서비스 인터페이스
 type Service interface {
-	Register(req *CreateUserRequest) (*User, error)
-	Login(req *LoginRequest) (*LoginResponse, error)
-	GetProfile(userID string) (*User, error)
-	UpdateProfile(userID string, req *UpdateUserRequest) error
-	RefreshToken(req *RefreshTokenRequest) (*RefreshTokenResponse, error)
-	Logout(userID string) error
+	Register(ctx context.Context, req *CreateUserRequest) (*User, error)
+	Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error)
+	GetProfile(ctx context.Context, userID string) (*User, error)
+	UpdateProfile(ctx context.Context, userID string, req *UpdateUserRequest) error
+	RefreshToken(ctx context.Context, req *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	Logout(ctx context.Context, req *LogoutRequest) error
+
+	// 기기별 세션 관리
+	GetSessions(ctx context.Context, userID, currentID string) ([]Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	RevokeOtherSessions(ctx context.Context, userID, currentID string) (int64, error)
+
+	// 비밀번호 재설정, 이메일 인증
+	ForgotPassword(ctx context.Context, req *ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, req *ResendVerificationRequest) error
+
+	// 2단계 인증 (TOTP)
+	CompleteMFALogin(ctx context.Context, req *MFALoginRequest) (*LoginResponse, error)
+	GetMFAStatus(ctx context.Context, userID string) (*MFAStatus, error)
+	SetupMFA(ctx context.Context, userID string) (*MFASetupResponse, error)
+	EnableMFA(ctx context.Context, userID, sessionID, code string) (*RecoveryCodesResponse, error)
+	DisableMFA(ctx context.Context, userID string, req *DisableMFARequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) (*RecoveryCodesResponse, error)
+
+	// API 키 (서버 간 호출)
+	GetAPIKeys(ctx context.Context, userID string) ([]APIKey, error)
+	CreateAPIKey(ctx context.Context, userID string, req *CreateAPIKeyRequest) (*APIKeyCreatedResponse, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID string) error
+
+	// 소셜 로그인 (OAuth2/OIDC)
+	OAuthProviders() []string
+	StartOAuth(ctx context.Context, provider, userID string) (*OAuthStart, error)
+	CompleteOAuth(ctx context.Context, req *OAuthCallbackRequest) (*OAuthResult, error)
+	GetIdentities(ctx context.Context, userID string) ([]Identity, error)
+	UnlinkIdentity(ctx context.Context, userID, provider string) error
 }
 
 type service struct {
-	repo   Repository
-	config *config.Config
+	repo       Repository
+	config     *config.Config
+	guard      *loginGuard
+	hasher     *password.Hasher
+	policy     *password.Policy
+	revoked    *revocation.List
+	mailer     mailer.Mailer
+	principals *middleware.PrincipalCache
+	providers  map[string]*oauth.Provider
+	oauthNames []string
 }
 
 // NewService 서비스 생성자
-func NewService(repo Repository, cfg *config.Config) Service {
+// revoked: 로그아웃, 비밀번호 변경 시 액세스 토큰을 즉시 폐기할 목록 (nil이면 토큰 만료까지 유효)
+// mail: 인증, 비밀번호 재설정 메일 발송 (nil이면 보내지 않음)
+// principals: 이메일 인증, 2단계 인증 설정 시 권한 정보 캐시를 비워 바로 반영하기 위함
+func NewService(repo Repository, cfg *config.Config, revoked *revocation.List, mail mailer.Mailer, principals *middleware.PrincipalCache) Service {
+	providers, oauthNames := newOAuthProviders(cfg.OAuth)
+
 	return &service{
-		repo:   repo,
-		config: cfg,
+		repo:       repo,
+		config:     cfg,
+		guard:      newLoginGuard(cfg.Login, repo),
+		hasher:     newPasswordHasher(cfg.Password),
+		policy:     newPasswordPolicy(cfg.Password),
+		revoked:    revoked,
+		mailer:     mail,
+		principals: principals,
+		providers:  providers,
+		oauthNames: oauthNames,
 	}
 }
 
 // Register 회원가입
-func (s *service) Register(req *CreateUserRequest) (*User, error) {
+func (s *service) Register(ctx context.Context, req *CreateUserRequest) (*User, error) {
 	// 중복 체크
-	exists, err := s.repo.Exists(req.ID)
+	exists, err := s.repo.Exists(ctx, req.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -44,17 +101,19 @@ func (s *service) Register(req *CreateUserRequest) (*User, error) {
 		return nil, errors.ErrUserExists
 	}
 
-	// 비밀번호 해싱
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	// 비밀번호 정책 확인 후 해싱
+	if err := s.checkPassword(ctx, req.Password, req.ID, req.Email, nil); err != nil {
+		return nil, err
+	}
+	hashedPassword, err := s.hashPassword(ctx, req.Password)
 	if err != nil {
-		logger.Error("비밀번호 해싱 실패: %v", err)
-		return nil, errors.Wrap(err, "PASSWORD_HASH_FAILED", "비밀번호 처리에 실패했습니다")
+		return nil, err
 	}
 
 	// 사용자 생성
 	user := &User{
 		ID:        req.ID,
-		Password:  string(hashedPassword),
+		Password:  hashedPassword,
 		Name:      req.Name,
 		Email:     req.Email,
 		AuthType:  "U", // 일반 사용자
@@ -62,184 +121,297 @@ func (s *service) Register(req *CreateUserRequest) (*User, error) {
 		CreatedAt: time.Now(),
 	}
 
-	if err := s.repo.Create(user); err != nil {
+	if err := s.repo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
-	logger.Info("새 사용자 등록 완료: %s", user.ID)
+	if err := s.recordPassword(ctx, user.ID, hashedPassword, user.CreatedAt); err != nil {
+		logger.FromContext(ctx).Warn("비밀번호 이력 저장 실패: %s: %v", user.ID, err)
+	}
+
+	logger.FromContext(ctx).Info("새 사용자 등록 완료: %s", user.ID)
+
+	// 이메일 인증 메일 (실패해도 가입은 완료 - 재발송 가능)
+	if err := s.sendVerification(ctx, user); err != nil {
+		logger.FromContext(ctx).Warn("이메일 인증 메일 발송 실패: %s: %v", user.ID, err)
+	}
+
 	return user.ToPublic(), nil
 }
 
 // Login 로그인
-func (s *service) Login(req *LoginRequest) (*LoginResponse, error) {
-	// 사용자 조회
-	user, err := s.repo.FindByID(req.ID)
+func (s *service) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	now := time.Now()
+
+	// 사용자 조회 (없는 계정도 IP 실패 횟수에는 포함)
+	user, err := s.repo.FindByID(ctx, req.ID)
 	if err != nil {
 		if errors.Is(err, errors.ErrUserNotFound) {
-			return nil, errors.ErrInvalidCredentials
+			if err := s.guard.check(nil, req.IP, now); err != nil {
+				return nil, err
+			}
+			return nil, s.guard.failed(ctx, nil, req.IP, now)
 		}
 		return nil, err
 	}
 
+	// 잠금, 실패 후 대기 시간 확인 (비밀번호 확인 전에 거부)
+	if err := s.guard.check(user, req.IP, now); err != nil {
+		logger.FromContext(ctx).Warn("로그인 거부 (잠금/대기 중): %s", req.ID)
+		return nil, err
+	}
+
 	// 비밀번호 확인
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		logger.Warn("로그인 실패 (잘못된 비밀번호): %s", req.ID)
-		return nil, errors.ErrInvalidCredentials
-	}
-
-	// 토큰 생성
-	accessToken, err := middleware.GenerateToken(
-		user.ID,
-		s.config.JWT.AccessExpireMin,
-		s.config.JWT.AccessSecret,
-		s.config.JWT.TokenSecret,
-		s.config.App.ServiceName,
-	)
-	if err != nil {
-		logger.Error("액세스 토큰 생성 실패: %v", err)
-		return nil, errors.Wrap(err, "TOKEN_GENERATION_FAILED", "토큰 생성에 실패했습니다")
+	if !s.verifyPassword(ctx, user, req.Password) {
+		return nil, s.guard.failed(ctx, user, req.IP, now)
 	}
 
-	refreshToken, err := middleware.GenerateToken(
-		user.ID,
-		s.config.JWT.RefreshExpireDays*24*60, // 일 -> 분
-		s.config.JWT.RefreshSecret,
-		s.config.JWT.TokenSecret,
-		s.config.App.ServiceName,
-	)
-	if err != nil {
-		logger.Error("리프레시 토큰 생성 실패: %v", err)
-		return nil, errors.Wrap(err, "TOKEN_GENERATION_FAILED", "토큰 생성에 실패했습니다")
+	// 해싱 설정이 바뀌었으면 확인한 비밀번호로 다시 해싱
+	s.upgradePassword(ctx, user, req.Password)
+
+	// 이메일 인증 전 로그인 불가 설정 (비밀번호 확인 후에 알려줌)
+	if s.config.Account.UnverifiedAccess == config.UnverifiedNone && !user.EmailVerified() {
+		logger.FromContext(ctx).Info("로그인 거부 (이메일 미인증): %s", user.ID)
+		return nil, errors.ErrEmailNotVerified
 	}
 
-	// 리프레시 토큰 DB 저장
-	if err := s.repo.UpdateRefreshToken(user.ID, refreshToken); err != nil {
+	// 2단계 인증 사용 중이면 코드 확인 후 토큰 발급 (실패 횟수는 2단계까지 통과해야 초기화)
+	if user.MFAEnabled() {
+		return s.startMFALogin(ctx, user)
+	}
+
+	if err := s.guard.succeeded(ctx, user); err != nil {
 		return nil, err
 	}
 
-	logger.Info("로그인 성공: %s", user.ID)
+	// 기기별 세션 생성 (다른 기기의 세션은 그대로 유지)
+	session, accessToken, refreshToken, err := s.startSession(ctx, user.ID, req.IP, req.UserAgent, now)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("로그인 성공: %s (세션: %s)", user.ID, session.ID)
 
 	return &LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-		User:         user.ToPublic(),
+		User:         s.withAccess(ctx, user.ToPublic()),
 	}, nil
 }
 
+// withAccess 응답에 역할과 권한 포함 (관리자 페이지 메뉴, 접근 판단용)
+// 조회에 실패하면 빈 채로 반환한다 (권한 검사는 요청마다 서버에서 다시 함)
+func (s *service) withAccess(ctx context.Context, user *User) *User {
+	if s.principals == nil {
+		return user
+	}
+
+	principal, err := s.principals.Get(ctx, user.ID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("사용자 권한 조회 실패: %s: %v", user.ID, err)
+		return user
+	}
+
+	user.Roles = principal.Roles
+	user.Permissions = principal.Permissions
+	return user
+}
+
 // GetProfile 프로필 조회
-func (s *service) GetProfile(userID string) (*User, error) {
-	user, err := s.repo.FindByID(userID)
+func (s *service) GetProfile(ctx context.Context, userID string) (*User, error) {
+	user, err := s.repo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return user.ToPublic(), nil
+	return s.withAccess(ctx, user.ToPublic()), nil
 }
 
 // UpdateProfile 프로필 수정
-func (s *service) UpdateProfile(userID string, req *UpdateUserRequest) error {
+// 이메일을 바꾸면 미인증 상태가 되고 새 이메일로 인증 메일을 보낸다
+func (s *service) UpdateProfile(ctx context.Context, userID string, req *UpdateUserRequest) error {
 	updates := make(map[string]interface{})
 
 	if req.Name != "" {
 		updates["u_name"] = req.Name
 	}
 
-	if req.Email != "" {
+	var user *User
+	if req.Email != "" || req.Password != "" {
+		found, err := s.repo.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		user = found
+	}
+
+	var emailChanged *User
+	if req.Email != "" && user.Email != req.Email {
 		updates["u_email"] = req.Email
+		updates["u_email_verified_at"] = nil
+		emailChanged = &User{ID: user.ID, Name: user.Name, Email: req.Email}
 	}
 
+	var hashedPassword string
 	if req.Password != "" {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		email := user.Email
+		if req.Email != "" {
+			email = req.Email
+		}
+		if err := s.checkPassword(ctx, req.Password, userID, email, user); err != nil {
+			return err
+		}
+
+		hashed, err := s.hashPassword(ctx, req.Password)
 		if err != nil {
-			logger.Error("비밀번호 해싱 실패: %v", err)
-			return errors.Wrap(err, "PASSWORD_HASH_FAILED", "비밀번호 처리에 실패했습니다")
+			return err
 		}
-		updates["u_pass"] = string(hashedPassword)
+		hashedPassword = hashed
+		updates["u_pass"] = hashedPassword
 	}
 
 	if len(updates) == 0 {
 		return errors.New("NO_UPDATES", "수정할 내용이 없습니다")
 	}
 
-	if err := s.repo.Update(userID, updates); err != nil {
+	if err := s.repo.Update(ctx, userID, updates); err != nil {
 		return err
 	}
 
-	logger.Info("프로필 수정 완료: %s", userID)
+	// 비밀번호가 바뀌면 모든 기기에서 로그아웃 (발급된 토큰 전체 폐기)
+	if req.Password != "" {
+		if err := s.recordPassword(ctx, userID, hashedPassword, time.Now()); err != nil {
+			logger.FromContext(ctx).Warn("비밀번호 이력 저장 실패: %s: %v", userID, err)
+		}
+		if _, err := s.repo.RevokeSessions(ctx, userID, "", SessionRevokePassword, time.Now()); err != nil {
+			return err
+		}
+		s.revoked.RevokeUser(ctx, userID, s.config.JWT.AccessTTL())
+		logger.FromContext(ctx).Info("비밀번호 변경으로 모든 세션 종료: %s", userID)
+	}
+
+	if emailChanged != nil {
+		s.principals.Invalidate(userID)
+		if err := s.sendVerification(ctx, emailChanged); err != nil {
+			logger.FromContext(ctx).Warn("이메일 인증 메일 발송 실패: %s: %v", userID, err)
+		}
+	}
+
+	logger.FromContext(ctx).Info("프로필 수정 완료: %s", userID)
 	return nil
 }
 
 // RefreshToken 토큰 갱신
-func (s *service) RefreshToken(req *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+// 리프레시 토큰은 매번 새로 발급하고, 이미 교체된 토큰이 다시 오면 탈취로 보고 세션을 종료한다
+func (s *service) RefreshToken(ctx context.Context, req *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	now := time.Now()
+
 	// 리프레시 토큰 검증
 	claims, err := middleware.ValidateToken(
 		req.RefreshToken,
-		s.config.JWT.RefreshSecret,
-		s.config.JWT.TokenSecret,
+		s.config.JWT.RefreshKeys,
+		s.config.JWT.TokenKeys,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if claims.SessionID == "" {
+		return nil, errors.ErrInvalidToken
+	}
 
-	// DB에 저장된 토큰과 대조
-	user, err := s.repo.FindByID(claims.UserID)
+	// 세션 확인
+	session, err := s.repo.FindSession(ctx, claims.SessionID)
 	if err != nil {
+		if errors.Is(err, errors.ErrSessionNotFound) {
+			return nil, errors.ErrInvalidToken
+		}
 		return nil, err
 	}
-
-	if user.RefreshToken != req.RefreshToken {
-		logger.Warn("유효하지 않은 리프레시 토큰: %s", claims.UserID)
+	if session.UserID != claims.UserID || !session.Active(now) {
+		logger.FromContext(ctx).Warn("종료된 세션의 리프레시 토큰: %s (세션: %s)", claims.UserID, session.ID)
 		return nil, errors.ErrInvalidToken
 	}
 
-	// 새 액세스 토큰 생성
-	accessToken, err := middleware.GenerateToken(
-		user.ID,
-		s.config.JWT.AccessExpireMin,
-		s.config.JWT.AccessSecret,
-		s.config.JWT.TokenSecret,
-		s.config.App.ServiceName,
-	)
+	oldHash := hashToken(req.RefreshToken)
+	if subtle.ConstantTimeCompare([]byte(oldHash), []byte(session.TokenHash)) != 1 {
+		return nil, s.tokenReused(ctx, session, now)
+	}
+
+	// 새 토큰 발급 후 세션의 토큰 교체
+	accessToken, refreshToken, err := s.issueTokens(session.UserID, session.ID)
 	if err != nil {
-		return nil, errors.Wrap(err, "TOKEN_GENERATION_FAILED", "토큰 생성에 실패했습니다")
-	}
-
-	// 리프레시 토큰 재사용 판단 (24시간 이상 남았으면 재사용)
-	newRefreshToken := req.RefreshToken
-	if time.Until(claims.ExpiresAt.Time) < time.Duration(s.config.JWT.RefreshReuseHours)*time.Hour {
-		// 새 리프레시 토큰 생성
-		newRefreshToken, err = middleware.GenerateToken(
-			user.ID,
-			s.config.JWT.RefreshExpireDays*24*60,
-			s.config.JWT.RefreshSecret,
-			s.config.JWT.TokenSecret,
-			s.config.App.ServiceName,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "TOKEN_GENERATION_FAILED", "토큰 생성에 실패했습니다")
-		}
+		logger.FromContext(ctx).Error("토큰 생성 실패: %v", err)
+		return nil, err
+	}
 
-		// DB 업데이트
-		if err := s.repo.UpdateRefreshToken(user.ID, newRefreshToken); err != nil {
-			return nil, err
-		}
+	rotated, err := s.repo.RotateSession(ctx, session.ID, oldHash, hashToken(refreshToken), s.refreshExpiresAt(now), req.IP, req.UserAgent, now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// 같은 토큰으로 다른 요청이 먼저 교체한 경우
+		return nil, s.tokenReused(ctx, session, now)
 	}
 
-	logger.Info("토큰 갱신 완료: %s", user.ID)
+	logger.FromContext(ctx).Info("토큰 갱신 완료: %s (세션: %s)", session.UserID, session.ID)
 
 	return &RefreshTokenResponse{
 		AccessToken:  accessToken,
-		RefreshToken: newRefreshToken,
+		RefreshToken: refreshToken,
 	}, nil
 }
 
-// Logout 로그아웃
-func (s *service) Logout(userID string) error {
-	// 리프레시 토큰 삭제
-	if err := s.repo.UpdateRefreshToken(userID, ""); err != nil {
-		return err
+// Logout 로그아웃 (현재 세션만 종료, 세션 정보가 없는 토큰이면 모든 세션 종료)
+// 요청에 사용한 액세스 토큰과 같은 세션에서 발급된 액세스 토큰도 즉시 폐기한다
+// (모든 세션을 종료하면 그 사용자에게 발급된 액세스 토큰 전체를 폐기)
+func (s *service) Logout(ctx context.Context, req *LogoutRequest) error {
+	now := time.Now()
+
+	if req.SessionID == "" {
+		if _, err := s.repo.RevokeSessions(ctx, req.UserID, "", SessionRevokeLogout, now); err != nil {
+			return err
+		}
+		s.revoked.RevokeUser(ctx, req.UserID, s.config.JWT.AccessTTL())
+	} else {
+		if _, err := s.repo.RevokeSession(ctx, req.UserID, req.SessionID, SessionRevokeLogout, now); err != nil {
+			return err
+		}
+		s.revoked.RevokeSession(ctx, req.SessionID, s.config.JWT.AccessTTL())
 	}
+	s.revoked.RevokeToken(ctx, req.TokenID, req.TokenExpiresAt)
 
-	logger.Info("로그아웃 완료: %s", userID)
+	logger.FromContext(ctx).Info("로그아웃 완료: %s (세션: %s)", req.UserID, req.SessionID)
 	return nil
-}
\ No newline at end of file
+}
+
+// NewPrincipalLoader 인증 미들웨어용 권한 정보 조회 함수 생성
+// policy: 사용자 역할을 권한 목록으로 바꾸는 역할별 권한 캐시
+func NewPrincipalLoader(repo Repository, policy *rbac.Policy) middleware.PrincipalLoader {
+	return func(ctx context.Context, userID string) (*middleware.Principal, error) {
+		user, err := repo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		roles, err := repo.FindRoles(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions, err := policy.Permissions(ctx, roles)
+		if err != nil {
+			logger.FromContext(ctx).Error("역할 권한 조회 실패: %v", err)
+			return nil, errors.Wrap(err, "ROLE_POLICY_FAILED", "역할 권한 조회에 실패했습니다")
+		}
+
+		return &middleware.Principal{
+			UserID:        user.ID,
+			AuthType:      user.AuthType,
+			AuthLevel:     user.AuthLevel,
+			Roles:         roles,
+			Permissions:   permissions,
+			EmailVerified: user.EmailVerified(),
+			MFAEnabled:    user.MFAEnabled(),
+		}, nil
+	}
+}
@@ -0,0 +1,181 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"gin_starter/internal/middleware"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"time"
+	"unicode/utf8"
+)
+
+// maxUserAgentLen 세션에 저장하는 User-Agent 최대 길이 (컬럼 크기)
+const maxUserAgentLen = 255
+
+// GetSessions 사용자의 활성 세션 목록 (currentID 세션에 current 표시)
+func (s *service) GetSessions(ctx context.Context, userID, currentID string) ([]Session, error) {
+	sessions, err := s.repo.FindActiveSessions(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentID
+	}
+	return sessions, nil
+}
+
+// RevokeSession 사용자가 자기 세션 하나 종료
+func (s *service) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	revoked, err := s.repo.RevokeSession(ctx, userID, sessionID, SessionRevokeUser, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.ErrSessionNotFound
+	}
+	s.revoked.RevokeSession(ctx, sessionID, s.config.JWT.AccessTTL())
+
+	logger.FromContext(ctx).Info("세션 종료: %s (세션: %s)", userID, sessionID)
+	return nil
+}
+
+// RevokeOtherSessions 현재 세션을 제외한 모든 세션 종료
+func (s *service) RevokeOtherSessions(ctx context.Context, userID, currentID string) (int64, error) {
+	count, err := s.revokeOtherSessions(ctx, userID, currentID, SessionRevokeUser, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	logger.FromContext(ctx).Info("다른 기기 세션 종료: %s (%d개)", userID, count)
+	return count, nil
+}
+
+// revokeOtherSessions 현재 세션을 제외한 세션 종료 및 액세스 토큰 폐기 (reason: 종료 사유)
+func (s *service) revokeOtherSessions(ctx context.Context, userID, currentID, reason string, now time.Time) (int64, error) {
+	// 액세스 토큰은 세션별로 폐기해야 하므로 종료할 세션 목록을 먼저 조회
+	sessions, err := s.repo.FindActiveSessions(ctx, userID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := s.repo.RevokeSessions(ctx, userID, currentID, reason, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, session := range sessions {
+		if session.ID != currentID {
+			s.revoked.RevokeSession(ctx, session.ID, s.config.JWT.AccessTTL())
+		}
+	}
+	return count, nil
+}
+
+// startSession 로그인 시 새 세션을 만들고 토큰 발급
+func (s *service) startSession(ctx context.Context, userID, ip, userAgent string, now time.Time) (*Session, string, string, error) {
+	// 만료된 세션 정리 (실패해도 로그인은 계속)
+	if _, err := s.repo.DeleteExpiredSessions(ctx, userID, now); err != nil {
+		logger.FromContext(ctx).Warn("만료 세션 정리 실패: %v", err)
+	}
+
+	sessionID, err := newSessionID()
+	if err != nil {
+		return nil, "", "", errors.Wrap(err, "SESSION_CREATE_FAILED", "세션 생성에 실패했습니다")
+	}
+
+	accessToken, refreshToken, err := s.issueTokens(userID, sessionID)
+	if err != nil {
+		logger.FromContext(ctx).Error("토큰 생성 실패: %v", err)
+		return nil, "", "", err
+	}
+
+	session := &Session{
+		ID:         sessionID,
+		UserID:     userID,
+		TokenHash:  hashToken(refreshToken),
+		UserAgent:  truncate(userAgent, maxUserAgentLen),
+		IP:         ip,
+		LastUsedAt: &now,
+		ExpiresAt:  s.refreshExpiresAt(now),
+		CreatedAt:  now,
+	}
+	if err := s.repo.CreateSession(ctx, session); err != nil {
+		return nil, "", "", err
+	}
+
+	return session, accessToken, refreshToken, nil
+}
+
+// issueTokens 세션의 액세스/리프레시 토큰 발급
+func (s *service) issueTokens(userID, sessionID string) (string, string, error) {
+	accessToken, err := middleware.GenerateToken(
+		userID,
+		sessionID,
+		s.config.JWT.AccessExpireMin,
+		s.config.JWT.AccessKeys,
+		s.config.JWT.TokenKeys,
+		s.config.App.ServiceName,
+	)
+	if err != nil {
+		return "", "", errors.Wrap(err, "TOKEN_GENERATION_FAILED", "토큰 생성에 실패했습니다")
+	}
+
+	refreshToken, err := middleware.GenerateToken(
+		userID,
+		sessionID,
+		s.config.JWT.RefreshExpireDays*24*60, // 일 -> 분
+		s.config.JWT.RefreshKeys,
+		s.config.JWT.TokenKeys,
+		s.config.App.ServiceName,
+	)
+	if err != nil {
+		return "", "", errors.Wrap(err, "TOKEN_GENERATION_FAILED", "토큰 생성에 실패했습니다")
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// tokenReused 교체된 리프레시 토큰 재사용 - 세션(토큰 패밀리) 전체 종료
+func (s *service) tokenReused(ctx context.Context, session *Session, now time.Time) error {
+	logger.FromContext(ctx).Warn("리프레시 토큰 재사용 감지, 세션 종료: %s (세션: %s)", session.UserID, session.ID)
+
+	if _, err := s.repo.RevokeSession(ctx, session.UserID, session.ID, SessionRevokeReuse, now); err != nil {
+		return err
+	}
+	s.revoked.RevokeSession(ctx, session.ID, s.config.JWT.AccessTTL())
+	return errors.ErrTokenReused
+}
+
+// refreshExpiresAt 지금 발급한 리프레시 토큰의 만료 시각
+func (s *service) refreshExpiresAt(now time.Time) time.Time {
+	return now.Add(time.Duration(s.config.JWT.RefreshExpireDays) * 24 * time.Hour)
+}
+
+// newSessionID 무작위 세션 ID (32자 hex)
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken DB에 저장할 리프레시 토큰 해시 (SHA-256 hex)
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// truncate 문자열을 최대 n바이트로 자름 (UTF-8 문자 중간에서 자르지 않음)
+func truncate(str string, n int) string {
+	if len(str) <= n {
+		return str
+	}
+	for n > 0 && !utf8.RuneStart(str[n]) {
+		n--
+	}
+	return str[:n]
+}
@@ -0,0 +1,910 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"gin_starter/pkg/errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// identPattern 허용하는 식별자 형식 (영문/숫자/밑줄, 숫자로 시작 불가)
+var identPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidIdent 테이블/컬럼 식별자가 허용 형식인지 확인 (schema.table 형식 포함)
+func ValidIdent(ident string) bool {
+	parts := strings.Split(ident, ".")
+	if len(parts) > 2 {
+		return false
+	}
+	for _, part := range parts {
+		if !identPattern.MatchString(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidIdent 식별자 검증 실패 에러
+// 호출하는 코드의 잘못이므로 500이고, 식별자는 원본 에러로만 남겨 응답에 보내지 않는다
+func invalidIdent(ident string) error {
+	return errors.Wrap(fmt.Errorf("유효하지 않은 식별자: %q", ident), "INVALID_IDENTIFIER", "쿼리를 만들 수 없습니다")
+}
+
+// sqlWriter 방언에 맞게 SQL과 바인드 값을 함께 조립 (첫 에러만 보관)
+type sqlWriter struct {
+	dialect Dialect
+	buf     strings.Builder
+	args    []interface{}
+	err     error
+}
+
+func (w *sqlWriter) write(s string) {
+	w.buf.WriteString(s)
+}
+
+// bind 값을 '?' 파라미터로 추가
+func (w *sqlWriter) bind(v interface{}) {
+	w.buf.WriteByte('?')
+	w.args = append(w.args, v)
+}
+
+// ident 식별자를 검증 후 인용해서 추가
+func (w *sqlWriter) ident(ident string) {
+	if !ValidIdent(ident) {
+		w.fail(invalidIdent(ident))
+		return
+	}
+	w.buf.WriteString(w.dialect.QuoteIdent(ident))
+}
+
+// table 테이블 이름 추가 ("_user u", "_user AS u" 형식의 별칭 허용)
+func (w *sqlWriter) table(table string) {
+	name, alias := splitAlias(table)
+	w.ident(name)
+	if alias != "" {
+		w.write(" ")
+		w.ident(alias)
+	}
+}
+
+// column SELECT 컬럼 추가 (*, t.*, "col AS alias" 허용)
+func (w *sqlWriter) column(col string) {
+	switch {
+	case col == "*":
+		w.write("*")
+	case strings.HasSuffix(col, ".*"):
+		w.ident(strings.TrimSuffix(col, ".*"))
+		w.write(".*")
+	default:
+		name, alias := splitAlias(col)
+		w.ident(name)
+		if alias != "" {
+			w.write(" AS ")
+			w.ident(alias)
+		}
+	}
+}
+
+func (w *sqlWriter) fail(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// splitAlias "이름 별칭" 또는 "이름 AS 별칭"을 분리
+func splitAlias(s string) (string, string) {
+	fields := strings.Fields(s)
+	switch {
+	case len(fields) == 2:
+		return fields[0], fields[1]
+	case len(fields) == 3 && strings.EqualFold(fields[1], "AS"):
+		return fields[0], fields[2]
+	default:
+		return strings.TrimSpace(s), ""
+	}
+}
+
+// sortedKeys 맵 키를 정렬해서 반환 (생성되는 SQL을 항상 같게 유지)
+func sortedKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ===== WHERE 조건식 =====
+
+// Expr WHERE/JOIN 조건식
+type Expr interface {
+	writeTo(w *sqlWriter)
+}
+
+type cmpExpr struct {
+	col string
+	op  string
+	val interface{}
+}
+
+func (e cmpExpr) writeTo(w *sqlWriter) {
+	w.ident(e.col)
+	w.write(" " + e.op + " ")
+	w.bind(e.val)
+}
+
+// Eq col = val (val이 nil이면 IS NULL)
+func Eq(col string, val interface{}) Expr {
+	if val == nil {
+		return IsNull(col)
+	}
+	return cmpExpr{col: col, op: "=", val: val}
+}
+
+// Ne col <> val (val이 nil이면 IS NOT NULL)
+func Ne(col string, val interface{}) Expr {
+	if val == nil {
+		return IsNotNull(col)
+	}
+	return cmpExpr{col: col, op: "<>", val: val}
+}
+
+// Gt col > val
+func Gt(col string, val interface{}) Expr { return cmpExpr{col: col, op: ">", val: val} }
+
+// Gte col >= val
+func Gte(col string, val interface{}) Expr { return cmpExpr{col: col, op: ">=", val: val} }
+
+// Lt col < val
+func Lt(col string, val interface{}) Expr { return cmpExpr{col: col, op: "<", val: val} }
+
+// Lte col <= val
+func Lte(col string, val interface{}) Expr { return cmpExpr{col: col, op: "<=", val: val} }
+
+// Like col LIKE pattern (와일드카드는 호출자가 포함)
+func Like(col string, pattern string) Expr { return cmpExpr{col: col, op: "LIKE", val: pattern} }
+
+type inExpr struct {
+	col  string
+	vals []interface{}
+	not  bool
+}
+
+func (e inExpr) writeTo(w *sqlWriter) {
+	// 빈 목록은 IN이면 항상 거짓, NOT IN이면 항상 참
+	if len(e.vals) == 0 {
+		if e.not {
+			w.write("1 = 1")
+		} else {
+			w.write("1 = 0")
+		}
+		return
+	}
+
+	w.ident(e.col)
+	if e.not {
+		w.write(" NOT IN (")
+	} else {
+		w.write(" IN (")
+	}
+	for i, v := range e.vals {
+		if i > 0 {
+			w.write(", ")
+		}
+		w.bind(v)
+	}
+	w.write(")")
+}
+
+// In col IN (vals...)
+func In(col string, vals ...interface{}) Expr { return inExpr{col: col, vals: vals} }
+
+// NotIn col NOT IN (vals...)
+func NotIn(col string, vals ...interface{}) Expr { return inExpr{col: col, vals: vals, not: true} }
+
+type nullExpr struct {
+	col string
+	not bool
+}
+
+func (e nullExpr) writeTo(w *sqlWriter) {
+	w.ident(e.col)
+	if e.not {
+		w.write(" IS NOT NULL")
+	} else {
+		w.write(" IS NULL")
+	}
+}
+
+// IsNull col IS NULL
+func IsNull(col string) Expr { return nullExpr{col: col} }
+
+// IsNotNull col IS NOT NULL
+func IsNotNull(col string) Expr { return nullExpr{col: col, not: true} }
+
+type betweenExpr struct {
+	col      string
+	from, to interface{}
+}
+
+func (e betweenExpr) writeTo(w *sqlWriter) {
+	w.ident(e.col)
+	w.write(" BETWEEN ")
+	w.bind(e.from)
+	w.write(" AND ")
+	w.bind(e.to)
+}
+
+// Between col BETWEEN from AND to
+func Between(col string, from, to interface{}) Expr { return betweenExpr{col: col, from: from, to: to} }
+
+type colCmpExpr struct {
+	left, op, right string
+}
+
+func (e colCmpExpr) writeTo(w *sqlWriter) {
+	w.ident(e.left)
+	w.write(" " + e.op + " ")
+	w.ident(e.right)
+}
+
+// ColEq 컬럼끼리 비교 (JOIN 조건용, 예: ColEq("b.author_id", "u.u_id"))
+func ColEq(left, right string) Expr { return colCmpExpr{left: left, op: "=", right: right} }
+
+type logicExpr struct {
+	op    string
+	exprs []Expr
+}
+
+func (e logicExpr) writeTo(w *sqlWriter) {
+	if len(e.exprs) == 0 {
+		// 조건이 없으면 AND는 참, OR는 거짓
+		if e.op == "AND" {
+			w.write("1 = 1")
+		} else {
+			w.write("1 = 0")
+		}
+		return
+	}
+
+	if len(e.exprs) == 1 {
+		e.exprs[0].writeTo(w)
+		return
+	}
+
+	w.write("(")
+	for i, expr := range e.exprs {
+		if i > 0 {
+			w.write(" " + e.op + " ")
+		}
+		expr.writeTo(w)
+	}
+	w.write(")")
+}
+
+// compact nil 조건 제거 (조건부로 조립할 때 nil을 그대로 넘길 수 있음)
+func compact(exprs []Expr) []Expr {
+	out := make([]Expr, 0, len(exprs))
+	for _, e := range exprs {
+		if e != nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// And 모든 조건 만족 (nil 조건은 무시)
+func And(exprs ...Expr) Expr { return logicExpr{op: "AND", exprs: compact(exprs)} }
+
+// Or 하나 이상 조건 만족 (nil 조건은 무시)
+func Or(exprs ...Expr) Expr { return logicExpr{op: "OR", exprs: compact(exprs)} }
+
+type notExpr struct {
+	expr Expr
+}
+
+func (e notExpr) writeTo(w *sqlWriter) {
+	w.write("NOT (")
+	e.expr.writeTo(w)
+	w.write(")")
+}
+
+// Not 조건 부정
+func Not(expr Expr) Expr { return notExpr{expr: expr} }
+
+type rawExpr struct {
+	sql  string
+	args []interface{}
+}
+
+func (e rawExpr) writeTo(w *sqlWriter) {
+	w.write("(" + e.sql + ")")
+	w.args = append(w.args, e.args...)
+}
+
+// Raw '?' 플레이스홀더를 사용하는 SQL 조각 (코드에 고정된 신뢰할 수 있는 문자열만 사용)
+// 빈 문자열이면 nil을 반환하므로 조건 없음으로 처리된다
+func Raw(sql string, args ...interface{}) Expr {
+	if strings.TrimSpace(sql) == "" {
+		return nil
+	}
+	return rawExpr{sql: sql, args: args}
+}
+
+// writeWhere WHERE 절 추가 (조건이 없으면 생략)
+func writeWhere(w *sqlWriter, where []Expr) {
+	if len(where) == 0 {
+		return
+	}
+	w.write(" WHERE ")
+	And(where...).writeTo(w)
+}
+
+// alwaysTrue 조건이 항상 참으로 렌더링되는지 확인 (빈 And, 빈 NotIn 등은 조건 없음과 같다)
+func alwaysTrue(e Expr) bool {
+	switch e := e.(type) {
+	case inExpr:
+		return e.not && len(e.vals) == 0
+	case logicExpr:
+		if e.op == "AND" {
+			for _, expr := range e.exprs {
+				if !alwaysTrue(expr) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, expr := range e.exprs {
+			if alwaysTrue(expr) {
+				return true
+			}
+		}
+		return false
+	case notExpr:
+		return alwaysFalse(e.expr)
+	}
+	return false
+}
+
+// alwaysFalse 조건이 항상 거짓으로 렌더링되는지 확인
+func alwaysFalse(e Expr) bool {
+	switch e := e.(type) {
+	case inExpr:
+		return !e.not && len(e.vals) == 0
+	case logicExpr:
+		if e.op == "OR" {
+			for _, expr := range e.exprs {
+				if !alwaysFalse(expr) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, expr := range e.exprs {
+			if alwaysFalse(expr) {
+				return true
+			}
+		}
+		return false
+	case notExpr:
+		return alwaysTrue(e.expr)
+	}
+	return false
+}
+
+// hasCondition 행을 실제로 거르는 조건이 있는지 확인 (UPDATE, DELETE의 All() 검사용)
+func hasCondition(where []Expr) bool {
+	return len(where) > 0 && !alwaysTrue(And(where...))
+}
+
+// ===== SELECT =====
+
+// SortOrder 정렬 방향
+type SortOrder string
+
+const (
+	Asc  SortOrder = "ASC"
+	Desc SortOrder = "DESC"
+)
+
+type orderBy struct {
+	col   string
+	order SortOrder
+}
+
+type join struct {
+	kind  string
+	table string
+	on    Expr
+}
+
+// SelectQuery SELECT 쿼리 빌더
+type SelectQuery struct {
+	repo     *Repository
+	table    string
+	columns  []string
+	distinct bool
+	joins    []join
+	where    []Expr
+	groupBy  []string
+	orderBy  []orderBy
+	limit    int
+	offset   int
+}
+
+// Select SELECT 쿼리 빌더 생성
+func (r *Repository) Select(table string, columns ...string) *SelectQuery {
+	return &SelectQuery{repo: r, table: table, columns: columns}
+}
+
+// Columns 조회할 컬럼 추가 (미지정 시 *)
+func (q *SelectQuery) Columns(columns ...string) *SelectQuery {
+	q.columns = append(q.columns, columns...)
+	return q
+}
+
+// Distinct SELECT DISTINCT
+func (q *SelectQuery) Distinct() *SelectQuery {
+	q.distinct = true
+	return q
+}
+
+// Join INNER JOIN
+func (q *SelectQuery) Join(table string, on Expr) *SelectQuery {
+	q.joins = append(q.joins, join{kind: "INNER JOIN", table: table, on: on})
+	return q
+}
+
+// LeftJoin LEFT JOIN
+func (q *SelectQuery) LeftJoin(table string, on Expr) *SelectQuery {
+	q.joins = append(q.joins, join{kind: "LEFT JOIN", table: table, on: on})
+	return q
+}
+
+// Where 조건 추가 (여러 번 호출하면 AND로 결합, nil은 무시)
+func (q *SelectQuery) Where(exprs ...Expr) *SelectQuery {
+	q.where = append(q.where, compact(exprs)...)
+	return q
+}
+
+// GroupBy GROUP BY 컬럼
+func (q *SelectQuery) GroupBy(columns ...string) *SelectQuery {
+	q.groupBy = append(q.groupBy, columns...)
+	return q
+}
+
+// OrderBy 정렬 추가
+func (q *SelectQuery) OrderBy(col string, order SortOrder) *SelectQuery {
+	q.orderBy = append(q.orderBy, orderBy{col: col, order: order})
+	return q
+}
+
+// Limit 최대 행 수
+func (q *SelectQuery) Limit(limit int) *SelectQuery {
+	q.limit = limit
+	return q
+}
+
+// Offset 건너뛸 행 수 (Limit과 함께 사용)
+func (q *SelectQuery) Offset(offset int) *SelectQuery {
+	q.offset = offset
+	return q
+}
+
+// Page 페이지 번호(1부터)와 페이지 크기로 Limit/Offset 설정
+func (q *SelectQuery) Page(page, limit int) *SelectQuery {
+	if page < 1 {
+		page = 1
+	}
+	q.limit = limit
+	q.offset = (page - 1) * limit
+	return q
+}
+
+// writeFrom FROM, JOIN, WHERE 절 작성
+func (q *SelectQuery) writeFrom(w *sqlWriter) {
+	w.write(" FROM ")
+	w.table(q.table)
+
+	for _, j := range q.joins {
+		w.write(" " + j.kind + " ")
+		w.table(j.table)
+		if j.on != nil {
+			w.write(" ON ")
+			j.on.writeTo(w)
+		}
+	}
+
+	writeWhere(w, q.where)
+}
+
+// Build SQL과 바인드 값 생성
+func (q *SelectQuery) Build() (string, []interface{}, error) {
+	w := &sqlWriter{dialect: q.repo.db.Dialect()}
+
+	w.write("SELECT ")
+	if q.distinct {
+		w.write("DISTINCT ")
+	}
+	if len(q.columns) == 0 {
+		w.write("*")
+	}
+	for i, col := range q.columns {
+		if i > 0 {
+			w.write(", ")
+		}
+		w.column(col)
+	}
+
+	q.writeFrom(w)
+
+	if len(q.groupBy) > 0 {
+		w.write(" GROUP BY ")
+		for i, col := range q.groupBy {
+			if i > 0 {
+				w.write(", ")
+			}
+			w.ident(col)
+		}
+	}
+
+	if len(q.orderBy) > 0 {
+		w.write(" ORDER BY ")
+		for i, o := range q.orderBy {
+			if i > 0 {
+				w.write(", ")
+			}
+			w.ident(o.col)
+			if o.order == Desc {
+				w.write(" DESC")
+			} else {
+				w.write(" ASC")
+			}
+		}
+	}
+
+	if q.limit > 0 {
+		w.write(" LIMIT ")
+		w.bind(q.limit)
+		if q.offset > 0 {
+			w.write(" OFFSET ")
+			w.bind(q.offset)
+		}
+	}
+
+	return w.buf.String(), w.args, w.err
+}
+
+// Query 다중 행 조회
+func (q *SelectQuery) Query(ctx context.Context) (*Rows, error) {
+	query, args, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+	return q.repo.Query(ctx, query, args...)
+}
+
+// QueryRow 단일 행 조회 (빌드 실패 시 Scan에서 에러 반환)
+func (q *SelectQuery) QueryRow(ctx context.Context) *Row {
+	query, args, err := q.Build()
+	if err != nil {
+		return &Row{err: err, cancel: func() {}}
+	}
+	return q.repo.QueryRow(ctx, query, args...)
+}
+
+// Count 조건에 맞는 행 수 (정렬, LIMIT 무시)
+func (q *SelectQuery) Count(ctx context.Context) (int64, error) {
+	w := &sqlWriter{dialect: q.repo.db.Dialect()}
+	w.write("SELECT COUNT(*)")
+	q.writeFrom(w)
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	var count int64
+	if err := q.repo.QueryRow(ctx, w.buf.String(), w.args...).Scan(&count); err != nil {
+		return 0, wrapScanError(err, "개수 조회 실패")
+	}
+	return count, nil
+}
+
+// Exists 조건에 맞는 행이 있는지 확인
+func (q *SelectQuery) Exists(ctx context.Context) (bool, error) {
+	w := &sqlWriter{dialect: q.repo.db.Dialect()}
+	w.write("SELECT EXISTS(SELECT 1")
+	q.writeFrom(w)
+	w.write(")")
+	if w.err != nil {
+		return false, w.err
+	}
+
+	var exists bool
+	if err := q.repo.QueryRow(ctx, w.buf.String(), w.args...).Scan(&exists); err != nil {
+		return false, wrapScanError(err, "존재 여부 확인 실패")
+	}
+	return exists, nil
+}
+
+// ===== INSERT =====
+
+// InsertQuery INSERT 쿼리 빌더
+type InsertQuery struct {
+	repo      *Repository
+	table     string
+	columns   []string
+	values    []interface{}
+	conflicts []string
+	upsert    bool
+	returning string
+}
+
+// InsertInto INSERT 쿼리 빌더 생성
+func (r *Repository) InsertInto(table string) *InsertQuery {
+	return &InsertQuery{repo: r, table: table}
+}
+
+// Set 컬럼 값 추가 (호출 순서대로 컬럼이 나열됨)
+func (q *InsertQuery) Set(col string, val interface{}) *InsertQuery {
+	q.columns = append(q.columns, col)
+	q.values = append(q.values, val)
+	return q
+}
+
+// Values 맵의 컬럼 값 추가 (컬럼 이름 순으로 정렬)
+func (q *InsertQuery) Values(data map[string]interface{}) *InsertQuery {
+	for _, col := range sortedKeys(data) {
+		q.Set(col, data[col])
+	}
+	return q
+}
+
+// OnConflictUpdate conflictColumns가 충돌하면 나머지 컬럼을 갱신 (UPSERT)
+func (q *InsertQuery) OnConflictUpdate(conflictColumns ...string) *InsertQuery {
+	q.upsert = true
+	q.conflicts = conflictColumns
+	return q
+}
+
+// Returning 생성된 행의 컬럼 반환 (RETURNING 지원 방언만)
+func (q *InsertQuery) Returning(col string) *InsertQuery {
+	q.returning = col
+	return q
+}
+
+// Build SQL과 바인드 값 생성
+func (q *InsertQuery) Build() (string, []interface{}, error) {
+	w := &sqlWriter{dialect: q.repo.db.Dialect()}
+	if len(q.columns) == 0 {
+		return "", nil, errors.New("INVALID_PARAM", "INSERT할 컬럼이 없습니다")
+	}
+
+	w.write("INSERT INTO ")
+	w.table(q.table)
+	w.write(" (")
+	for i, col := range q.columns {
+		if i > 0 {
+			w.write(", ")
+		}
+		w.ident(col)
+	}
+	w.write(") VALUES (")
+	for i, val := range q.values {
+		if i > 0 {
+			w.write(", ")
+		}
+		w.bind(val)
+	}
+	w.write(")")
+
+	if q.upsert {
+		conflicts := make(map[string]bool, len(q.conflicts))
+		for _, col := range q.conflicts {
+			if !ValidIdent(col) {
+				w.fail(invalidIdent(col))
+			}
+			conflicts[col] = true
+		}
+
+		updates := make([]string, 0, len(q.columns))
+		for _, col := range q.columns {
+			if !conflicts[col] {
+				updates = append(updates, col)
+			}
+		}
+		w.write(w.dialect.UpsertClause(q.conflicts, updates))
+	}
+
+	if q.returning != "" {
+		if !w.dialect.SupportsReturning() {
+			w.fail(errors.Wrap(fmt.Errorf("%s는 RETURNING을 지원하지 않습니다", w.dialect.Name()), "UNSUPPORTED", "쿼리를 만들 수 없습니다"))
+		}
+		w.write(" RETURNING ")
+		w.ident(q.returning)
+	}
+
+	return w.buf.String(), w.args, w.err
+}
+
+// Exec INSERT 실행 (트랜잭션은 ctx로 전달)
+func (q *InsertQuery) Exec(ctx context.Context) (sql.Result, error) {
+	query, args, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+	return q.repo.Exec(ctx, query, args...)
+}
+
+// QueryRow RETURNING 결과 조회
+func (q *InsertQuery) QueryRow(ctx context.Context) *Row {
+	query, args, err := q.Build()
+	if err != nil {
+		return &Row{err: err, cancel: func() {}}
+	}
+	return q.repo.QueryRow(ctx, query, args...)
+}
+
+// ===== UPDATE =====
+
+// assignment SET 절 항목
+type assignment struct {
+	col      string
+	val      interface{}
+	operator string // 비어 있으면 단순 대입, 아니면 col = col <operator> val
+}
+
+// UpdateQuery UPDATE 쿼리 빌더
+type UpdateQuery struct {
+	repo  *Repository
+	table string
+	sets  []assignment
+	where []Expr
+	all   bool
+}
+
+// UpdateTable UPDATE 쿼리 빌더 생성
+func (r *Repository) UpdateTable(table string) *UpdateQuery {
+	return &UpdateQuery{repo: r, table: table}
+}
+
+// Set 컬럼 값 대입
+func (q *UpdateQuery) Set(col string, val interface{}) *UpdateQuery {
+	q.sets = append(q.sets, assignment{col: col, val: val})
+	return q
+}
+
+// Values 맵의 컬럼 값 대입 (컬럼 이름 순으로 정렬)
+func (q *UpdateQuery) Values(data map[string]interface{}) *UpdateQuery {
+	for _, col := range sortedKeys(data) {
+		q.Set(col, data[col])
+	}
+	return q
+}
+
+// SetMath 현재 값에 사칙연산 적용 (col = col <operator> ?), operator는 + - * /
+func (q *UpdateQuery) SetMath(col string, operator string, operand interface{}) *UpdateQuery {
+	q.sets = append(q.sets, assignment{col: col, val: operand, operator: operator})
+	return q
+}
+
+// Where 조건 추가 (여러 번 호출하면 AND로 결합, nil은 무시)
+func (q *UpdateQuery) Where(exprs ...Expr) *UpdateQuery {
+	q.where = append(q.where, compact(exprs)...)
+	return q
+}
+
+// All 조건 없이 모든 행 수정을 허용 (호출하지 않으면 조건이 없거나 항상 참인 UPDATE는 에러)
+func (q *UpdateQuery) All() *UpdateQuery {
+	q.all = true
+	return q
+}
+
+// Build SQL과 바인드 값 생성
+func (q *UpdateQuery) Build() (string, []interface{}, error) {
+	w := &sqlWriter{dialect: q.repo.db.Dialect()}
+	if len(q.sets) == 0 {
+		return "", nil, errors.New("INVALID_PARAM", "수정할 컬럼이 없습니다")
+	}
+	if !hasCondition(q.where) && !q.all {
+		return "", nil, errors.New("INVALID_PARAM", "조건 없는 UPDATE는 All()을 호출해야 합니다")
+	}
+
+	w.write("UPDATE ")
+	w.table(q.table)
+	w.write(" SET ")
+	for i, set := range q.sets {
+		if i > 0 {
+			w.write(", ")
+		}
+		w.ident(set.col)
+		w.write(" = ")
+
+		switch set.operator {
+		case "":
+		case "+", "-", "*", "/":
+			w.ident(set.col)
+			w.write(" " + set.operator + " ")
+		default:
+			w.fail(errors.New("INVALID_PARAM", fmt.Sprintf("지원하지 않는 연산자: %s", set.operator)))
+		}
+		w.bind(set.val)
+	}
+
+	writeWhere(w, q.where)
+
+	return w.buf.String(), w.args, w.err
+}
+
+// Exec UPDATE 실행 후 영향받은 행 수 반환
+func (q *UpdateQuery) Exec(ctx context.Context) (int64, error) {
+	query, args, err := q.Build()
+	if err != nil {
+		return 0, err
+	}
+	return rowsAffected(q.repo.Exec(ctx, query, args...))
+}
+
+// ===== DELETE =====
+
+// DeleteQuery DELETE 쿼리 빌더
+type DeleteQuery struct {
+	repo  *Repository
+	table string
+	where []Expr
+	all   bool
+}
+
+// DeleteFrom DELETE 쿼리 빌더 생성
+func (r *Repository) DeleteFrom(table string) *DeleteQuery {
+	return &DeleteQuery{repo: r, table: table}
+}
+
+// Where 조건 추가 (여러 번 호출하면 AND로 결합, nil은 무시)
+func (q *DeleteQuery) Where(exprs ...Expr) *DeleteQuery {
+	q.where = append(q.where, compact(exprs)...)
+	return q
+}
+
+// All 조건 없이 모든 행 삭제를 허용 (호출하지 않으면 조건이 없거나 항상 참인 DELETE는 에러)
+func (q *DeleteQuery) All() *DeleteQuery {
+	q.all = true
+	return q
+}
+
+// Build SQL과 바인드 값 생성
+func (q *DeleteQuery) Build() (string, []interface{}, error) {
+	if !hasCondition(q.where) && !q.all {
+		return "", nil, errors.New("INVALID_PARAM", "조건 없는 DELETE는 All()을 호출해야 합니다")
+	}
+
+	w := &sqlWriter{dialect: q.repo.db.Dialect()}
+	w.write("DELETE FROM ")
+	w.table(q.table)
+	writeWhere(w, q.where)
+	return w.buf.String(), w.args, w.err
+}
+
+// Exec DELETE 실행 후 영향받은 행 수 반환
+func (q *DeleteQuery) Exec(ctx context.Context) (int64, error) {
+	query, args, err := q.Build()
+	if err != nil {
+		return 0, err
+	}
+	return rowsAffected(q.repo.Exec(ctx, query, args...))
+}
+
+// rowsAffected 실행 결과에서 영향받은 행 수 추출
+func rowsAffected(result sql.Result, err error) (int64, error) {
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "DATABASE_ERROR", "영향받은 행 조회 실패")
+	}
+	return affected, nil
+}
@@ -0,0 +1,116 @@
+package database_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gin_starter/internal/infrastructure/database"
+	"gin_starter/pkg/errors"
+)
+
+// newRepo 쿼리 생성만 확인하는 리포지토리 (기본 MySQL 방언, 연결 없음)
+func newRepo() *database.Repository {
+	return database.NewRepository(&database.DB{})
+}
+
+func TestUpdateDeleteRequireAll(t *testing.T) {
+	// 모두 렌더링하면 항상 참이라 전체 행이 바뀌는 조건들
+	cases := map[string][]database.Expr{
+		"없음":         nil,
+		"nil만":       {nil, nil},
+		"빈 And":      {database.And()},
+		"nil만 든 And": {database.And(nil, nil)},
+		"빈 NotIn":    {database.NotIn("u_id")},
+		"중첩 And":     {database.And(database.And(), database.NotIn("u_id"))},
+		"참을 포함한 Or":  {database.Or(database.Eq("u_id", "a"), database.And())},
+		"Not(빈 In)":  {database.Not(database.In("u_id"))},
+		"여러 번 Where": {database.And(), database.NotIn("u_state")},
+	}
+
+	repo := newRepo()
+	for name, where := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := repo.UpdateTable("_a_user").Set("u_state", "N").Where(where...).Build(); err == nil {
+				t.Error("All() 없이 UPDATE가 만들어짐")
+			}
+			if _, _, err := repo.DeleteFrom("_a_user").Where(where...).Build(); err == nil {
+				t.Error("All() 없이 DELETE가 만들어짐")
+			}
+
+			if _, _, err := repo.UpdateTable("_a_user").Set("u_state", "N").Where(where...).All().Build(); err != nil {
+				t.Errorf("All() UPDATE: %v", err)
+			}
+			if _, _, err := repo.DeleteFrom("_a_user").Where(where...).All().Build(); err != nil {
+				t.Errorf("All() DELETE: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateDeleteWithCondition(t *testing.T) {
+	repo := newRepo()
+
+	// 빈 필터가 섞여 있어도 실제 조건이 하나라도 있으면 허용
+	where := database.And(database.NotIn("u_state"), database.Eq("u_id", "a"))
+
+	query, args, err := repo.UpdateTable("_a_user").Set("u_state", "N").Where(where).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(query, "WHERE") || len(args) != 2 {
+		t.Errorf("UPDATE = %q %v", query, args)
+	}
+
+	// 항상 거짓인 In은 행을 거르므로 조건으로 본다
+	if _, _, err := repo.DeleteFrom("_a_user").Where(database.In("u_id")).Build(); err != nil {
+		t.Errorf("빈 In DELETE: %v", err)
+	}
+}
+
+func TestUpdateMathRejectsNonFinite(t *testing.T) {
+	repo := newRepo()
+
+	for _, op := range []string{"+NaN", "*Inf", "-inf", "+1e400"} {
+		_, err := repo.UpdateMath(context.Background(), "_a_user", map[string]string{"u_level": op}, "u_id = ?", "a")
+		if err == nil {
+			t.Errorf("%q: 유한하지 않은 피연산자가 허용됨", op)
+		}
+	}
+}
+
+func TestBuildErrorsAreServerFaults(t *testing.T) {
+	repo := newRepo()
+
+	cases := map[string]func() error{
+		"잘못된 테이블": func() error {
+			_, _, err := repo.Select("_a_user; DROP TABLE x").Build()
+			return err
+		},
+		"잘못된 컬럼": func() error {
+			_, _, err := repo.InsertInto("_a_user").Set("u_id`--", "a").Build()
+			return err
+		},
+		"RETURNING 미지원": func() error {
+			_, _, err := repo.InsertInto("_a_user").Set("u_id", "a").Returning("u_no").Build()
+			return err
+		},
+	}
+
+	for name, build := range cases {
+		t.Run(name, func(t *testing.T) {
+			appErr, ok := errors.As(build())
+			if !ok {
+				t.Fatal("AppError가 아님")
+			}
+			// 코드의 잘못이므로 500이고, 식별자는 응답 메시지에 넣지 않는다
+			if appErr.HTTPStatus() != http.StatusInternalServerError {
+				t.Errorf("status = %d, want 500", appErr.HTTPStatus())
+			}
+			if strings.Contains(appErr.Message, "DROP") || strings.Contains(appErr.Message, "--") {
+				t.Errorf("응답 메시지에 식별자가 들어감: %q", appErr.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"gin_starter/pkg/errors"
+	"time"
+)
+
+// withTimeout 쿼리 타임아웃을 적용한 컨텍스트 생성
+// 상위 컨텍스트의 마감 시간이 더 이르면 그대로 따른다
+func (db *DB) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if db.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, db.queryTimeout)
+}
+
+// QueryTimeout 쿼리 1건당 최대 실행 시간 (0이면 제한 없음)
+func (db *DB) QueryTimeout() time.Duration {
+	return db.queryTimeout
+}
+
+// contextError 컨텍스트 마감/취소로 실패한 에러를 전용 에러 코드로 변환
+// 컨텍스트와 무관한 에러면 nil 반환
+func contextError(ctx context.Context, err error) *errors.AppError {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case stderrors.Is(err, context.DeadlineExceeded), ctx.Err() == context.DeadlineExceeded:
+		return errors.ErrQueryTimeout.Wrap(err)
+	case stderrors.Is(err, context.Canceled), ctx.Err() == context.Canceled:
+		return errors.ErrRequestCanceled.Wrap(err)
+	}
+
+	return nil
+}
+
+// Row QueryRow 결과 (Scan 후 쿼리 컨텍스트 해제)
+type Row struct {
+	row    *sql.Row
+	ctx    context.Context
+	cancel context.CancelFunc
+	err    error // 쿼리 조립 실패 등 실행 전 에러
+}
+
+// Scan 결과를 dest에 복사 (sql.ErrNoRows는 그대로 반환)
+func (r *Row) Scan(dest ...interface{}) error {
+	defer r.cancel()
+	if r.err != nil {
+		return r.err
+	}
+
+	err := r.row.Scan(dest...)
+	if ctxErr := contextError(r.ctx, err); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// Err 쿼리 실행 에러 반환
+func (r *Row) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	err := r.row.Err()
+	if ctxErr := contextError(r.ctx, err); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// Rows Query 결과 (Close 시 쿼리 컨텍스트와 prepared statement 참조 해제)
+type Rows struct {
+	*sql.Rows
+	ctx     context.Context
+	cancel  context.CancelFunc
+	release func() // 캐시된 statement 참조 해제 (캐시를 쓰지 않았으면 nil)
+}
+
+// Close 결과 셋을 닫고 쿼리 컨텍스트, statement 참조 해제 (여러 번 호출해도 한 번만 해제)
+func (r *Rows) Close() error {
+	defer r.cancel()
+	err := r.Rows.Close()
+	if r.release != nil {
+		r.release()
+		r.release = nil
+	}
+	return err
+}
+
+// Err 순회 중 발생한 에러 반환 (마감/취소는 전용 에러 코드로 변환)
+func (r *Rows) Err() error {
+	err := r.Rows.Err()
+	if ctxErr := contextError(r.ctx, err); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect 드라이버별 SQL 문법 차이를 추상화
+type Dialect interface {
+	// Name 방언 이름 (mysql, sqlite) - 마이그레이션 디렉토리 이름으로도 사용
+	Name() string
+
+	// DriverName database/sql 드라이버 이름
+	DriverName() string
+
+	// Placeholder n번째(1부터 시작) 바인드 파라미터
+	Placeholder(n int) string
+
+	// QuoteIdent 테이블/컬럼 식별자 인용
+	QuoteIdent(ident string) string
+
+	// SupportsLastInsertID sql.Result.LastInsertId 지원 여부
+	SupportsLastInsertID() bool
+
+	// SupportsReturning INSERT ... RETURNING 지원 여부
+	SupportsReturning() bool
+
+	// UpsertClause INSERT 뒤에 붙는 충돌 시 갱신 구문
+	UpsertClause(conflictColumns, updateColumns []string) string
+
+	// Lock 이름 기반 advisory lock 획득 (마이그레이션 동시 실행 방지)
+	Lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) error
+
+	// Unlock advisory lock 해제
+	Unlock(ctx context.Context, conn *sql.Conn, name string) error
+
+	// ReplicationLag 읽기 복제본의 복제 지연 (복제본이 아니면 0)
+	ReplicationLag(ctx context.Context, db *sql.DB) (time.Duration, error)
+
+	// TableExists 현재 데이터베이스에 테이블이 있는지 확인 (DDL 없이 조회만)
+	TableExists(ctx context.Context, conn *sql.Conn, table string) (bool, error)
+
+	// TransactionalDDL DDL을 트랜잭션 안에서 실행하고 롤백할 수 있는지 (MySQL은 DDL마다 암묵적 커밋)
+	TransactionalDDL() bool
+}
+
+// 지원하는 드라이버 이름
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+)
+
+// DialectFor 드라이버 이름으로 방언 반환
+func DialectFor(driver string) (Dialect, error) {
+	switch strings.ToLower(driver) {
+	case "", DriverMySQL:
+		return mysqlDialect{}, nil
+	case DriverSQLite, "sqlite3":
+		return sqliteDialect{}, nil
+	default:
+		return nil, fmt.Errorf("지원하지 않는 DB 드라이버: %s", driver)
+	}
+}
+
+// Rebind '?' 플레이스홀더를 방언의 플레이스홀더로 변환 (문자열 리터럴 내부는 유지)
+func Rebind(d Dialect, query string) string {
+	if d.Placeholder(1) == "?" {
+		return query
+	}
+
+	var b strings.Builder
+	var quote rune
+	n := 0
+
+	for _, ch := range query {
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+			b.WriteRune(ch)
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+			b.WriteRune(ch)
+		case ch == '?':
+			n++
+			b.WriteString(d.Placeholder(n))
+		default:
+			b.WriteRune(ch)
+		}
+	}
+
+	return b.String()
+}
+
+// quoteWith 식별자 인용 (schema.table 형식 지원, 인용 문자는 이중화)
+func quoteWith(ident string, q string) string {
+	parts := strings.Split(ident, ".")
+	for i, part := range parts {
+		parts[i] = q + strings.ReplaceAll(part, q, q+q) + q
+	}
+	return strings.Join(parts, ".")
+}
+
+// mysqlDialect MySQL / MariaDB
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string               { return DriverMySQL }
+func (mysqlDialect) DriverName() string         { return "mysql" }
+func (mysqlDialect) Placeholder(int) string     { return "?" }
+func (mysqlDialect) QuoteIdent(s string) string { return quoteWith(s, "`") }
+func (mysqlDialect) SupportsLastInsertID() bool { return true }
+func (mysqlDialect) SupportsReturning() bool    { return false }
+func (mysqlDialect) TransactionalDDL() bool     { return false }
+
+func (d mysqlDialect) UpsertClause(conflictColumns, updateColumns []string) string {
+	sets := make([]string, 0, len(updateColumns))
+	for _, col := range updateColumns {
+		quoted := d.QuoteIdent(col)
+		sets = append(sets, fmt.Sprintf("%s = VALUES(%s)", quoted, quoted))
+	}
+
+	// 갱신할 컬럼이 없으면 아무것도 바꾸지 않는 대입으로 충돌만 무시
+	if len(sets) == 0 && len(conflictColumns) > 0 {
+		quoted := d.QuoteIdent(conflictColumns[0])
+		sets = append(sets, fmt.Sprintf("%s = %s", quoted, quoted))
+	}
+	return " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
+
+// Lock GET_LOCK은 초 단위이므로 대기 시간을 올림하고, 1초 미만이면 1초 기다린다 (0초는 바로 실패)
+func (mysqlDialect) Lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) error {
+	seconds := int((timeout + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, seconds).Scan(&acquired); err != nil {
+		return fmt.Errorf("잠금 획득 실패: %w", err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return fmt.Errorf("잠금 대기 시간(%s)을 초과했습니다", timeout)
+	}
+	return nil
+}
+
+func (mysqlDialect) Unlock(ctx context.Context, conn *sql.Conn, name string) error {
+	_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", name)
+	return err
+}
+
+// ReplicationLag SHOW REPLICA STATUS (8.0.22 미만은 SHOW SLAVE STATUS)의 Seconds_Behind 값
+// 복제 스레드가 멈춰 값이 NULL이면 에러 반환
+func (mysqlDialect) ReplicationLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	rows, err := db.QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		if rows, err = db.QueryContext(ctx, "SHOW SLAVE STATUS"); err != nil {
+			return 0, fmt.Errorf("복제 상태 조회 실패: %w", err)
+		}
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if !rows.Next() {
+		// 복제 설정이 없는 서버 (primary를 복제본으로 지정한 경우 등)
+		return 0, rows.Err()
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for i, col := range columns {
+		if col != "Seconds_Behind_Source" && col != "Seconds_Behind_Master" {
+			continue
+		}
+		if !values[i].Valid {
+			return 0, fmt.Errorf("복제가 중지되어 있습니다")
+		}
+		seconds, err := strconv.Atoi(values[i].String)
+		if err != nil {
+			return 0, fmt.Errorf("복제 지연 값 해석 실패: %w", err)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, fmt.Errorf("복제 지연 컬럼을 찾을 수 없습니다")
+}
+
+// TableExists information_schema에서 현재 스키마의 테이블 조회
+func (mysqlDialect) TableExists(ctx context.Context, conn *sql.Conn, table string) (bool, error) {
+	var count int
+	err := conn.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?", table,
+	).Scan(&count)
+	return count > 0, err
+}
+
+// sqliteDialect SQLite (로컬 실행, 테스트용)
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string               { return DriverSQLite }
+func (sqliteDialect) DriverName() string         { return "sqlite" }
+func (sqliteDialect) Placeholder(int) string     { return "?" }
+func (sqliteDialect) QuoteIdent(s string) string { return quoteWith(s, `"`) }
+func (sqliteDialect) SupportsLastInsertID() bool { return true }
+func (sqliteDialect) SupportsReturning() bool    { return true }
+func (sqliteDialect) TransactionalDDL() bool     { return true }
+
+func (d sqliteDialect) UpsertClause(conflictColumns, updateColumns []string) string {
+	conflicts := make([]string, 0, len(conflictColumns))
+	for _, col := range conflictColumns {
+		conflicts = append(conflicts, d.QuoteIdent(col))
+	}
+
+	sets := make([]string, 0, len(updateColumns))
+	for _, col := range updateColumns {
+		quoted := d.QuoteIdent(col)
+		sets = append(sets, fmt.Sprintf("%s = excluded.%s", quoted, quoted))
+	}
+
+	if len(sets) == 0 {
+		return fmt.Sprintf(" ON CONFLICT (%s) DO NOTHING", strings.Join(conflicts, ", "))
+	}
+	return fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(conflicts, ", "), strings.Join(sets, ", "))
+}
+
+// Lock SQLite는 단일 파일 DB라 advisory lock이 없음 (쓰기 트랜잭션 자체가 직렬화됨)
+func (sqliteDialect) Lock(context.Context, *sql.Conn, string, time.Duration) error { return nil }
+
+func (sqliteDialect) Unlock(context.Context, *sql.Conn, string) error { return nil }
+
+// ReplicationLag SQLite는 복제가 없으므로 항상 0 (파일 복사본을 복제본으로 쓰는 경우)
+func (sqliteDialect) ReplicationLag(context.Context, *sql.DB) (time.Duration, error) { return 0, nil }
+
+// TableExists sqlite_master에서 테이블 조회
+func (sqliteDialect) TableExists(ctx context.Context, conn *sql.Conn, table string) (bool, error) {
+	var count int
+	err := conn.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table,
+	).Scan(&count)
+	return count > 0, err
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"database/sql"
+	"gin_starter/pkg/metrics"
+)
+
+// poolStats 커넥션 풀 이름과 통계
+type poolStats struct {
+	name  string
+	stats sql.DBStats
+	stmts StmtCacheStats
+}
+
+// Collect 커넥션 풀(primary, 복제본)과 statement 캐시 지표 기록 (metrics.Collector 구현)
+func (db *DB) Collect(w *metrics.Writer) {
+	pools := []poolStats{{name: "primary", stats: db.Stats(), stmts: db.StmtCacheStats()}}
+	for _, r := range db.replicas.list() {
+		var stmts StmtCacheStats
+		if r.stmts != nil {
+			stmts = r.stmts.stats()
+		}
+		pools = append(pools, poolStats{name: r.name, stats: r.db.Stats(), stmts: stmts})
+	}
+
+	gauges := []struct {
+		name, help string
+		value      func(p poolStats) float64
+	}{
+		{"db_max_open_connections", "최대 커넥션 수", func(p poolStats) float64 { return float64(p.stats.MaxOpenConnections) }},
+		{"db_open_connections", "열린 커넥션 수 (사용 중 + 유휴)", func(p poolStats) float64 { return float64(p.stats.OpenConnections) }},
+		{"db_in_use_connections", "사용 중인 커넥션 수", func(p poolStats) float64 { return float64(p.stats.InUse) }},
+		{"db_idle_connections", "유휴 커넥션 수", func(p poolStats) float64 { return float64(p.stats.Idle) }},
+		{"db_stmt_cache_size", "캐시된 prepared statement 수", func(p poolStats) float64 { return float64(p.stmts.Size) }},
+	}
+	counters := []struct {
+		name, help string
+		value      func(p poolStats) float64
+	}{
+		{"db_wait_count_total", "커넥션을 기다린 횟수", func(p poolStats) float64 { return float64(p.stats.WaitCount) }},
+		{"db_wait_duration_seconds_total", "커넥션을 기다린 누적 시간 (초)", func(p poolStats) float64 { return p.stats.WaitDuration.Seconds() }},
+		{"db_max_idle_closed_total", "유휴 커넥션 한도로 닫힌 커넥션 수", func(p poolStats) float64 { return float64(p.stats.MaxIdleClosed) }},
+		{"db_max_idle_time_closed_total", "유휴 시간 초과로 닫힌 커넥션 수", func(p poolStats) float64 { return float64(p.stats.MaxIdleTimeClosed) }},
+		{"db_max_lifetime_closed_total", "최대 수명 초과로 닫힌 커넥션 수", func(p poolStats) float64 { return float64(p.stats.MaxLifetimeClosed) }},
+		{"db_stmt_cache_hits_total", "prepared statement 캐시 적중 수", func(p poolStats) float64 { return float64(p.stmts.Hits) }},
+		{"db_stmt_cache_misses_total", "prepared statement 캐시 미적중 수", func(p poolStats) float64 { return float64(p.stmts.Misses) }},
+		{"db_stmt_cache_evictions_total", "prepared statement 캐시 제거 수", func(p poolStats) float64 { return float64(p.stmts.Evictions) }},
+	}
+
+	for _, g := range gauges {
+		w.Header(g.name, g.help, metrics.TypeGauge)
+		for _, p := range pools {
+			w.Sample(g.name, g.value(p), "pool", p.name)
+		}
+	}
+	for _, c := range counters {
+		w.Header(c.name, c.help, metrics.TypeCounter)
+		for _, p := range pools {
+			w.Sample(c.name, c.value(p), "pool", p.name)
+		}
+	}
+
+	replicas := db.ReplicaStatus()
+	if len(replicas) == 0 {
+		return
+	}
+
+	w.Header("db_replica_healthy", "복제본 읽기 가능 여부 (1: 정상)", metrics.TypeGauge)
+	for _, status := range replicas {
+		healthy := 0.0
+		if status.Healthy {
+			healthy = 1
+		}
+		w.Sample("db_replica_healthy", healthy, "pool", status.Name)
+	}
+
+	w.Header("db_replica_lag_seconds", "복제 지연 (초, -1: 알 수 없음)", metrics.TypeGauge)
+	for _, status := range replicas {
+		w.Sample("db_replica_lag_seconds", status.LagSeconds, "pool", status.Name)
+	}
+}
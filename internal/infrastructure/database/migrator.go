@@ -0,0 +1,656 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gin_starter/pkg/logger"
+)
+
+const (
+	// 적용된 마이그레이션 기록 테이블
+	migrationTable = "_schema_migrations"
+
+	// 동시 실행 방지용 advisory lock 이름
+	migrationLockName = "gin_starter_migrate"
+
+	// 마이그레이션 파일 섹션 구분자
+	migrateUpMarker   = "-- +migrate Up"
+	migrateDownMarker = "-- +migrate Down"
+)
+
+// 마이그레이션 파일명 형식: 001_create_user_table.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_\-]+)\.sql$`)
+
+// Migration 버전별 마이그레이션
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       []string // 적용 SQL 문 목록
+	Down     []string // 롤백 SQL 문 목록
+	Checksum string   // 파일 내용 SHA-256
+}
+
+// MigrationStatus 마이그레이션 적용 상태
+type MigrationStatus struct {
+	Version          int64      `json:"version"`
+	Name             string     `json:"name"`
+	Applied          bool       `json:"applied"`
+	AppliedAt        *time.Time `json:"applied_at,omitempty"`
+	ChecksumMismatch bool       `json:"checksum_mismatch"` // 적용 후 파일이 수정됨
+	Missing          bool       `json:"missing"`           // 적용됐지만 파일이 없음
+}
+
+// appliedMigration 스키마 테이블에 기록된 마이그레이션
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Migrator 버전 기반 SQL 마이그레이션 실행기
+type Migrator struct {
+	db          *DB
+	dir         string
+	LockTimeout time.Duration // advisory lock 대기 시간
+	DryRun      bool          // true면 SQL만 출력하고 실행하지 않음
+	Out         io.Writer     // DryRun 출력 대상
+}
+
+// MigrationsDir 드라이버별 마이그레이션 디렉토리 (예: migrations/mysql)
+func MigrationsDir(root string, db *DB) string {
+	return filepath.Join(root, db.Dialect().Name())
+}
+
+// NewMigrator 마이그레이션 실행기 생성
+func NewMigrator(db *DB, dir string) *Migrator {
+	return &Migrator{
+		db:          db,
+		dir:         dir,
+		LockTimeout: 30 * time.Second,
+		Out:         os.Stdout,
+	}
+}
+
+// Load 마이그레이션 파일 로드 (버전 오름차순)
+func (m *Migrator) Load() ([]*Migration, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, fmt.Errorf("마이그레이션 디렉토리 읽기 실패: %w", err)
+	}
+
+	var migrations []*Migration
+	seen := make(map[int64]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 마이그레이션 버전: %s", entry.Name())
+		}
+		if prev, ok := seen[version]; ok {
+			return nil, fmt.Errorf("중복된 마이그레이션 버전 %d: %s, %s", version, prev, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		content, err := os.ReadFile(filepath.Join(m.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("마이그레이션 파일 읽기 실패 (%s): %w", entry.Name(), err)
+		}
+
+		up, down := splitMigrationSections(string(content))
+		sum := sha256.Sum256(content)
+
+		migrations = append(migrations, &Migration{
+			Version:  version,
+			Name:     matches[2],
+			Up:       splitStatements(up),
+			Down:     splitStatements(down),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Status 전체 마이그레이션 상태 조회
+// 조회만 하므로 스키마 테이블이 없으면 만들지 않고 모두 미적용으로 보고한다
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := m.currentApplied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildStatus(migrations, applied), nil
+}
+
+// CurrentVersion 마지막으로 적용된 버전 (없으면 0)
+// 스키마 테이블이 없으면 만들지 않고 0
+func (m *Migrator) CurrentVersion(ctx context.Context) (int64, error) {
+	applied, err := m.currentApplied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var current int64
+	for version := range applied {
+		if version > current {
+			current = version
+		}
+	}
+	return current, nil
+}
+
+// HealthChecker 파일의 모든 마이그레이션이 적용되어 있는지 확인하는 검사 생성 (readiness 검사용)
+// 파일 목록과 체크섬은 생성 시 한 번만 읽고, 검사 때는 스키마 테이블을 조회만 한다 (테이블을 만들지 않음)
+// 스키마 테이블이 없거나 미적용 마이그레이션이 있거나 적용 후 파일이 수정되었으면 에러
+func (m *Migrator) HealthChecker() (func(ctx context.Context) error, error) {
+	migrations, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		conn, err := m.db.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("커넥션 획득 실패: %w", err)
+		}
+		defer conn.Close()
+
+		applied, exists, err := m.appliedIfExists(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("마이그레이션이 적용되지 않았습니다 (%s 테이블 없음)", migrationTable)
+		}
+
+		var pending, mismatched int
+		var current int64
+		for _, status := range buildStatus(migrations, applied) {
+			switch {
+			case !status.Applied:
+				pending++
+			case status.ChecksumMismatch:
+				mismatched++
+			}
+			if status.Applied && status.Version > current {
+				current = status.Version
+			}
+		}
+
+		if pending > 0 {
+			return fmt.Errorf("미적용 마이그레이션 %d개 (현재 버전: %d)", pending, current)
+		}
+		if mismatched > 0 {
+			return fmt.Errorf("적용 후 수정된 마이그레이션 %d개 (현재 버전: %d)", mismatched, current)
+		}
+		return nil
+	}, nil
+}
+
+// Up 미적용 마이그레이션 전체 적용
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	return m.To(ctx, -1)
+}
+
+// Down 최근 적용된 마이그레이션부터 steps개 롤백
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps < 1 {
+		return 0, fmt.Errorf("롤백 단계는 1 이상이어야 합니다")
+	}
+
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		migrations, applied, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+// To 지정한 버전까지 적용 또는 롤백 (version < 0 이면 최신 버전까지 적용)
+func (m *Migrator) To(ctx context.Context, version int64) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		migrations, applied, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if version >= 0 {
+			if _, ok := findMigration(migrations, version); !ok && version != 0 {
+				return fmt.Errorf("존재하지 않는 마이그레이션 버전: %d", version)
+			}
+		}
+
+		// 롤백: 목표 버전보다 큰 적용 버전을 역순으로 되돌림
+		if version >= 0 {
+			for i := len(migrations) - 1; i >= 0; i-- {
+				migration := migrations[i]
+				if migration.Version <= version {
+					break
+				}
+				if _, ok := applied[migration.Version]; !ok {
+					continue
+				}
+				if err := m.revert(ctx, conn, migration); err != nil {
+					return err
+				}
+				count++
+			}
+		}
+
+		// 적용: 목표 버전 이하의 미적용 버전을 순서대로 적용
+		for _, migration := range migrations {
+			if version >= 0 && migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+// prepare 스키마 테이블 준비, 파일 로드 및 체크섬 검증
+// DryRun이면 스키마 테이블을 만들지 않는다 (없으면 적용된 마이그레이션 없음으로 본다)
+func (m *Migrator) prepare(ctx context.Context, conn *sql.Conn) ([]*Migration, map[int64]appliedMigration, error) {
+	migrations, err := m.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var applied map[int64]appliedMigration
+	if m.DryRun {
+		applied, _, err = m.appliedIfExists(ctx, conn)
+	} else {
+		if err := m.ensureTable(ctx, conn); err != nil {
+			return nil, nil, err
+		}
+		applied, err = m.applied(ctx, conn)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 이미 적용된 파일이 수정되었는지 확인
+	for _, status := range buildStatus(migrations, applied) {
+		if status.ChecksumMismatch {
+			return nil, nil, fmt.Errorf("적용된 마이그레이션 %d_%s의 체크섬이 일치하지 않습니다 (파일이 수정됨)", status.Version, status.Name)
+		}
+	}
+
+	return migrations, applied, nil
+}
+
+// apply 마이그레이션 적용 후 기록 (트랜잭션 DDL을 지원하면 한 트랜잭션으로)
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	logger.Info("마이그레이션 적용: %d_%s", migration.Version, migration.Name)
+
+	record := fmt.Sprintf("INSERT INTO %s (sm_version, sm_name, sm_checksum, sm_applied_at) VALUES (?, ?, ?, ?)", migrationTable)
+	if m.DryRun {
+		m.printStatements(migration.Up)
+		fmt.Fprintf(m.Out, "%s; -- %d\n", record, migration.Version)
+		return nil
+	}
+
+	return m.inTx(ctx, conn, func(q querier) error {
+		if err := m.execStatements(ctx, q, migration, migration.Up); err != nil {
+			return err
+		}
+		if _, err := q.ExecContext(ctx, record, migration.Version, migration.Name, migration.Checksum, time.Now()); err != nil {
+			return fmt.Errorf("마이그레이션 기록 실패 (%d): %w", migration.Version, err)
+		}
+		return nil
+	})
+}
+
+// revert 마이그레이션 롤백 후 기록 삭제 (트랜잭션 DDL을 지원하면 한 트랜잭션으로)
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	if len(migration.Down) == 0 {
+		return fmt.Errorf("마이그레이션 %d_%s에 Down 섹션이 없습니다", migration.Version, migration.Name)
+	}
+
+	logger.Info("마이그레이션 롤백: %d_%s", migration.Version, migration.Name)
+
+	record := fmt.Sprintf("DELETE FROM %s WHERE sm_version = ?", migrationTable)
+	if m.DryRun {
+		m.printStatements(migration.Down)
+		fmt.Fprintf(m.Out, "%s; -- %d\n", record, migration.Version)
+		return nil
+	}
+
+	return m.inTx(ctx, conn, func(q querier) error {
+		if err := m.execStatements(ctx, q, migration, migration.Down); err != nil {
+			return err
+		}
+		if _, err := q.ExecContext(ctx, record, migration.Version); err != nil {
+			return fmt.Errorf("마이그레이션 기록 삭제 실패 (%d): %w", migration.Version, err)
+		}
+		return nil
+	})
+}
+
+// inTx 방언이 트랜잭션 DDL을 지원하면 트랜잭션 안에서 실행 (실패 시 파일 전체와 기록을 롤백)
+// MySQL은 DDL이 암묵적으로 커밋되므로 커넥션에서 바로 실행한다 (중간에 실패하면 일부만 적용될 수 있음)
+func (m *Migrator) inTx(ctx context.Context, conn *sql.Conn, fn func(q querier) error) error {
+	if !m.db.Dialect().TransactionalDDL() {
+		return fn(conn)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("마이그레이션 트랜잭션 시작 실패: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("마이그레이션 트랜잭션 커밋 실패: %w", err)
+	}
+	return nil
+}
+
+// execStatements SQL 문 순차 실행
+func (m *Migrator) execStatements(ctx context.Context, q querier, migration *Migration, statements []string) error {
+	for i, stmt := range statements {
+		if _, err := q.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("마이그레이션 %d_%s 실행 실패 (문장 %d): %w", migration.Version, migration.Name, i+1, err)
+		}
+	}
+	return nil
+}
+
+// printStatements DryRun 출력
+func (m *Migrator) printStatements(statements []string) {
+	for _, stmt := range statements {
+		fmt.Fprintf(m.Out, "%s;\n", stmt)
+	}
+}
+
+// withLock advisory lock을 잡은 전용 커넥션에서 실행 (방언별 Lock 사용)
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("마이그레이션 커넥션 획득 실패: %w", err)
+	}
+	defer conn.Close()
+
+	dialect := m.db.Dialect()
+	if err := dialect.Lock(ctx, conn, migrationLockName, m.LockTimeout); err != nil {
+		return fmt.Errorf("마이그레이션 잠금 실패 (다른 마이그레이션이 실행 중일 수 있습니다): %w", err)
+	}
+
+	defer func() {
+		// 요청 컨텍스트가 취소되어도 잠금은 해제
+		if err := dialect.Unlock(context.Background(), conn, migrationLockName); err != nil {
+			logger.Error("마이그레이션 잠금 해제 실패: %v", err)
+		}
+	}()
+
+	return fn(conn)
+}
+
+// querier 스키마 테이블 조회에 필요한 최소 인터페이스 (*sql.DB, *sql.Conn)
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// ensureTable 스키마 테이블 생성
+func (m *Migrator) ensureTable(ctx context.Context, q querier) error {
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		sm_version BIGINT NOT NULL,
+		sm_name VARCHAR(255) NOT NULL,
+		sm_checksum CHAR(64) NOT NULL,
+		sm_applied_at DATETIME NOT NULL,
+		PRIMARY KEY (sm_version)
+	)`, migrationTable)
+
+	if _, err := q.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("스키마 테이블 생성 실패: %w", err)
+	}
+	return nil
+}
+
+// appliedIfExists 스키마 테이블이 있으면 적용된 마이그레이션 조회 (없으면 빈 목록, 테이블을 만들지 않음)
+func (m *Migrator) appliedIfExists(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, bool, error) {
+	exists, err := m.db.Dialect().TableExists(ctx, conn, migrationTable)
+	if err != nil {
+		return nil, false, fmt.Errorf("스키마 테이블 확인 실패: %w", err)
+	}
+	if !exists {
+		return map[int64]appliedMigration{}, false, nil
+	}
+
+	applied, err := m.applied(ctx, conn)
+	return applied, true, err
+}
+
+// currentApplied 커넥션을 하나 잡아 적용된 마이그레이션 조회 (스키마 테이블이 없으면 빈 목록)
+func (m *Migrator) currentApplied(ctx context.Context) (map[int64]appliedMigration, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("커넥션 획득 실패: %w", err)
+	}
+	defer conn.Close()
+
+	applied, _, err := m.appliedIfExists(ctx, conn)
+	return applied, err
+}
+
+// applied 적용된 마이그레이션 목록 조회
+func (m *Migrator) applied(ctx context.Context, q querier) (map[int64]appliedMigration, error) {
+	query := fmt.Sprintf("SELECT sm_version, sm_name, sm_checksum, sm_applied_at FROM %s ORDER BY sm_version", migrationTable)
+
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("적용된 마이그레이션 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var a appliedMigration
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied[a.Version] = a
+	}
+
+	return applied, rows.Err()
+}
+
+// buildStatus 파일 목록과 적용 기록을 합쳐 상태 생성
+func buildStatus(migrations []*Migration, applied map[int64]appliedMigration) []MigrationStatus {
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	known := make(map[int64]bool, len(migrations))
+
+	for _, migration := range migrations {
+		known[migration.Version] = true
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+
+		if a, ok := applied[migration.Version]; ok {
+			appliedAt := a.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.ChecksumMismatch = a.Checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+
+	// 적용 기록은 있는데 파일이 없는 경우
+	for version, a := range applied {
+		if known[version] {
+			continue
+		}
+		appliedAt := a.AppliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:   version,
+			Name:      a.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses
+}
+
+// findMigration 버전으로 마이그레이션 검색
+func findMigration(migrations []*Migration, version int64) (*Migration, bool) {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return nil, false
+}
+
+// splitMigrationSections 파일 내용을 Up/Down 섹션으로 분리
+// 구분자가 없으면 전체를 Up으로 간주
+func splitMigrationSections(content string) (string, string) {
+	upIdx := strings.Index(content, migrateUpMarker)
+	downIdx := strings.Index(content, migrateDownMarker)
+
+	switch {
+	case upIdx < 0 && downIdx < 0:
+		return content, ""
+	case downIdx < 0:
+		return content[upIdx+len(migrateUpMarker):], ""
+	case upIdx < 0:
+		return content[:downIdx], content[downIdx+len(migrateDownMarker):]
+	case upIdx < downIdx:
+		return content[upIdx+len(migrateUpMarker) : downIdx], content[downIdx+len(migrateDownMarker):]
+	default:
+		return content[upIdx+len(migrateUpMarker):], content[downIdx+len(migrateDownMarker) : upIdx]
+	}
+}
+
+// splitStatements 세미콜론 기준으로 SQL 문 분리 (문자열, 주석 내부의 세미콜론은 무시)
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	var quote rune
+	inLineComment := false
+	inBlockComment := false
+	runes := []rune(script)
+
+	flush := func() {
+		stmt := strings.TrimSpace(current.String())
+		if stmt != "" {
+			statements = append(statements, stmt)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		var next rune
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case inLineComment:
+			if ch == '\n' {
+				inLineComment = false
+				current.WriteRune(ch)
+			}
+			continue
+		case inBlockComment:
+			if ch == '*' && next == '/' {
+				inBlockComment = false
+				i++
+			}
+			continue
+		case quote != 0:
+			current.WriteRune(ch)
+			if ch == '\\' && quote != '`' && next != 0 {
+				current.WriteRune(next)
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case ch == '-' && next == '-':
+			inLineComment = true
+			i++
+		case ch == '#':
+			inLineComment = true
+		case ch == '/' && next == '*':
+			inBlockComment = true
+			i++
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+			current.WriteRune(ch)
+		case ch == ';':
+			flush()
+		default:
+			current.WriteRune(ch)
+		}
+	}
+	flush()
+
+	return statements
+}
@@ -0,0 +1,188 @@
+package database_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gin_starter/internal/config"
+	"gin_starter/internal/infrastructure/database"
+)
+
+// openSQLite 임시 파일 SQLite DB 연결
+func openSQLite(t *testing.T) *database.DB {
+	t.Helper()
+
+	cfg := &config.Config{Database: config.DatabaseConfig{
+		Driver:       "sqlite",
+		Database:     filepath.Join(t.TempDir(), "test.db"),
+		MaxOpenConns: 4,
+		MaxIdleConns: 4,
+	}}
+	db, err := database.Connect(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// writeMigrations 임시 디렉토리에 마이그레이션 파일 작성
+func writeMigrations(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// tableExists 테이블 존재 여부 확인
+func tableExists(t *testing.T, db *database.DB, table string) bool {
+	t.Helper()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	exists, err := db.Dialect().TableExists(ctx, conn, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return exists
+}
+
+func TestMigratorHealthCheckerReadOnly(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	dir := writeMigrations(t, map[string]string{
+		"001_create_items.sql": "-- +migrate Up\nCREATE TABLE items (id INTEGER PRIMARY KEY);\n-- +migrate Down\nDROP TABLE items;\n",
+	})
+
+	migrator := database.NewMigrator(db, dir)
+	check, err := migrator.HealthChecker()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 스키마 테이블이 없으면 만들지 않고 미적용으로 보고
+	if err := check(ctx); err == nil {
+		t.Error("마이그레이션 전인데 검사가 통과함")
+	}
+	if tableExists(t, db, "_schema_migrations") {
+		t.Error("readiness 검사가 스키마 테이블을 생성함")
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := check(ctx); err != nil {
+		t.Errorf("적용 후 검사: %v", err)
+	}
+
+	// 파일은 검사 생성 시 한 번만 읽으므로 이후 디스크 변경은 반영되지 않는다
+	if err := os.Remove(filepath.Join(dir, "001_create_items.sql")); err != nil {
+		t.Fatal(err)
+	}
+	if err := check(ctx); err != nil {
+		t.Errorf("파일 삭제 후 검사: %v", err)
+	}
+}
+
+func TestMigratorRollsBackFailedMigration(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	dir := writeMigrations(t, map[string]string{
+		"001_create_items.sql": "CREATE TABLE items (id INTEGER PRIMARY KEY);\n",
+		// 두 번째 문장이 실패하면 첫 문장의 테이블도 남지 않아야 한다
+		"002_broken.sql": "CREATE TABLE tags (id INTEGER PRIMARY KEY);\nINSERT INTO missing_table VALUES (1);\n",
+	})
+
+	migrator := database.NewMigrator(db, dir)
+	if _, err := migrator.Up(ctx); err == nil {
+		t.Fatal("실패하는 마이그레이션이 적용됨")
+	}
+
+	if tableExists(t, db, "tags") {
+		t.Error("실패한 마이그레이션의 앞 문장이 남음")
+	}
+	version, err := migrator.CurrentVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 1 {
+		t.Errorf("현재 버전 = %d, want 1", version)
+	}
+}
+
+func TestMigratorDryRunCreatesNothing(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	dir := writeMigrations(t, map[string]string{
+		"001_create_items.sql": "CREATE TABLE items (id INTEGER PRIMARY KEY);\n",
+	})
+
+	var out strings.Builder
+	migrator := database.NewMigrator(db, dir)
+	migrator.DryRun = true
+	migrator.Out = &out
+
+	count, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || !strings.Contains(out.String(), "CREATE TABLE items") {
+		t.Errorf("dry-run 결과 %d개, 출력 %q", count, out.String())
+	}
+	if tableExists(t, db, "_schema_migrations") || tableExists(t, db, "items") {
+		t.Error("dry-run이 테이블을 생성함")
+	}
+}
+
+func TestMigratorStatusReadOnly(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	dir := writeMigrations(t, map[string]string{
+		"001_create_items.sql": "CREATE TABLE items (id INTEGER PRIMARY KEY);\n",
+		"002_create_tags.sql":  "CREATE TABLE tags (id INTEGER PRIMARY KEY);\n",
+	})
+
+	migrator := database.NewMigrator(db, dir)
+
+	// 스키마 테이블이 없으면 만들지 않고 적용된 것이 없다고 보고
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || statuses[0].Applied || statuses[1].Applied {
+		t.Errorf("상태 = %+v, want 모두 미적용", statuses)
+	}
+	version, err := migrator.CurrentVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 0 {
+		t.Errorf("현재 버전 = %d, want 0", version)
+	}
+	if tableExists(t, db, "_schema_migrations") {
+		t.Error("상태 조회가 스키마 테이블을 생성함")
+	}
+
+	if _, err := migrator.To(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if statuses, err = migrator.Status(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !statuses[0].Applied || statuses[1].Applied {
+		t.Errorf("상태 = %+v, want 1번만 적용", statuses)
+	}
+}
@@ -9,40 +9,86 @@ import (
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "modernc.org/sqlite"
 )
 
 // DB 데이터베이스 연결 래퍼
 type DB struct {
 	*sql.DB
+	dialect      Dialect
+	queryTimeout time.Duration
+	isolation    sql.IsolationLevel // WithTx 기본 격리 수준
+	stmts        *stmtCache         // prepared statement 캐시 (nil이면 미사용)
+	replicas     *replicaSet        // 읽기 복제본 (nil이면 모든 쿼리를 primary에서 실행)
 }
 
 var instance *DB
 
-// Connect 데이터베이스 연결
+// Connect 데이터베이스 연결 (DB_DRIVER에 따라 MySQL 또는 SQLite, DB_REPLICAS가 있으면 읽기 복제본 포함)
 func Connect(cfg *config.Config) (*DB, error) {
-	dsn := cfg.GetDSN()
+	dialect, err := DialectFor(cfg.Database.Driver)
+	if err != nil {
+		return nil, err
+	}
 
-	db, err := sql.Open("mysql", dsn)
+	isolation, err := ParseIsolationLevel(cfg.Database.TxIsolation)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(dialect.DriverName(), cfg.GetDSN())
 	if err != nil {
 		return nil, fmt.Errorf("데이터베이스 열기 실패: %w", err)
 	}
 
 	// 연결 풀 설정
-	db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
-	db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
-	db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	if cfg.IsInMemoryDB() {
+		// 인메모리 DB는 마지막 커넥션이 닫히면 사라지므로 커넥션 하나를 계속 유지
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxLifetime(0)
+	} else {
+		db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	}
 
 	// 연결 확인
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("데이터베이스 연결 실패: %w", err)
 	}
 
-	instance = &DB{DB: db}
-	logger.Info("✅ MySQL 연결 성공 (호스트: %s, 데이터베이스: %s)", cfg.Database.Host, cfg.Database.Database)
+	replicas, err := openReplicas(cfg, dialect)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	instance = &DB{
+		DB:           db,
+		dialect:      dialect,
+		queryTimeout: cfg.Database.QueryTimeout,
+		isolation:    isolation,
+		stmts:        newStmtCache(db, cfg.Database.StmtCacheSize),
+		replicas:     replicas,
+	}
+	if dialect.Name() == DriverSQLite {
+		logger.Info("✅ SQLite 연결 성공 (데이터베이스: %s)", cfg.Database.Database)
+	} else {
+		logger.Info("✅ MySQL 연결 성공 (호스트: %s, 데이터베이스: %s)", cfg.Database.Host, cfg.Database.Database)
+	}
 
 	return instance, nil
 }
 
+// Dialect 현재 연결의 SQL 방언 반환
+func (db *DB) Dialect() Dialect {
+	if db.dialect == nil {
+		return mysqlDialect{}
+	}
+	return db.dialect
+}
+
 // GetDB 싱글톤 DB 인스턴스 반환
 func GetDB() *DB {
 	return instance
@@ -50,6 +96,8 @@ func GetDB() *DB {
 
 // Close 데이터베이스 연결 종료
 func (db *DB) Close() error {
+	db.replicas.close()
+	db.stmts.reset()
 	if db.DB != nil {
 		return db.DB.Close()
 	}
@@ -62,13 +110,15 @@ func (db *DB) HealthCheck() error {
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
+		// 서버 재시작 등으로 연결이 끊겼을 수 있으므로 prepared statement를 비워 재연결 후 다시 준비
+		db.ResetStatements()
 		return fmt.Errorf("데이터베이스 헬스 체크 실패: %w", err)
 	}
 
 	return nil
 }
 
-// BeginTx 트랜잭션 시작
+// BeginTx 트랜잭션 시작 (여러 리포지토리를 묶을 때는 WithTx 사용)
 func (db *DB) BeginTx() (*sql.Tx, error) {
 	tx, err := db.Begin()
 	if err != nil {
@@ -100,4 +150,4 @@ func RollbackTx(tx *sql.Tx) {
 	if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
 		logger.Error("트랜잭션 롤백 실패: %v", err)
 	}
-}
\ No newline at end of file
+}
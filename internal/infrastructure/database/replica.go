@@ -0,0 +1,256 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"gin_starter/internal/config"
+	"gin_starter/pkg/logger"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReplicaStatus 읽기 복제본 상태 (/health 보고용)
+type ReplicaStatus struct {
+	Name       string    `json:"name"`
+	Healthy    bool      `json:"healthy"`
+	LagSeconds float64   `json:"lag_seconds"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// replica 읽기 복제본 커넥션 풀 (statement 캐시는 풀마다 따로 유지)
+type replica struct {
+	name    string
+	db      *sql.DB
+	stmts   *stmtCache
+	healthy atomic.Bool
+
+	mu     sync.Mutex
+	status ReplicaStatus
+}
+
+// replicaSet 읽기 복제본 목록 (라운드로빈 선택, 주기적 헬스 체크)
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	dialect  Dialect
+	maxLag   time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// openReplicas 설정된 복제본 연결 (없으면 nil)
+// 시작 시점에 응답하지 않는 복제본은 에러 대신 비정상 상태로 두고 헬스 체크가 복구를 감지한다
+func openReplicas(cfg *config.Config, dialect Dialect) (*replicaSet, error) {
+	dsns := cfg.GetReplicaDSNs()
+	if len(dsns) == 0 {
+		return nil, nil
+	}
+
+	set := &replicaSet{
+		dialect: dialect,
+		maxLag:  cfg.Database.ReplicaMaxLag,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	for i, dsn := range dsns {
+		db, err := sql.Open(dialect.DriverName(), dsn)
+		if err != nil {
+			for _, r := range set.replicas {
+				r.db.Close()
+			}
+			return nil, fmt.Errorf("복제본 열기 실패 (replica-%d): %w", i+1, err)
+		}
+
+		db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+
+		set.replicas = append(set.replicas, &replica{
+			name:  fmt.Sprintf("replica-%d", i+1),
+			db:    db,
+			stmts: newStmtCache(db, cfg.Database.StmtCacheSize),
+		})
+	}
+
+	set.checkAll()
+
+	interval := cfg.Database.ReplicaCheckInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	go set.run(interval)
+
+	logger.Info("✅ 읽기 복제본 %d개 연결", len(set.replicas))
+	return set, nil
+}
+
+// list 복제본 목록 (nil이면 빈 목록)
+func (s *replicaSet) list() []*replica {
+	if s == nil {
+		return nil
+	}
+	return s.replicas
+}
+
+// pick 라운드로빈으로 정상 복제본 선택 (정상 복제본이 없으면 nil - primary 사용)
+func (s *replicaSet) pick() *replica {
+	if s == nil || len(s.replicas) == 0 {
+		return nil
+	}
+
+	n := uint64(len(s.replicas))
+	start := s.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := s.replicas[(start+i)%n]; r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// run 주기적으로 복제본 상태 확인 (close 호출 시 종료)
+func (s *replicaSet) run(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.checkAll()
+		}
+	}
+}
+
+// checkAll 모든 복제본 상태 확인
+func (s *replicaSet) checkAll() {
+	for _, r := range s.replicas {
+		s.check(r)
+	}
+}
+
+// check 연결과 복제 지연을 확인해 읽기 대상 여부 갱신
+func (s *replicaSet) check(r *replica) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	status := ReplicaStatus{Name: r.name, LagSeconds: -1, CheckedAt: time.Now()}
+
+	if err := r.db.PingContext(ctx); err != nil {
+		// 재연결 후 다시 prepare하도록 캐시 비움
+		r.stmts.reset()
+		status.Error = err.Error()
+	} else if lag, err := s.dialect.ReplicationLag(ctx, r.db); err != nil {
+		status.Error = err.Error()
+	} else {
+		status.LagSeconds = lag.Seconds()
+		if s.maxLag > 0 && lag > s.maxLag {
+			status.Error = fmt.Sprintf("복제 지연 %s가 허용치 %s를 초과했습니다", lag, s.maxLag)
+		} else {
+			status.Healthy = true
+		}
+	}
+
+	r.mu.Lock()
+	first := r.status.CheckedAt.IsZero()
+	r.status = status
+	r.mu.Unlock()
+
+	was := r.healthy.Swap(status.Healthy)
+	switch {
+	case !status.Healthy && (was || first):
+		logger.Warn("복제본 읽기 제외: %s (%s)", r.name, status.Error)
+	case status.Healthy && !was && !first:
+		logger.Info("복제본 복구: %s", r.name)
+	}
+}
+
+// close 헬스 체크 중지 및 복제본 연결 종료
+func (s *replicaSet) close() {
+	if s == nil {
+		return
+	}
+
+	close(s.stop)
+	<-s.done
+
+	for _, r := range s.replicas {
+		r.stmts.reset()
+		if err := r.db.Close(); err != nil {
+			logger.Error("복제본 종료 실패 (%s): %v", r.name, err)
+		}
+	}
+}
+
+// ReplicaStatus 복제본별 마지막 헬스 체크 결과 (복제본 미사용 시 nil)
+func (db *DB) ReplicaStatus() []ReplicaStatus {
+	replicas := db.replicas.list()
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	statuses := make([]ReplicaStatus, 0, len(replicas))
+	for _, r := range replicas {
+		r.mu.Lock()
+		statuses = append(statuses, r.status)
+		r.mu.Unlock()
+	}
+	return statuses
+}
+
+// routingKey 컨텍스트에 읽기 라우팅 상태를 담는 키
+type routingKey struct{}
+
+// routingState 요청 단위 읽기 라우팅 상태
+type routingState struct {
+	primary atomic.Bool // true면 읽기도 primary에서 실행
+}
+
+// WithReadYourWrites 이 ctx(및 파생 ctx)로 쓰기를 실행하면 이후 읽기를 primary에서 실행
+// 요청마다 한 번 연결해 두면 방금 쓴 데이터를 복제 지연 없이 다시 읽을 수 있다
+func WithReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(routingKey{}).(*routingState); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, routingKey{}, &routingState{})
+}
+
+// UsePrimary 이 ctx의 읽기를 항상 primary에서 실행
+func UsePrimary(ctx context.Context) context.Context {
+	state := &routingState{}
+	state.primary.Store(true)
+	return context.WithValue(ctx, routingKey{}, state)
+}
+
+// markWrite 쓰기 발생 기록 (WithReadYourWrites가 연결된 경우만)
+func markWrite(ctx context.Context) {
+	if state, ok := ctx.Value(routingKey{}).(*routingState); ok {
+		state.primary.Store(true)
+	}
+}
+
+// readsFromPrimary 이 ctx의 읽기를 primary로 보내야 하는지 여부
+func readsFromPrimary(ctx context.Context) bool {
+	state, ok := ctx.Value(routingKey{}).(*routingState)
+	return ok && state.primary.Load()
+}
+
+// isReadQuery 복제본에서 실행해도 되는 조회 쿼리인지 확인
+// INSERT ... RETURNING, 잠금 조회(FOR UPDATE 등)는 primary에서 실행한다
+func isReadQuery(query string) bool {
+	q := strings.ToUpper(strings.TrimLeft(query, " \t\r\n("))
+	if !strings.HasPrefix(q, "SELECT") && !strings.HasPrefix(q, "WITH") {
+		return false
+	}
+	return !strings.Contains(q, "FOR UPDATE") &&
+		!strings.Contains(q, "FOR SHARE") &&
+		!strings.Contains(q, "LOCK IN SHARE MODE")
+}
@@ -1,14 +1,23 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	stderrors "errors"
 	"fmt"
 	"gin_starter/pkg/errors"
 	"gin_starter/pkg/logger"
+	"gin_starter/pkg/trace"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 // Repository 공통 데이터베이스 리포지토리
+// 쿼리는 '?' 플레이스홀더로 작성하고 실행 시 방언에 맞게 변환한다
+// ctx에 WithTx 트랜잭션이 있으면 해당 트랜잭션에서 실행한다
 type Repository struct {
 	db *DB
 }
@@ -18,351 +27,311 @@ func NewRepository(db *DB) *Repository {
 	return &Repository{db: db}
 }
 
-// QueryRow SELECT 단일 행 조회
-func (r *Repository) QueryRow(query string, args ...interface{}) *sql.Row {
-	logger.Debug("SQL Query: %s, Args: %v", query, args)
-	return r.db.QueryRow(query, args...)
+// QueryRow SELECT 단일 행 조회 (Scan 시점까지 쿼리 타임아웃 적용)
+func (r *Repository) QueryRow(ctx context.Context, query string, args ...interface{}) *Row {
+	query = Rebind(r.db.Dialect(), query)
+	logger.FromContext(ctx).Debug("SQL Query: %s, Args: %v", query, args)
+
+	ctx, cancel := r.db.withTimeout(ctx)
+	return &Row{row: r.db.queryRow(ctx, nil, query, args), ctx: ctx, cancel: cancel}
 }
 
-// Query SELECT 다중 행 조회
-func (r *Repository) Query(query string, args ...interface{}) (*sql.Rows, error) {
-	logger.Debug("SQL Query: %s, Args: %v", query, args)
-	rows, err := r.db.Query(query, args...)
+// Query SELECT 다중 행 조회 (Close 시점까지 쿼리 타임아웃 적용)
+func (r *Repository) Query(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	query = Rebind(r.db.Dialect(), query)
+	logger.FromContext(ctx).Debug("SQL Query: %s, Args: %v", query, args)
+
+	ctx, cancel := r.db.withTimeout(ctx)
+	rows, release, err := r.db.query(ctx, nil, query, args)
 	if err != nil {
-		logger.Error("Query 실행 실패: %v", err)
-		r.LogError("Repository.Query", err.Error(), fmt.Sprintf("%s | Args: %v", query, args))
-		return nil, errors.Wrap(err, "DATABASE_ERROR", "쿼리 실행에 실패했습니다")
+		cancel()
+		return nil, r.queryError(ctx, "Repository.Query", err, query, args)
 	}
-	return rows, nil
+	return &Rows{Rows: rows, ctx: ctx, cancel: cancel, release: release}, nil
 }
 
 // Exec INSERT, UPDATE, DELETE 실행
-func (r *Repository) Exec(query string, args ...interface{}) (sql.Result, error) {
-	logger.Debug("SQL Exec: %s, Args: %v", query, args)
-	result, err := r.db.Exec(query, args...)
+func (r *Repository) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query = Rebind(r.db.Dialect(), query)
+	logger.FromContext(ctx).Debug("SQL Exec: %s, Args: %v", query, args)
+
+	ctx, cancel := r.db.withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, nil, query, args)
 	if err != nil {
-		logger.Error("Exec 실행 실패: %v", err)
-		r.LogError("Repository.Exec", err.Error(), fmt.Sprintf("%s | Args: %v", query, args))
-		return nil, errors.Wrap(err, "DATABASE_ERROR", "쿼리 실행에 실패했습니다")
+		return nil, r.queryError(ctx, "Repository.Exec", err, query, args)
 	}
 	return result, nil
 }
 
 // ExecTx 트랜잭션 내에서 INSERT, UPDATE, DELETE 실행
-func (r *Repository) ExecTx(tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
-	logger.Debug("SQL Exec (TX): %s, Args: %v", query, args)
-	result, err := tx.Exec(query, args...)
+func (r *Repository) ExecTx(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
+	query = Rebind(r.db.Dialect(), query)
+	logger.FromContext(ctx).Debug("SQL Exec (TX): %s, Args: %v", query, args)
+
+	ctx, cancel := r.db.withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, tx, query, args)
 	if err != nil {
-		logger.Error("ExecTx 실행 실패: %v", err)
-		r.LogError("Repository.ExecTx", err.Error(), fmt.Sprintf("%s | Args: %v", query, args))
-		return nil, errors.Wrap(err, "DATABASE_ERROR", "트랜잭션 쿼리 실행에 실패했습니다")
+		return nil, r.queryError(ctx, "Repository.ExecTx", err, query, args)
 	}
 	return result, nil
 }
 
 // QueryRowTx 트랜잭션 내에서 단일 행 조회
-func (r *Repository) QueryRowTx(tx *sql.Tx, query string, args ...interface{}) *sql.Row {
-	logger.Debug("SQL Query (TX): %s, Args: %v", query, args)
-	return tx.QueryRow(query, args...)
+func (r *Repository) QueryRowTx(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) *Row {
+	query = Rebind(r.db.Dialect(), query)
+	logger.FromContext(ctx).Debug("SQL Query (TX): %s, Args: %v", query, args)
+
+	ctx, cancel := r.db.withTimeout(ctx)
+	return &Row{row: r.db.queryRow(ctx, tx, query, args), ctx: ctx, cancel: cancel}
 }
 
-// Insert INSERT 쿼리 실행 및 ID 반환
-func (r *Repository) Insert(table string, data map[string]interface{}) (int64, error) {
-	columns := make([]string, 0, len(data))
-	placeholders := make([]string, 0, len(data))
-	values := make([]interface{}, 0, len(data))
+// queryError 쿼리 실패 로그를 남기고 에러 코드를 붙여 반환
+// 마감 시간 초과는 QUERY_TIMEOUT, 클라이언트 취소는 REQUEST_CANCELED (취소는 DB 로그 생략)
+func (r *Repository) queryError(ctx context.Context, location string, err error, query string, args []interface{}) error {
+	sqlText := fmt.Sprintf("%s | Args: %v", query, args)
+	log := logger.FromContext(ctx)
+
+	if ctxErr := contextError(ctx, err); ctxErr != nil {
+		if ctxErr.Code == errors.ErrQueryTimeout.Code {
+			log.Error("%s 시간 초과: %v", location, err)
+			r.LogError(ctx, location, err.Error(), sqlText)
+		} else {
+			log.Warn("%s 취소됨: %v", location, err)
+		}
+		return ctxErr
+	}
 
-	for col, val := range data {
-		columns = append(columns, col)
-		placeholders = append(placeholders, "?")
-		values = append(values, val)
+	// 연결이 끊긴 경우 재연결 후 다시 prepare하도록 캐시 비움
+	if stderrors.Is(err, driver.ErrBadConn) {
+		r.db.ResetStatements()
 	}
 
-	query := fmt.Sprintf(
-		"INSERT INTO %s (%s) VALUES (%s)",
-		table,
-		strings.Join(columns, ", "),
-		strings.Join(placeholders, ", "),
-	)
+	log.Error("%s 실행 실패: %v", location, err)
+	r.LogError(ctx, location, err.Error(), sqlText)
+	return errors.Wrap(err, "DATABASE_ERROR", "쿼리 실행에 실패했습니다")
+}
 
-	result, err := r.Exec(query, values...)
+// Insert INSERT 쿼리 실행 및 ID 반환 (컬럼은 이름 순으로 정렬)
+func (r *Repository) Insert(ctx context.Context, table string, data map[string]interface{}) (int64, error) {
+	result, err := r.InsertInto(table).Values(data).Exec(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		return 0, errors.Wrap(err, "DATABASE_ERROR", "INSERT ID 조회 실패")
-	}
-
-	return id, nil
+	return lastInsertID(result)
 }
 
 // InsertTx 트랜잭션 내에서 INSERT 실행
-func (r *Repository) InsertTx(tx *sql.Tx, table string, data map[string]interface{}) (int64, error) {
-	columns := make([]string, 0, len(data))
-	placeholders := make([]string, 0, len(data))
-	values := make([]interface{}, 0, len(data))
-
-	for col, val := range data {
-		columns = append(columns, col)
-		placeholders = append(placeholders, "?")
-		values = append(values, val)
-	}
-
-	query := fmt.Sprintf(
-		"INSERT INTO %s (%s) VALUES (%s)",
-		table,
-		strings.Join(columns, ", "),
-		strings.Join(placeholders, ", "),
-	)
-
-	result, err := r.ExecTx(tx, query, values...)
+func (r *Repository) InsertTx(ctx context.Context, tx *sql.Tx, table string, data map[string]interface{}) (int64, error) {
+	query, values, err := r.InsertInto(table).Values(data).Build()
 	if err != nil {
 		return 0, err
 	}
 
-	id, err := result.LastInsertId()
+	result, err := r.ExecTx(ctx, tx, query, values...)
 	if err != nil {
-		return 0, errors.Wrap(err, "DATABASE_ERROR", "INSERT ID 조회 실패")
+		return 0, err
 	}
 
-	return id, nil
+	return lastInsertID(result)
 }
 
 // Update UPDATE 쿼리 실행
-func (r *Repository) Update(table string, data map[string]interface{}, where string, whereArgs ...interface{}) (int64, error) {
-	setClauses := make([]string, 0, len(data))
-	values := make([]interface{}, 0, len(data)+len(whereArgs))
-
-	for col, val := range data {
-		setClauses = append(setClauses, fmt.Sprintf("%s = ?", col))
-		values = append(values, val)
-	}
-
-	values = append(values, whereArgs...)
-
-	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE %s",
-		table,
-		strings.Join(setClauses, ", "),
-		where,
-	)
-
-	result, err := r.Exec(query, values...)
-	if err != nil {
+// where는 '?' 플레이스홀더를 쓰는 고정 문자열만 사용 (사용자 입력은 whereArgs로 바인딩)
+// where가 비어 있으면 에러 (전체 수정은 UpdateTable(...).All() 사용)
+func (r *Repository) Update(ctx context.Context, table string, data map[string]interface{}, where string, whereArgs ...interface{}) (int64, error) {
+	if err := requireWhere(where); err != nil {
 		return 0, err
 	}
-
-	affected, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "DATABASE_ERROR", "영향받은 행 조회 실패")
-	}
-
-	return affected, nil
+	return r.UpdateTable(table).Values(data).Where(Raw(where, whereArgs...)).Exec(ctx)
 }
 
 // UpdateTx 트랜잭션 내에서 UPDATE 실행
-func (r *Repository) UpdateTx(tx *sql.Tx, table string, data map[string]interface{}, where string, whereArgs ...interface{}) (int64, error) {
-	setClauses := make([]string, 0, len(data))
-	values := make([]interface{}, 0, len(data)+len(whereArgs))
-
-	for col, val := range data {
-		setClauses = append(setClauses, fmt.Sprintf("%s = ?", col))
-		values = append(values, val)
-	}
-
-	values = append(values, whereArgs...)
-
-	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE %s",
-		table,
-		strings.Join(setClauses, ", "),
-		where,
-	)
-
-	result, err := r.ExecTx(tx, query, values...)
-	if err != nil {
+func (r *Repository) UpdateTx(ctx context.Context, tx *sql.Tx, table string, data map[string]interface{}, where string, whereArgs ...interface{}) (int64, error) {
+	if err := requireWhere(where); err != nil {
 		return 0, err
 	}
-
-	affected, err := result.RowsAffected()
+	query, values, err := r.UpdateTable(table).Values(data).Where(Raw(where, whereArgs...)).Build()
 	if err != nil {
-		return 0, errors.Wrap(err, "DATABASE_ERROR", "영향받은 행 조회 실패")
+		return 0, err
 	}
 
-	return affected, nil
+	return rowsAffected(r.ExecTx(ctx, tx, query, values...))
 }
 
 // Delete DELETE 쿼리 실행
-func (r *Repository) Delete(table string, where string, whereArgs ...interface{}) (int64, error) {
-	query := fmt.Sprintf("DELETE FROM %s WHERE %s", table, where)
-
-	result, err := r.Exec(query, whereArgs...)
-	if err != nil {
+// where가 비어 있으면 에러 (전체 삭제는 DeleteFrom(...).All() 사용)
+func (r *Repository) Delete(ctx context.Context, table string, where string, whereArgs ...interface{}) (int64, error) {
+	if err := requireWhere(where); err != nil {
 		return 0, err
 	}
-
-	affected, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "DATABASE_ERROR", "영향받은 행 조회 실패")
-	}
-
-	return affected, nil
+	return r.DeleteFrom(table).Where(Raw(where, whereArgs...)).Exec(ctx)
 }
 
 // DeleteTx 트랜잭션 내에서 DELETE 실행
-func (r *Repository) DeleteTx(tx *sql.Tx, table string, where string, whereArgs ...interface{}) (int64, error) {
-	query := fmt.Sprintf("DELETE FROM %s WHERE %s", table, where)
-
-	result, err := r.ExecTx(tx, query, whereArgs...)
-	if err != nil {
+func (r *Repository) DeleteTx(ctx context.Context, tx *sql.Tx, table string, where string, whereArgs ...interface{}) (int64, error) {
+	if err := requireWhere(where); err != nil {
 		return 0, err
 	}
-
-	affected, err := result.RowsAffected()
+	query, values, err := r.DeleteFrom(table).Where(Raw(where, whereArgs...)).Build()
 	if err != nil {
-		return 0, errors.Wrap(err, "DATABASE_ERROR", "영향받은 행 조회 실패")
+		return 0, err
 	}
 
-	return affected, nil
+	return rowsAffected(r.ExecTx(ctx, tx, query, values...))
 }
 
 // Exists 레코드 존재 여부 확인
-func (r *Repository) Exists(table string, where string, whereArgs ...interface{}) (bool, error) {
-	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE %s)", table, where)
+func (r *Repository) Exists(ctx context.Context, table string, where string, whereArgs ...interface{}) (bool, error) {
+	return r.Select(table).Where(Raw(where, whereArgs...)).Exists(ctx)
+}
+
+// Count 레코드 개수 조회
+func (r *Repository) Count(ctx context.Context, table string, where string, whereArgs ...interface{}) (int64, error) {
+	return r.Select(table).Where(Raw(where, whereArgs...)).Count(ctx)
+}
 
-	var exists bool
-	err := r.QueryRow(query, whereArgs...).Scan(&exists)
+// UpdateMath 숫자 필드에 사칙연산 수행 (원자적 업데이트)
+// operations: map[컬럼명]연산 (예: map[string]string{"count": "+1", "price": "*2", "stock": "-5"})
+// 지원 연산자: + (덧셈), - (뺄셈), * (곱셈), / (나눗셈) - 피연산자는 숫자만 허용하며 파라미터로 바인딩
+func (r *Repository) UpdateMath(ctx context.Context, table string, operations map[string]string, where string, whereArgs ...interface{}) (int64, error) {
+	q, err := r.buildUpdateMath(table, operations, where, whereArgs)
 	if err != nil {
-		return false, errors.Wrap(err, "DATABASE_ERROR", "존재 여부 확인 실패")
+		return 0, err
 	}
 
-	return exists, nil
+	return q.Exec(ctx)
 }
 
-// Count 레코드 개수 조회
-func (r *Repository) Count(table string, where string, whereArgs ...interface{}) (int64, error) {
-	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", table, where)
+// UpdateMathTx 트랜잭션 내에서 사칙연산 수행
+func (r *Repository) UpdateMathTx(ctx context.Context, tx *sql.Tx, table string, operations map[string]string, where string, whereArgs ...interface{}) (int64, error) {
+	q, err := r.buildUpdateMath(table, operations, where, whereArgs)
+	if err != nil {
+		return 0, err
+	}
 
-	var count int64
-	err := r.QueryRow(query, whereArgs...).Scan(&count)
+	query, values, err := q.Build()
 	if err != nil {
-		return 0, errors.Wrap(err, "DATABASE_ERROR", "개수 조회 실패")
+		return 0, err
 	}
 
-	return count, nil
+	return rowsAffected(r.ExecTx(ctx, tx, query, values...))
 }
 
-// UpdateMath 숫자 필드에 사칙연산 수행 (원자적 업데이트)
-// operations: map[컬럼명]연산 (예: map[string]string{"count": "+1", "price": "*2", "stock": "-5"})
-// 지원 연산자: + (덧셈), - (뺄셈), * (곱셈), / (나눗셈)
-func (r *Repository) UpdateMath(table string, operations map[string]string, where string, whereArgs ...interface{}) (int64, error) {
-	if len(operations) == 0 {
-		return 0, errors.New("INVALID_PARAM", "연산할 필드가 없습니다")
+// InsertReturning INSERT 후 지정한 ID 컬럼 값 반환
+// RETURNING을 지원하는 방언은 RETURNING을, 아니면 LastInsertId를 사용
+func (r *Repository) InsertReturning(ctx context.Context, table string, data map[string]interface{}, idColumn string) (int64, error) {
+	if !r.db.Dialect().SupportsReturning() {
+		return r.Insert(ctx, table, data)
 	}
 
-	setClauses := make([]string, 0, len(operations))
-	for col, op := range operations {
-		if len(op) < 2 {
-			return 0, errors.New("INVALID_PARAM", fmt.Sprintf("잘못된 연산 형식: %s", op))
-		}
-
-		operator := string(op[0])
-		value := op[1:]
-
-		switch operator {
-		case "+":
-			setClauses = append(setClauses, fmt.Sprintf("%s = %s + %s", col, col, value))
-		case "-":
-			setClauses = append(setClauses, fmt.Sprintf("%s = %s - %s", col, col, value))
-		case "*":
-			setClauses = append(setClauses, fmt.Sprintf("%s = %s * %s", col, col, value))
-		case "/":
-			setClauses = append(setClauses, fmt.Sprintf("%s = %s / %s", col, col, value))
-		default:
-			return 0, errors.New("INVALID_PARAM", fmt.Sprintf("지원하지 않는 연산자: %s", operator))
-		}
+	var id int64
+	if err := r.InsertInto(table).Values(data).Returning(idColumn).QueryRow(ctx).Scan(&id); err != nil {
+		return 0, wrapScanError(err, "쿼리 실행에 실패했습니다")
 	}
 
-	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE %s",
-		table,
-		strings.Join(setClauses, ", "),
-		where,
-	)
+	return id, nil
+}
 
-	result, err := r.Exec(query, whereArgs...)
-	if err != nil {
-		return 0, err
+// Upsert INSERT 하되 conflictColumns가 충돌하면 나머지 컬럼을 갱신
+func (r *Repository) Upsert(ctx context.Context, table string, data map[string]interface{}, conflictColumns ...string) (int64, error) {
+	return rowsAffected(r.InsertInto(table).Values(data).OnConflictUpdate(conflictColumns...).Exec(ctx))
+}
+
+// wrapScanError Scan 에러를 DATABASE_ERROR로 감싸기 (이미 코드가 있는 에러는 유지)
+func wrapScanError(err error, message string) error {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return appErr
 	}
+	return errors.Wrap(err, "DATABASE_ERROR", message)
+}
 
-	affected, err := result.RowsAffected()
+// lastInsertID 실행 결과에서 생성된 ID 추출
+func lastInsertID(result sql.Result) (int64, error) {
+	id, err := result.LastInsertId()
 	if err != nil {
-		return 0, errors.Wrap(err, "DATABASE_ERROR", "영향받은 행 조회 실패")
+		return 0, errors.Wrap(err, "DATABASE_ERROR", "INSERT ID 조회 실패")
 	}
+	return id, nil
+}
 
-	return affected, nil
+// requireWhere 수정, 삭제 헬퍼의 where가 비어 있으면 에러 (빈 조건으로 전체 행이 바뀌지 않도록)
+func requireWhere(where string) error {
+	if strings.TrimSpace(where) == "" {
+		return errors.New("INVALID_PARAM", "수정, 삭제 조건(where)이 비어 있습니다")
+	}
+	return nil
 }
 
-// UpdateMathTx 트랜잭션 내에서 사칙연산 수행
-func (r *Repository) UpdateMathTx(tx *sql.Tx, table string, operations map[string]string, where string, whereArgs ...interface{}) (int64, error) {
+// buildUpdateMath 사칙연산 연산 문자열을 검증해 UPDATE 빌더로 변환
+func (r *Repository) buildUpdateMath(table string, operations map[string]string, where string, whereArgs []interface{}) (*UpdateQuery, error) {
 	if len(operations) == 0 {
-		return 0, errors.New("INVALID_PARAM", "연산할 필드가 없습니다")
+		return nil, errors.New("INVALID_PARAM", "연산할 필드가 없습니다")
+	}
+	if err := requireWhere(where); err != nil {
+		return nil, err
 	}
 
-	setClauses := make([]string, 0, len(operations))
-	for col, op := range operations {
+	q := r.UpdateTable(table).Where(Raw(where, whereArgs...))
+
+	cols := make([]string, 0, len(operations))
+	for col := range operations {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	for _, col := range cols {
+		op := strings.TrimSpace(operations[col])
 		if len(op) < 2 {
-			return 0, errors.New("INVALID_PARAM", fmt.Sprintf("잘못된 연산 형식: %s", op))
+			return nil, errors.New("INVALID_PARAM", fmt.Sprintf("잘못된 연산 형식: %s", op))
 		}
 
-		operator := string(op[0])
-		value := op[1:]
+		operator := op[:1]
+		operand, err := strconv.ParseFloat(strings.TrimSpace(op[1:]), 64)
+		if err != nil || math.IsNaN(operand) || math.IsInf(operand, 0) {
+			return nil, errors.New("INVALID_PARAM", fmt.Sprintf("피연산자는 유한한 숫자여야 합니다: %s", op))
+		}
 
 		switch operator {
-		case "+":
-			setClauses = append(setClauses, fmt.Sprintf("%s = %s + %s", col, col, value))
-		case "-":
-			setClauses = append(setClauses, fmt.Sprintf("%s = %s - %s", col, col, value))
-		case "*":
-			setClauses = append(setClauses, fmt.Sprintf("%s = %s * %s", col, col, value))
+		case "+", "-", "*":
 		case "/":
-			setClauses = append(setClauses, fmt.Sprintf("%s = %s / %s", col, col, value))
+			if operand == 0 {
+				return nil, errors.New("INVALID_PARAM", "0으로 나눌 수 없습니다")
+			}
 		default:
-			return 0, errors.New("INVALID_PARAM", fmt.Sprintf("지원하지 않는 연산자: %s", operator))
+			return nil, errors.New("INVALID_PARAM", fmt.Sprintf("지원하지 않는 연산자: %s", operator))
 		}
-	}
-
-	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE %s",
-		table,
-		strings.Join(setClauses, ", "),
-		where,
-	)
-
-	result, err := r.ExecTx(tx, query, whereArgs...)
-	if err != nil {
-		return 0, err
-	}
 
-	affected, err := result.RowsAffected()
-	if err != nil {
-		return 0, errors.Wrap(err, "DATABASE_ERROR", "영향받은 행 조회 실패")
+		// 정수 피연산자는 정수로 바인딩 (정수 컬럼이 실수로 바뀌지 않도록)
+		if operand == float64(int64(operand)) {
+			q.SetMath(col, operator, int64(operand))
+		} else {
+			q.SetMath(col, operator, operand)
+		}
 	}
 
-	return affected, nil
+	return q, nil
 }
 
-// LogError 에러 로그를 데이터베이스에 저장 (트랜잭션과 무관하게 별도 커넥션 사용)
-func (r *Repository) LogError(location string, message string, sqlQuery string) {
-	query := `INSERT INTO _a_error_logs (el_where, el_message, el_sql) VALUES (?, ?, ?)`
+// LogError 에러 로그를 데이터베이스에 저장 (트랜잭션, 요청 컨텍스트와 무관하게 별도 커넥션 사용)
+// ctx는 요청 ID를 기록하는 데만 사용하며, 요청이 취소되어도 로그는 저장한다
+func (r *Repository) LogError(ctx context.Context, location string, message string, sqlQuery string) {
+	query := `INSERT INTO _a_error_logs (el_where, el_message, el_sql, el_request_id) VALUES (?, ?, ?, ?)`
+	requestID := trace.RequestID(ctx)
+	log := logger.FromContext(ctx)
 
 	// 트랜잭션과 무관하게 별도 커넥션으로 실행
 	go func() {
-		_, err := r.db.Exec(query, location, message, sqlQuery)
+		ctx, cancel := r.db.withTimeout(context.Background())
+		defer cancel()
+
+		_, err := r.db.ExecContext(ctx, query, location, message, sqlQuery, requestID)
 		if err != nil {
-			logger.Error("에러 로그 저장 실패 [%s]: %v", location, err)
+			log.Error("에러 로그 저장 실패 [%s]: %v", location, err)
 		}
 	}()
 }
@@ -0,0 +1,209 @@
+package database
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"gin_starter/pkg/logger"
+	"sync"
+	"sync/atomic"
+)
+
+// StmtCacheStats prepared statement 캐시 통계
+type StmtCacheStats struct {
+	Size      int     `json:"size"`
+	Capacity  int     `json:"capacity"`
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	Evictions uint64  `json:"evictions"`
+	HitRate   float64 `json:"hit_rate"`
+}
+
+// stmtEntry 캐시 항목 (사용 중인 동안은 제거되어도 닫지 않음)
+type stmtEntry struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+// stmtCache SQL 문자열을 키로 하는 prepared statement LRU 캐시
+type stmtCache struct {
+	db       *sql.DB
+	capacity int
+
+	mu    sync.Mutex
+	order *list.List // 앞쪽이 최근 사용
+	items map[string]*list.Element
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// newStmtCache 캐시 생성 (capacity가 0 이하면 nil - 캐시 미사용)
+func newStmtCache(db *sql.DB, capacity int) *stmtCache {
+	if capacity <= 0 {
+		return nil
+	}
+	return &stmtCache{
+		db:       db,
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// lookup 캐시된 statement 반환 (없으면 nil, prepare하지 않음)
+// 사용이 끝나면 반드시 release 호출
+func (c *stmtCache) lookup(query string) *stmtEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[query]
+	if !ok {
+		c.misses.Add(1)
+		return nil
+	}
+
+	entry := el.Value.(*stmtEntry)
+	entry.refs++
+	c.order.MoveToFront(el)
+	c.hits.Add(1)
+	return entry
+}
+
+// acquire 캐시된 statement 반환 (없으면 prepare 후 저장)
+// 사용이 끝나면 반드시 release 호출
+func (c *stmtCache) acquire(ctx context.Context, query string) (*stmtEntry, error) {
+	if entry := c.lookup(query); entry != nil {
+		return entry, nil
+	}
+
+	// prepare는 DB 왕복이 있으므로 잠금 밖에서 수행
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 동시에 같은 쿼리를 prepare한 경우 먼저 저장된 것을 사용
+	if el, ok := c.items[query]; ok {
+		stmt.Close()
+		entry := el.Value.(*stmtEntry)
+		entry.refs++
+		c.order.MoveToFront(el)
+		return entry, nil
+	}
+
+	entry := &stmtEntry{query: query, stmt: stmt, refs: 1}
+	c.items[query] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.capacity {
+		c.removeLocked(c.order.Back())
+		c.evictions.Add(1)
+	}
+
+	return entry, nil
+}
+
+// release 사용 완료 표시 (제거된 항목은 마지막 사용자가 닫음)
+func (c *stmtCache) release(entry *stmtEntry) {
+	c.mu.Lock()
+	entry.refs--
+	closeNow := entry.evicted && entry.refs == 0
+	c.mu.Unlock()
+
+	if closeNow {
+		entry.stmt.Close()
+	}
+}
+
+// removeLocked 항목 제거 (c.mu 보유 상태에서 호출)
+func (c *stmtCache) removeLocked(el *list.Element) {
+	entry := el.Value.(*stmtEntry)
+	c.order.Remove(el)
+	delete(c.items, entry.query)
+
+	entry.evicted = true
+	if entry.refs == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// reset 모든 statement 제거 (재연결, 연결 끊김 시)
+func (c *stmtCache) reset() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.order.Len() > 0 {
+		c.removeLocked(c.order.Back())
+	}
+}
+
+// stats 현재 통계
+func (c *stmtCache) stats() StmtCacheStats {
+	c.mu.Lock()
+	size := c.order.Len()
+	c.mu.Unlock()
+
+	hits, misses := c.hits.Load(), c.misses.Load()
+	stats := StmtCacheStats{
+		Size:      size,
+		Capacity:  c.capacity,
+		Hits:      hits,
+		Misses:    misses,
+		Evictions: c.evictions.Load(),
+	}
+	if total := hits + misses; total > 0 {
+		stats.HitRate = float64(hits) / float64(total)
+	}
+	return stats
+}
+
+// prepared 쿼리에 맞는 prepared statement 반환 (트랜잭션이 있으면 tx.Stmt로 재바인딩)
+// 캐시를 쓰지 않거나 prepare에 실패하면 nil을 반환하므로 호출자는 일반 실행으로 대체한다
+func (c *stmtCache) prepared(ctx context.Context, tx *sql.Tx, query string) (*sql.Stmt, func()) {
+	if c == nil {
+		return nil, nil
+	}
+
+	if tx != nil {
+		// 트랜잭션이 커넥션을 잡고 있으므로 풀에서 새로 prepare하지 않고 캐시에 있을 때만 재바인딩
+		// (트랜잭션 전용 statement는 커밋/롤백 시 자동으로 닫힘)
+		entry := c.lookup(query)
+		if entry == nil {
+			return nil, nil
+		}
+		return tx.StmtContext(ctx, entry.stmt), func() { c.release(entry) }
+	}
+
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		logger.Debug("prepare 실패, 일반 실행으로 대체: %v", err)
+		return nil, nil
+	}
+	return entry.stmt, func() { c.release(entry) }
+}
+
+// StmtCacheStats prepared statement 캐시 통계 (캐시 미사용 시 빈 값)
+func (db *DB) StmtCacheStats() StmtCacheStats {
+	if db.stmts == nil {
+		return StmtCacheStats{}
+	}
+	return db.stmts.stats()
+}
+
+// ResetStatements 캐시된 prepared statement 모두 닫기 (복제본 포함)
+func (db *DB) ResetStatements() {
+	db.stmts.reset()
+	for _, r := range db.replicas.list() {
+		r.stmts.reset()
+	}
+}
@@ -0,0 +1,225 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"strings"
+)
+
+// txKey 컨텍스트에 트랜잭션을 담는 키
+type txKey struct{}
+
+// txState 진행 중인 트랜잭션과 세이브포인트 깊이
+type txState struct {
+	tx    *sql.Tx
+	depth int
+}
+
+// TxOption 트랜잭션 옵션 설정 함수
+type TxOption func(*sql.TxOptions)
+
+// WithIsolation 트랜잭션 격리 수준 지정 (미지정 시 DB_TX_ISOLATION 설정값)
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.Isolation = level
+	}
+}
+
+// ReadOnly 읽기 전용 트랜잭션
+func ReadOnly() TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.ReadOnly = true
+	}
+}
+
+// TxFromContext 컨텍스트에 묶인 트랜잭션 반환
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// WithTx fn을 하나의 트랜잭션(작업 단위)으로 실행
+// fn에 전달되는 ctx에 트랜잭션이 담겨 있어 Repository 호출은 자동으로 같은 트랜잭션에서 실행된다.
+// fn이 에러를 반환하거나 패닉이 발생하면 롤백하고, 아니면 커밋한다.
+// 이미 트랜잭션 안이면 세이브포인트를 만들어 fn 실패 시 해당 지점까지만 롤백한다 (옵션은 무시).
+func (db *DB) WithTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) (err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return db.withSavepoint(ctx, state, fn)
+	}
+
+	txOpts := &sql.TxOptions{Isolation: db.isolation}
+	for _, opt := range opts {
+		opt(txOpts)
+	}
+
+	tx, err := db.DB.BeginTx(ctx, txOpts)
+	if err != nil {
+		logger.Error("트랜잭션 시작 실패: %v", err)
+		if ctxErr := contextError(ctx, err); ctxErr != nil {
+			return ctxErr
+		}
+		return errors.Wrap(err, "TX_BEGIN_FAILED", "트랜잭션 시작에 실패했습니다")
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			RollbackTx(tx)
+			panic(p)
+		}
+		if err != nil {
+			RollbackTx(tx)
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			logger.Error("트랜잭션 커밋 실패: %v", commitErr)
+			err = errors.Wrap(commitErr, "TX_COMMIT_FAILED", "트랜잭션 커밋에 실패했습니다")
+		}
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, &txState{tx: tx}))
+}
+
+// withSavepoint 진행 중인 트랜잭션 안에서 세이브포인트로 fn 실행
+func (db *DB) withSavepoint(ctx context.Context, state *txState, fn func(ctx context.Context) error) (err error) {
+	state.depth++
+	name := fmt.Sprintf("sp_%d", state.depth)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		state.depth--
+		logger.Error("세이브포인트 생성 실패 (%s): %v", name, err)
+		return errors.Wrap(err, "TX_SAVEPOINT_FAILED", "세이브포인트 생성에 실패했습니다")
+	}
+
+	defer func() {
+		defer func() { state.depth-- }()
+
+		if p := recover(); p != nil {
+			db.rollbackTo(ctx, state.tx, name)
+			panic(p)
+		}
+		if err != nil {
+			db.rollbackTo(ctx, state.tx, name)
+			return
+		}
+		if _, releaseErr := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); releaseErr != nil {
+			logger.Error("세이브포인트 해제 실패 (%s): %v", name, releaseErr)
+			err = errors.Wrap(releaseErr, "TX_SAVEPOINT_FAILED", "세이브포인트 해제에 실패했습니다")
+		}
+	}()
+
+	return fn(ctx)
+}
+
+// rollbackTo 세이브포인트까지 롤백 (실패는 로그만 남김, 바깥 트랜잭션이 최종 롤백)
+func (db *DB) rollbackTo(ctx context.Context, tx *sql.Tx, name string) {
+	if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); err != nil {
+		logger.Error("세이브포인트 롤백 실패 (%s): %v", name, err)
+	}
+}
+
+// ParseIsolationLevel 설정 문자열을 격리 수준으로 변환
+// 빈 값/default는 드라이버 기본값 (MySQL InnoDB: REPEATABLE READ)
+func ParseIsolationLevel(level string) (sql.IsolationLevel, error) {
+	switch strings.ToLower(strings.NewReplacer("-", "_", " ", "_").Replace(level)) {
+	case "", "default":
+		return sql.LevelDefault, nil
+	case "read_uncommitted":
+		return sql.LevelReadUncommitted, nil
+	case "read_committed":
+		return sql.LevelReadCommitted, nil
+	case "repeatable_read":
+		return sql.LevelRepeatableRead, nil
+	case "serializable":
+		return sql.LevelSerializable, nil
+	default:
+		return sql.LevelDefault, fmt.Errorf("지원하지 않는 트랜잭션 격리 수준: %s", level)
+	}
+}
+
+// executor *sql.DB와 *sql.Tx 공통 실행 메서드
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// bindTx 명시한 트랜잭션이 없으면 컨텍스트의 트랜잭션 사용
+func bindTx(ctx context.Context, tx *sql.Tx) *sql.Tx {
+	if tx != nil {
+		return tx
+	}
+	tx, _ = TxFromContext(ctx)
+	return tx
+}
+
+// route 쿼리를 실행할 대상과 statement 캐시 선택
+// 트랜잭션 안이면 해당 트랜잭션, 조회 쿼리는 정상 복제본(라운드로빈), 나머지는 primary
+// 쓰기 쿼리는 이후 같은 요청의 읽기를 primary로 고정한다 (WithReadYourWrites)
+func (db *DB) route(ctx context.Context, tx *sql.Tx, query string) (executor, *stmtCache) {
+	read := isReadQuery(query)
+	if !read {
+		markWrite(ctx)
+	}
+
+	if tx != nil {
+		return tx, db.stmts
+	}
+	if read && !readsFromPrimary(ctx) {
+		if r := db.replicas.pick(); r != nil {
+			return r.db, r.stmts
+		}
+	}
+	return db.DB, db.stmts
+}
+
+// query 다중 행 조회 (캐시된 prepared statement 우선 사용)
+// 결과 셋을 읽는 동안 statement가 캐시에서 제거되어 닫히지 않도록, 반환한 release는 rows를 닫을 때 호출한다
+func (db *DB) query(ctx context.Context, tx *sql.Tx, query string, args []interface{}) (*sql.Rows, func(), error) {
+	tx = bindTx(ctx, tx)
+	target, stmts := db.route(ctx, tx, query)
+	if stmt, release := stmts.prepared(ctx, tx, query); stmt != nil {
+		rows, err := stmt.QueryContext(ctx, args...)
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+		return rows, release, nil
+	}
+	rows, err := target.QueryContext(ctx, query, args...)
+	return rows, nil, err
+}
+
+// queryRow 단일 행 조회 (캐시된 prepared statement 우선 사용)
+func (db *DB) queryRow(ctx context.Context, tx *sql.Tx, query string, args []interface{}) *sql.Row {
+	tx = bindTx(ctx, tx)
+	target, stmts := db.route(ctx, tx, query)
+	if stmt, release := stmts.prepared(ctx, tx, query); stmt != nil {
+		defer release()
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	return target.QueryRowContext(ctx, query, args...)
+}
+
+// exec INSERT, UPDATE, DELETE 실행 (캐시된 prepared statement 우선 사용)
+func (db *DB) exec(ctx context.Context, tx *sql.Tx, query string, args []interface{}) (sql.Result, error) {
+	tx = bindTx(ctx, tx)
+	target, stmts := db.route(ctx, tx, query)
+	if stmt, release := stmts.prepared(ctx, tx, query); stmt != nil {
+		defer release()
+		return stmt.ExecContext(ctx, args...)
+	}
+	return target.ExecContext(ctx, query, args...)
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyPrefix API 키 접두사 (Authorization: Bearer 값이 이것으로 시작하면 JWT 대신 API 키로 인증)
+const APIKeyPrefix = "gsk_"
+
+// APIKeyHeader API 키 전용 헤더 (Authorization 헤더가 없을 때만 사용)
+const APIKeyHeader = "X-API-Key"
+
+// APIKey 인증된 API 키 정보
+type APIKey struct {
+	ID     string
+	UserID string   // 키 소유자 (권한은 소유자의 역할에서 가져옴)
+	Scopes []string // 사용 범위 권한 (소유자 권한 중 이 범위 안의 것만 사용)
+}
+
+// APIKeyVerifier API 키 확인 함수 (key: 요청의 키 원문, ip: 접속 IP)
+// 키가 없거나 폐기, 만료되었거나 허용하지 않은 IP면 에러를 돌려준다
+type APIKeyVerifier func(ctx context.Context, key, ip string) (*APIKey, error)
+
+// apiKeyCredential 요청에 담긴 API 키 (Authorization: Bearer gsk_..., 또는 X-API-Key)
+func apiKeyCredential(c *gin.Context) (string, bool) {
+	if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+		token, ok := strings.CutPrefix(authHeader, "Bearer ")
+		if ok && strings.HasPrefix(token, APIKeyPrefix) {
+			return token, true
+		}
+		return "", false
+	}
+
+	if key := c.GetHeader(APIKeyHeader); key != "" {
+		return key, true
+	}
+	return "", false
+}
+
+// apiKeyScopes API 키로 인증한 요청의 사용 범위 (JWT 인증이면 false)
+func apiKeyScopes(c *gin.Context) ([]string, bool) {
+	value, ok := c.Get("api_key_scopes")
+	if !ok {
+		return nil, false
+	}
+	scopes, _ := value.([]string)
+	return scopes, true
+}
@@ -3,12 +3,17 @@ package middleware
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"gin_starter/internal/config"
 	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/rbac"
 	"gin_starter/pkg/response"
+	"gin_starter/pkg/revocation"
 	"strings"
 	"time"
 
@@ -18,55 +23,190 @@ import (
 
 // Claims JWT 클레임 구조
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID        string `json:"user_id"`
+	SessionID     string `json:"sid,omitempty"`    // 로그인 세션 ID (기기별)
+	IssuedAtMilli int64  `json:"iat_ms,omitempty"` // 발급 시각 (밀리초, 폐기 시각과 비교)
 	jwt.RegisteredClaims
 }
 
 // EncryptedClaims 암호화된 클레임
 type EncryptedClaims struct {
-	Data string `json:"data"`
+	Data          string `json:"data"`
+	DataKeyID     string `json:"ekid,omitempty"`   // Data를 암호화한 키 ID (서명 키 ID는 헤더의 kid)
+	IssuedAtMilli int64  `json:"iat_ms,omitempty"` // 발급 시각 (밀리초, iat는 초 단위라 같은 초의 폐기와 구분할 수 없음)
 	jwt.RegisteredClaims
 }
 
-// AuthMiddleware JWT 인증 미들웨어
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+// tokenPayload 토큰에 암호화해 넣는 내용
+type tokenPayload struct {
+	UserID    string `json:"user_id"`
+	SessionID string `json:"sid,omitempty"`
+}
+
+// AuthMiddleware 인증 미들웨어 (JWT 액세스 토큰 또는 API 키)
+// principals가 주어지면 사용자 타입/레벨(user_type, user_level), 역할과 권한(roles, permissions)도 컨텍스트에 저장한다
+// revoked가 주어지면 로그아웃 등으로 폐기된 토큰을 만료 전이라도 거부한다
+// apiKeys가 주어지면 API 키(Authorization: Bearer gsk_..., X-API-Key)도 받고, nil이면 API 키 요청은 거부한다
+func AuthMiddleware(cfg *config.Config, principals *PrincipalCache, revoked *revocation.List, apiKeys APIKeyVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			response.Unauthorized(c, "인증 토큰이 필요합니다")
-			c.Abort()
-			return
+		var userID string
+		if key, ok := apiKeyCredential(c); ok {
+			apiKey, ok := authenticateAPIKey(c, apiKeys, key)
+			if !ok {
+				c.Abort()
+				return
+			}
+			userID = apiKey.UserID
+		} else {
+			claims, ok := authenticateToken(c, cfg, revoked)
+			if !ok {
+				c.Abort()
+				return
+			}
+			userID = claims.UserID
 		}
 
-		// Bearer 토큰 파싱
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			response.Unauthorized(c, "잘못된 토큰 형식입니다")
-			c.Abort()
-			return
+		// 컨텍스트에 사용자 정보 저장 (이후 요청 로그에 user_id 포함)
+		c.Set("user_id", userID)
+		c.Request = c.Request.WithContext(logger.WithContextFields(c.Request.Context(), logger.String("user_id", userID)))
+
+		// 권한 정보 저장
+		if principals != nil {
+			principal, err := principals.Get(c.Request.Context(), userID)
+			if err != nil {
+				if errors.Is(err, errors.ErrUserNotFound) {
+					response.Unauthorized(c, "사용자를 찾을 수 없습니다")
+				} else {
+					response.FromError(c, err)
+				}
+				c.Abort()
+				return
+			}
+
+			c.Set("user_type", principal.AuthType)
+			c.Set("user_level", principal.AuthLevel)
+			c.Set("roles", principal.Roles)
+			c.Set("permissions", principal.Permissions)
+			c.Set("email_verified", principal.EmailVerified)
+			c.Set("mfa_enabled", principal.MFAEnabled)
 		}
 
-		tokenString := parts[1]
+		c.Next()
+	}
+}
+
+// authenticateToken Bearer JWT 액세스 토큰 검증 (실패하면 응답을 쓰고 false)
+func authenticateToken(c *gin.Context, cfg *config.Config, revoked *revocation.List) (*Claims, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		response.Unauthorized(c, "인증 토큰이 필요합니다")
+		return nil, false
+	}
 
-		// 토큰 검증
-		claims, err := ValidateToken(tokenString, cfg.JWT.AccessSecret, cfg.JWT.TokenSecret)
-		if err != nil {
-			if errors.Is(err, errors.ErrExpiredToken) {
-				response.TokenExpired(c)
-			} else {
-				response.TokenInvalid(c)
-			}
-			c.Abort()
+	// Bearer 토큰 파싱
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		response.Unauthorized(c, "잘못된 토큰 형식입니다")
+		return nil, false
+	}
+
+	tokenString := parts[1]
+
+	// 토큰 검증
+	claims, err := ValidateToken(tokenString, cfg.JWT.AccessKeys, cfg.JWT.TokenKeys)
+	if err != nil {
+		if errors.Is(err, errors.ErrExpiredToken) {
+			response.TokenExpired(c)
+		} else {
+			response.TokenInvalid(c)
+		}
+		return nil, false
+	}
+
+	// 폐기 목록 확인 (로그아웃, 비밀번호 변경, 권한 변경 등)
+	if revoked.Revoked(c.Request.Context(), revocation.Token{
+		ID:        claims.ID,
+		SessionID: claims.SessionID,
+		UserID:    claims.UserID,
+		IssuedAt:  issuedAt(claims),
+	}) {
+		response.TokenRevoked(c)
+		return nil, false
+	}
+
+	c.Set("session_id", claims.SessionID)
+	c.Set("token_id", claims.ID)
+	c.Set("token_expires_at", claims.ExpiresAt.Time)
+	return claims, true
+}
+
+// authenticateAPIKey API 키 확인 (실패하면 응답을 쓰고 false)
+// 키의 사용 범위(api_key_scopes)를 저장해 RequirePermission, HasPermission이 범위 밖의 권한을 거부하게 한다
+func authenticateAPIKey(c *gin.Context, apiKeys APIKeyVerifier, key string) (*APIKey, bool) {
+	if apiKeys == nil {
+		response.FromError(c, errors.ErrAPIKeyNotAllowed)
+		return nil, false
+	}
+
+	apiKey, err := apiKeys(c.Request.Context(), key, c.ClientIP())
+	if err != nil {
+		// INVALID_API_KEY, API_KEY_EXPIRED (401), API_KEY_IP_DENIED (403)
+		response.FromError(c, err)
+		return nil, false
+	}
+
+	c.Set("api_key_id", apiKey.ID)
+	c.Set("api_key_scopes", apiKey.Scopes)
+	c.Request = c.Request.WithContext(logger.WithContextFields(c.Request.Context(), logger.String("api_key_id", apiKey.ID)))
+	return apiKey, true
+}
+
+// RequirePermission 권한 요구 미들웨어 (AuthMiddleware 뒤에 둔다)
+// 사용자의 역할들에 permission(blog:delete:any 등)이나 이를 포함하는 와일드카드가 부여되어 있어야 통과한다
+// API 키로 인증한 요청은 키의 사용 범위에도 permission이 포함되어야 한다
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if HasPermission(c, permission) {
+			c.Next()
 			return
 		}
 
-		// 컨텍스트에 사용자 정보 저장
-		c.Set("user_id", claims.UserID)
-		c.Next()
+		PermissionDenied(c, permission)
+		c.Abort()
+	}
+}
+
+// PermissionDenied 권한 부족 응답 (사용자에게는 있는 권한이면 API 키 사용 범위 밖이므로 API_KEY_SCOPE)
+func PermissionDenied(c *gin.Context, permission string) {
+	denied := errors.ErrPermissionDenied
+	if userHasPermission(c, permission) {
+		denied = errors.ErrAPIKeyScope
+	}
+	response.FromError(c, denied.WithMeta("permission", permission))
+}
+
+// HasPermission 요청 사용자에게 권한이 있는지 (핸들러에서 다른 사용자의 리소스 접근 허용 판단 등)
+// API 키로 인증한 요청은 사용자 권한과 키의 사용 범위에 모두 포함되어야 한다
+func HasPermission(c *gin.Context, permission string) bool {
+	if !userHasPermission(c, permission) {
+		return false
+	}
+
+	if scopes, ok := apiKeyScopes(c); ok {
+		return rbac.Has(scopes, permission)
 	}
+	return true
+}
+
+// userHasPermission 사용자 역할에 권한이 부여되어 있는지 (API 키 사용 범위는 보지 않음)
+func userHasPermission(c *gin.Context, permission string) bool {
+	value, _ := c.Get("permissions")
+	permissions, _ := value.([]string)
+	return rbac.Has(permissions, permission)
 }
 
 // RequireUserType 특정 사용자 타입 요구 미들웨어
+// 대표 역할(u_auth_type) 하나만 비교하므로 새 라우트는 RequirePermission을 사용한다
 func RequireUserType(userType string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestUserType, exists := c.Get("user_type")
@@ -86,6 +226,34 @@ func RequireUserType(userType string) gin.HandlerFunc {
 	}
 }
 
+// RequireVerifiedEmail 이메일 인증 요구 미들웨어 (AuthMiddleware 뒤에 둔다)
+// access가 full이면 미인증 계정도 통과시킨다 (EMAIL_UNVERIFIED_ACCESS)
+func RequireVerifiedEmail(access string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if access == config.UnverifiedFull || c.GetBool("email_verified") {
+			c.Next()
+			return
+		}
+
+		response.FromError(c, errors.ErrEmailNotVerified)
+		c.Abort()
+	}
+}
+
+// RequireMFA 2단계 인증 사용 요구 미들웨어 (AuthMiddleware 뒤에 둔다)
+// required가 false면 모두 통과시킨다 (MFA_REQUIRED_FOR_ADMIN)
+func RequireMFA(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !required || c.GetBool("mfa_enabled") {
+			c.Next()
+			return
+		}
+
+		response.FromError(c, errors.ErrMFARequired)
+		c.Abort()
+	}
+}
+
 // RequireAuthLevel 최소 권한 레벨 요구 미들웨어
 func RequireAuthLevel(minLevel int) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -108,14 +276,17 @@ func RequireAuthLevel(minLevel int) gin.HandlerFunc {
 }
 
 // GenerateToken JWT 토큰 생성
-func GenerateToken(userID string, expireMinutes int, signingKey, encryptionKey []byte, serviceName string) (string, error) {
+// 서명은 signingKeys, 페이로드 암호화는 encryptionKeys의 primary 키로 하고 키 ID를 토큰에 남긴다
+// sessionID는 사용자 ID와 함께 암호화된 페이로드에 들어간다
+func GenerateToken(userID, sessionID string, expireMinutes int, signingKeys, encryptionKeys config.Keyring, serviceName string) (string, error) {
 	now := time.Now()
+	signingKey := signingKeys.PrimaryKey()
+	encryptionKey := encryptionKeys.PrimaryKey()
 
 	// 페이로드 생성
-	payload := struct {
-		UserID string `json:"user_id"`
-	}{
-		UserID: userID,
+	payload := tokenPayload{
+		UserID:    userID,
+		SessionID: sessionID,
 	}
 
 	raw, err := json.Marshal(payload)
@@ -124,7 +295,7 @@ func GenerateToken(userID string, expireMinutes int, signingKey, encryptionKey [
 	}
 
 	// AES-GCM 암호화
-	cipherBytes, err := encryptAESGCM(encryptionKey, raw)
+	cipherBytes, err := encryptAESGCM(encryptionKey.Secret, raw)
 	if err != nil {
 		return "", err
 	}
@@ -132,10 +303,19 @@ func GenerateToken(userID string, expireMinutes int, signingKey, encryptionKey [
 	// Base64 인코딩
 	dataB64 := base64.RawURLEncoding.EncodeToString(cipherBytes)
 
+	// 토큰 ID (jti - 토큰 하나만 폐기할 때 사용)
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	// JWT 생성
 	claims := EncryptedClaims{
-		Data: dataB64,
+		Data:          dataB64,
+		DataKeyID:     encryptionKey.ID,
+		IssuedAtMilli: now.UnixMilli(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(expireMinutes) * time.Minute)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			Subject:   serviceName,
@@ -143,21 +323,29 @@ func GenerateToken(userID string, expireMinutes int, signingKey, encryptionKey [
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(signingKey)
+	token.Header["kid"] = signingKey.ID
+	return token.SignedString(signingKey.Secret)
 }
 
 // ValidateToken JWT 토큰 검증
-func ValidateToken(tokenStr string, signingKey, encryptionKey []byte) (*Claims, error) {
+// 헤더의 kid, 클레임의 ekid로 키를 고르므로 키 교체 중에도 폐기되지 않은 이전 키로 만든 토큰을 받는다
+func ValidateToken(tokenStr string, signingKeys, encryptionKeys config.Keyring) (*Claims, error) {
 	// JWT 파싱 및 서명 검증
 	token, err := jwt.ParseWithClaims(tokenStr, &EncryptedClaims{}, func(t *jwt.Token) (interface{}, error) {
 		if t.Method != jwt.SigningMethodHS256 {
 			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
 		}
-		return signingKey, nil
+
+		kid, _ := t.Header["kid"].(string)
+		key, err := signingKeys.Lookup(kid)
+		if err != nil {
+			return nil, err
+		}
+		return key.Secret, nil
 	})
 
 	if err != nil {
-		return nil, errors.Wrap(err, "INVALID_TOKEN", "토큰 파싱 실패")
+		return nil, errors.ErrInvalidToken.Wrap(err)
 	}
 
 	encClaims, ok := token.Claims.(*EncryptedClaims)
@@ -173,29 +361,55 @@ func ValidateToken(tokenStr string, signingKey, encryptionKey []byte) (*Claims,
 	// Base64 디코딩
 	cipherBytes, err := base64.RawURLEncoding.DecodeString(encClaims.Data)
 	if err != nil {
-		return nil, errors.Wrap(err, "INVALID_TOKEN", "Base64 디코딩 실패")
+		return nil, errors.ErrInvalidToken.Wrap(err)
 	}
 
 	// AES-GCM 복호화
-	raw, err := decryptAESGCM(encryptionKey, cipherBytes)
+	encryptionKey, err := encryptionKeys.Lookup(encClaims.DataKeyID)
 	if err != nil {
-		return nil, errors.Wrap(err, "INVALID_TOKEN", "복호화 실패")
+		return nil, errors.ErrInvalidToken.Wrap(err)
 	}
 
-	// JSON 언마샬
-	var payload struct {
-		UserID string `json:"user_id"`
+	raw, err := decryptAESGCM(encryptionKey.Secret, cipherBytes)
+	if err != nil {
+		return nil, errors.ErrInvalidToken.Wrap(err)
 	}
+
+	// JSON 언마샬
+	var payload tokenPayload
 	if err := json.Unmarshal(raw, &payload); err != nil {
-		return nil, errors.Wrap(err, "INVALID_TOKEN", "페이로드 파싱 실패")
+		return nil, errors.ErrInvalidToken.Wrap(err)
 	}
 
 	return &Claims{
 		UserID:           payload.UserID,
+		SessionID:        payload.SessionID,
+		IssuedAtMilli:    encClaims.IssuedAtMilli,
 		RegisteredClaims: encClaims.RegisteredClaims,
 	}, nil
 }
 
+// newTokenID 무작위 토큰 ID (32자 hex)
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// issuedAt 토큰 발급 시각 (iat_ms, 없으면 초 단위 iat, 둘 다 없으면 zero - 사용자/세션 폐기 시 거부됨)
+// iat_ms가 없는 이전 토큰은 초의 시작으로 보므로 폐기와 같은 초에 발급됐으면 거부된다
+func issuedAt(claims *Claims) time.Time {
+	if claims.IssuedAtMilli > 0 {
+		return time.UnixMilli(claims.IssuedAtMilli)
+	}
+	if claims.IssuedAt == nil {
+		return time.Time{}
+	}
+	return claims.IssuedAt.Time
+}
+
 // encryptAESGCM AES-GCM 암호화
 func encryptAESGCM(key, plaintext []byte) ([]byte, error) {
 	block, err := aes.NewCipher(key)
@@ -208,13 +422,51 @@ func encryptAESGCM(key, plaintext []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	// 같은 키로 nonce를 재사용하면 GCM이 깨지므로 매번 무작위로 생성
 	nonce := make([]byte, aead.NonceSize())
-	// 실제 운영환경에서는 crypto/rand 사용 필요
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
 	cipherText := aead.Seal(nil, nonce, plaintext, nil)
 
 	return append(nonce, cipherText...), nil
 }
 
+// SealSecret 서버에 보관할 비밀 값 암호화 (TOTP 시크릿 등)
+// primary 키로 암호화하고 "키ID.base64" 형식으로 돌려주므로 키를 교체해도 이전 값을 열 수 있다
+func SealSecret(keys config.Keyring, plaintext string) (string, error) {
+	key := keys.PrimaryKey()
+	sealed, err := encryptAESGCM(key.Secret, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return key.ID + "." + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// OpenSecret SealSecret으로 암호화한 값 복호화
+func OpenSecret(keys config.Keyring, sealed string) (string, error) {
+	keyID, data, ok := strings.Cut(sealed, ".")
+	if !ok {
+		return "", fmt.Errorf("잘못된 암호화 값 형식")
+	}
+
+	key, err := keys.Lookup(keyID)
+	if err != nil {
+		return "", err
+	}
+
+	cipherData, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := decryptAESGCM(key.Secret, cipherData)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
 // decryptAESGCM AES-GCM 복호화
 func decryptAESGCM(key, cipherData []byte) ([]byte, error) {
 	block, err := aes.NewCipher(key)
@@ -239,4 +491,4 @@ func decryptAESGCM(key, cipherData []byte) ([]byte, error) {
 	}
 
 	return plaintext, nil
-}
\ No newline at end of file
+}
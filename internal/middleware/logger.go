@@ -8,31 +8,40 @@ import (
 )
 
 // LoggerMiddleware 요청/응답 로깅 미들웨어
+// 요청 컨텍스트에 요청 단위 로거를 담아 두면 이후 미들웨어가 추가한 필드(user_id 등)도 함께 기록된다
 func LoggerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
 		raw := c.Request.URL.RawQuery
 
+		if raw != "" {
+			path = path + "?" + raw
+		}
+
+		ctx := logger.WithContextFields(c.Request.Context(),
+			logger.String("method", c.Request.Method),
+			logger.String("path", path),
+		)
+		c.Request = c.Request.WithContext(ctx)
+
 		// 요청 처리
 		c.Next()
 
-		// 응답 로깅
-		latency := time.Since(start)
-		statusCode := c.Writer.Status()
-		clientIP := c.ClientIP()
-		method := c.Request.Method
+		// 응답 로깅 (핸들러 체인에서 추가된 필드 포함)
+		log := logger.FromContext(c.Request.Context()).With(
+			logger.Int("status", c.Writer.Status()),
+			logger.Duration("latency", time.Since(start)),
+			logger.String("ip", c.ClientIP()),
+		)
 
-		if raw != "" {
-			path = path + "?" + raw
+		switch status := c.Writer.Status(); {
+		case status >= 500:
+			log.Error("Request processed")
+		case status >= 400:
+			log.Warn("Request processed")
+		default:
+			log.Info("Request processed")
 		}
-
-		logger.WithFields(map[string]interface{}{
-			"status":  statusCode,
-			"latency": latency,
-			"ip":      clientIP,
-			"method":  method,
-			"path":    path,
-		}).Info("Request processed")
 	}
-}
\ No newline at end of file
+}
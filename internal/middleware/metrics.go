@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/metrics"
+	"gin_starter/pkg/response"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware HTTP 요청 수, 처리 시간, 동시 처리 수 지표 수집
+// route 레이블은 경로 템플릿(/api/blog/:id)을 사용하고, 매칭되지 않은 경로는 unmatched로 묶는다
+func MetricsMiddleware(registry *metrics.Registry) gin.HandlerFunc {
+	requests := registry.NewCounterVec("http_requests_total", "처리한 HTTP 요청 수", "method", "route", "status")
+	duration := registry.NewHistogramVec("http_request_duration_seconds", "HTTP 요청 처리 시간 (초)", nil, "method", "route", "status")
+	inFlight := registry.NewGaugeVec("http_requests_in_flight", "처리 중인 HTTP 요청 수").WithLabelValues()
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		requests.WithLabelValues(c.Request.Method, route, status).Inc()
+		duration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// MetricsAuthMiddleware /metrics 접근 제한
+// token(Authorization: Bearer)이 일치하거나 접속 IP가 allowIPs(IP 또는 CIDR)에 있으면 허용한다
+// 둘 다 비어 있으면 제한하지 않는다. IP는 위조 가능한 프록시 헤더 대신 직접 연결한 주소로 확인한다
+func MetricsAuthMiddleware(token string, allowIPs []string) gin.HandlerFunc {
+	networks := parseNetworks(allowIPs)
+
+	return func(c *gin.Context) {
+		if token == "" && len(networks) == 0 {
+			c.Next()
+			return
+		}
+
+		if token != "" {
+			bearer := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+				c.Next()
+				return
+			}
+		}
+
+		if ip := net.ParseIP(c.RemoteIP()); ip != nil {
+			for _, network := range networks {
+				if network.Contains(ip) {
+					c.Next()
+					return
+				}
+			}
+		}
+
+		response.Forbidden(c, "지표 조회 권한이 없습니다")
+		c.Abort()
+	}
+}
+
+// parseNetworks IP/CIDR 목록 해석 (잘못된 항목은 경고 후 제외)
+func parseNetworks(entries []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil {
+				bits := 128
+				if ip.To4() != nil {
+					ip, bits = ip.To4(), 32
+				}
+				networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+				continue
+			}
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			logger.Warn("잘못된 IP 허용 목록 항목 무시: %s", entry)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
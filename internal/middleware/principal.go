@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Principal 인증된 사용자의 권한 정보
+type Principal struct {
+	UserID        string
+	AuthType      string   // u_auth_type (대표 역할)
+	AuthLevel     int      // u_auth_level
+	Roles         []string // 부여된 역할 (_user_roles)
+	Permissions   []string // 역할들에 부여된 권한 (와일드카드 포함)
+	EmailVerified bool     // 이메일 인증 여부 (미인증 계정 이용 제한)
+	MFAEnabled    bool     // 2단계 인증 사용 여부 (관리자 2단계 인증 강제)
+}
+
+// PrincipalLoader 사용자 ID로 권한 정보를 조회하는 함수
+type PrincipalLoader func(ctx context.Context, userID string) (*Principal, error)
+
+// principalEntry 캐시 항목
+// 조회 중인 항목은 principal이 없어도 남겨 두고, 무효화되면 gen을 올려 진행 중인 조회 결과가 저장되지 않게 한다
+type principalEntry struct {
+	principal *Principal // nil이면 캐시된 값 없음
+	expiresAt time.Time
+	gen       uint64 // 무효화될 때마다 증가
+	loading   int    // 진행 중인 조회 수
+}
+
+// PrincipalCache 사용자 권한 정보 캐시 (짧은 TTL + 명시적 무효화)
+// 만료된 항목은 TTL 주기마다 저장 시점에 정리한다
+type PrincipalCache struct {
+	loader    PrincipalLoader
+	ttl       time.Duration
+	mu        sync.RWMutex
+	entries   map[string]*principalEntry
+	lastSweep time.Time
+}
+
+// NewPrincipalCache 권한 정보 캐시 생성
+func NewPrincipalCache(loader PrincipalLoader, ttl time.Duration) *PrincipalCache {
+	return &PrincipalCache{
+		loader:    loader,
+		ttl:       ttl,
+		entries:   make(map[string]*principalEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Get 캐시된 권한 정보 반환 (없거나 만료되면 다시 조회)
+// 조회 중에 Invalidate가 호출되면 조회 결과는 반환만 하고 캐시에 저장하지 않는다
+func (c *PrincipalCache) Get(ctx context.Context, userID string) (*Principal, error) {
+	if c.ttl <= 0 {
+		return c.loader(ctx, userID)
+	}
+
+	now := time.Now()
+
+	c.mu.RLock()
+	if entry, ok := c.entries[userID]; ok && entry.principal != nil && now.Before(entry.expiresAt) {
+		c.mu.RUnlock()
+		return entry.principal, nil
+	}
+	c.mu.RUnlock()
+
+	c.mu.Lock()
+	entry, ok := c.entries[userID]
+	if !ok {
+		entry = &principalEntry{}
+		c.entries[userID] = entry
+	}
+	entry.loading++
+	gen := entry.gen
+	c.mu.Unlock()
+
+	principal, err := c.loader(ctx, userID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.loading--
+	if err == nil && entry.gen == gen {
+		entry.principal = principal
+		entry.expiresAt = now.Add(c.ttl)
+	}
+	if entry.principal == nil && entry.loading == 0 {
+		delete(c.entries, userID)
+	}
+	c.sweep(now)
+
+	if err != nil {
+		return nil, err
+	}
+	return principal, nil
+}
+
+// Invalidate 특정 사용자의 캐시 제거 (권한 변경, 삭제 시 호출)
+func (c *PrincipalCache) Invalidate(userID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	if entry, ok := c.entries[userID]; ok {
+		c.invalidate(userID, entry)
+	}
+	c.mu.Unlock()
+}
+
+// InvalidateAll 전체 캐시 제거 (역할의 권한이 바뀌어 여러 사용자에게 영향이 있을 때 호출)
+func (c *PrincipalCache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	for userID, entry := range c.entries {
+		c.invalidate(userID, entry)
+	}
+	c.mu.Unlock()
+}
+
+// Len 캐시 항목 수 (조회 중인 항목 포함)
+func (c *PrincipalCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// invalidate 항목 무효화 (조회 중이면 세대만 올려 결과가 저장되지 않게 하고, 아니면 삭제 - 잠금 상태에서 호출)
+func (c *PrincipalCache) invalidate(userID string, entry *principalEntry) {
+	if entry.loading == 0 {
+		delete(c.entries, userID)
+		return
+	}
+	entry.gen++
+	entry.principal = nil
+	entry.expiresAt = time.Time{}
+}
+
+// sweep TTL이 지났으면 만료된 항목 정리 (조회 중인 항목은 유지 - 잠금 상태에서 호출)
+func (c *PrincipalCache) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	for userID, entry := range c.entries {
+		if entry.loading == 0 && !now.Before(entry.expiresAt) {
+			delete(c.entries, userID)
+		}
+	}
+	c.lastSweep = now
+}
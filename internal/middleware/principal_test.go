@@ -0,0 +1,75 @@
+package middleware_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gin_starter/internal/middleware"
+)
+
+func TestPrincipalCacheInvalidateDuringLoad(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	cache := middleware.NewPrincipalCache(func(ctx context.Context, userID string) (*middleware.Principal, error) {
+		// 첫 조회는 무효화가 끝날 때까지 기다렸다가 이전 역할을 반환
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+			return &middleware.Principal{UserID: userID, AuthType: "A"}, nil
+		}
+		return &middleware.Principal{UserID: userID, AuthType: "U"}, nil
+	}, time.Minute)
+
+	done := make(chan *middleware.Principal)
+	go func() {
+		principal, _ := cache.Get(context.Background(), "user-1")
+		done <- principal
+	}()
+
+	<-started
+	cache.Invalidate("user-1")
+	close(release)
+
+	if principal := <-done; principal.AuthType != "A" {
+		t.Fatalf("진행 중이던 조회 결과 = %q", principal.AuthType)
+	}
+
+	// 무효화 전에 시작된 조회 결과는 캐시되지 않아야 한다
+	principal, err := cache.Get(context.Background(), "user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.AuthType != "U" || calls.Load() != 2 {
+		t.Errorf("무효화 후 Get = %q (조회 %d회), 이전 권한이 다시 캐시됨", principal.AuthType, calls.Load())
+	}
+}
+
+func TestPrincipalCacheSweepsExpired(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	cache := middleware.NewPrincipalCache(func(ctx context.Context, userID string) (*middleware.Principal, error) {
+		return &middleware.Principal{UserID: userID}, nil
+	}, ttl)
+
+	ctx := context.Background()
+	for _, id := range []string{"user-1", "user-2", "user-3"} {
+		if _, err := cache.Get(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := cache.Len(); got != 3 {
+		t.Fatalf("Len = %d, want 3", got)
+	}
+
+	// TTL이 지난 뒤 저장할 때 만료된 항목이 정리된다
+	time.Sleep(2 * ttl)
+	if _, err := cache.Get(ctx, "user-4"); err != nil {
+		t.Fatal(err)
+	}
+	if got := cache.Len(); got != 1 {
+		t.Errorf("정리 후 Len = %d, want 1", got)
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"fmt"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/ratelimit"
+	"gin_starter/pkg/response"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitKeyFunc 요청을 어느 버킷에 넣을지 정하는 키
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// RateLimitPolicy 라우트 그룹 하나에 적용할 요청 제한
+type RateLimitPolicy struct {
+	Name  string // 정책 이름 (버킷 키 접두사 - 정책끼리 한도를 공유하지 않음)
+	Limit ratelimit.Limit
+	Key   RateLimitKeyFunc // 비우면 KeyByIP
+}
+
+// KeyByIP 접속 IP 기준 (프록시 헤더는 TRUSTED_PROXIES에 있는 프록시가 보낸 경우만 신뢰)
+func KeyByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// KeyByUser 인증된 사용자 기준 (AuthMiddleware 뒤에 둔다, 인증 전이면 IP 기준)
+func KeyByUser(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return "user:" + userID
+	}
+	return KeyByIP(c)
+}
+
+// KeyByAPIKey AuthMiddleware가 확인한 API 키 기준 (AuthMiddleware 뒤에 둔다, API 키 요청이 아니면 사용자/IP 기준)
+// 키마다 한도를 따로 두므로 한 사용자의 키 여러 개가 서로의 한도를 쓰지 않는다
+func KeyByAPIKey(c *gin.Context) string {
+	if keyID := c.GetString("api_key_id"); keyID != "" {
+		return "key:" + keyID
+	}
+	return KeyByUser(c)
+}
+
+// RateLimitMiddleware 토큰 버킷 요청 제한
+// 응답에 RateLimit-Limit/Remaining/Reset/Policy 헤더를 붙이고 (정책이 겹치면 남은 요청이 가장 적은 정책 기준), 한도를 넘으면 429 RATE_LIMITED와 Retry-After를 보낸다
+// store가 nil이거나 한도가 0이면 제한하지 않고, 저장소 오류 시에는 요청을 통과시킨다
+func RateLimitMiddleware(store ratelimit.Store, policy RateLimitPolicy) gin.HandlerFunc {
+	if store == nil || policy.Limit.Burst <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	keyFunc := policy.Key
+	if keyFunc == nil {
+		keyFunc = KeyByIP
+	}
+	policyHeader := fmt.Sprintf("%d;w=%d", policy.Limit.Burst, ceilSeconds(policy.Limit.Window()))
+
+	return func(c *gin.Context) {
+		key := policy.Name + ":" + keyFunc(c)
+
+		result, err := store.Take(c.Request.Context(), key, policy.Limit)
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Warn("요청 제한 확인 실패 (통과 처리): %v", err)
+			c.Next()
+			return
+		}
+
+		// 정책이 여러 개 겹치면 남은 요청이 가장 적은 (먼저 거부할) 정책의 헤더만 남긴다
+		if !result.Allowed || moreRestrictive(c, result.Remaining) {
+			c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+			c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+			c.Header("RateLimit-Policy", policyHeader)
+		}
+
+		if !result.Allowed {
+			logger.FromContext(c.Request.Context()).Warn("요청 제한 초과: policy=%s key=%s", policy.Name, key)
+			response.TooManyRequests(c, ceilSeconds(result.RetryAfter))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// moreRestrictive 앞선 정책이 쓴 RateLimit-Remaining보다 남은 요청이 적은지 (헤더가 없으면 true)
+func moreRestrictive(c *gin.Context, remaining int) bool {
+	current, err := strconv.Atoi(c.Writer.Header().Get("RateLimit-Remaining"))
+	return err != nil || remaining < current
+}
+
+// ceilSeconds 초 단위 올림 (헤더 값, 최소 0)
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
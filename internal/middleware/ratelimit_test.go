@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gin_starter/internal/middleware"
+	"gin_starter/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stackedRouter 느슨한 정책 뒤에 엄격한 정책, 그 뒤에 다시 느슨한 정책을 둔 라우터
+func stackedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	store := ratelimit.NewMemoryStore(time.Minute)
+
+	r := gin.New()
+	r.GET("/",
+		middleware.RateLimitMiddleware(store, middleware.RateLimitPolicy{Name: "loose", Limit: ratelimit.PerMinute(100)}),
+		middleware.RateLimitMiddleware(store, middleware.RateLimitPolicy{Name: "strict", Limit: ratelimit.PerMinute(3)}),
+		middleware.RateLimitMiddleware(store, middleware.RateLimitPolicy{Name: "looser", Limit: ratelimit.PerMinute(50)}),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+	return r
+}
+
+func TestRateLimitStackedPoliciesReportStrictest(t *testing.T) {
+	r := stackedRouter()
+
+	for i, want := range []string{"2", "1", "0"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("요청 %d: status = %d", i+1, w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "3" {
+			t.Errorf("요청 %d: RateLimit-Limit = %s, want 3", i+1, got)
+		}
+		if got := w.Header().Get("RateLimit-Remaining"); got != want {
+			t.Errorf("요청 %d: RateLimit-Remaining = %s, want %s", i+1, got, want)
+		}
+	}
+
+	// 거부한 정책의 헤더가 남아야 한다
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("한도 초과 status = %d", w.Code)
+	}
+	if got := w.Header().Get("RateLimit-Limit"); got != "3" {
+		t.Errorf("거부 RateLimit-Limit = %s, want 3", got)
+	}
+	if got := w.Header().Get("Retry-After"); got == "" {
+		t.Error("Retry-After 없음")
+	}
+}
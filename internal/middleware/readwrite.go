@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"gin_starter/internal/infrastructure/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadYourWritesMiddleware 요청 안에서 쓰기를 실행하면 이후 읽기를 primary에서 실행
+// 읽기 복제본을 쓰는 경우 방금 저장한 데이터를 복제 지연 때문에 못 읽는 문제를 막는다
+func ReadYourWritesMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(database.WithReadYourWrites(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/trace"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDMiddleware 요청 ID와 W3C traceparent 처리 미들웨어
+// 받은 X-Request-ID/traceparent를 이어받거나 새로 생성해 컨텍스트와 요청 로거에 담고 응답 헤더로 돌려준다
+// 다른 미들웨어보다 먼저 등록해야 이후 로그에 요청 ID가 포함된다
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t := trace.FromHeaders(c.GetHeader(trace.HeaderRequestID), c.GetHeader(trace.HeaderTraceparent))
+
+		ctx := trace.NewContext(c.Request.Context(), t)
+		ctx = logger.WithContextFields(ctx,
+			logger.String("request_id", t.RequestID),
+			logger.String("trace_id", t.TraceID),
+			logger.String("span_id", t.SpanID),
+		)
+		c.Request = c.Request.WithContext(ctx)
+		c.Set("request_id", t.RequestID)
+
+		c.Header(trace.HeaderRequestID, t.RequestID)
+		c.Header(trace.HeaderTraceparent, t.Traceparent())
+
+		c.Next()
+	}
+}
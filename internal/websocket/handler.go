@@ -5,6 +5,7 @@ import (
 	"gin_starter/internal/middleware"
 	"gin_starter/pkg/logger"
 	"gin_starter/pkg/response"
+	"gin_starter/pkg/revocation"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -60,7 +61,7 @@ func (h *Handler) HandleChat(c *gin.Context) {
 	// WebSocket 업그레이드
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		logger.Error("WebSocket 업그레이드 실패: %v", err)
+		logger.FromContext(c.Request.Context()).Error("WebSocket 업그레이드 실패: %v", err)
 		return
 	}
 
@@ -116,21 +117,21 @@ func (h *Handler) GetStats(c *gin.Context) {
 }
 
 // SetupWebSocketRoutes WebSocket 라우트 설정
-func SetupWebSocketRoutes(r *gin.Engine, hub *Hub, cfg *config.Config) {
+func SetupWebSocketRoutes(r *gin.Engine, hub *Hub, cfg *config.Config, principals *middleware.PrincipalCache, revoked *revocation.List) {
 	handler := NewHandler(hub, cfg)
 
-	// WebSocket 엔드포인트 (인증 필요)
+	// WebSocket 엔드포인트 (인증 필요, 로그인한 사용자만 - API 키는 받지 않음)
 	ws := r.Group("/ws")
-	ws.Use(middleware.AuthMiddleware(cfg))
+	ws.Use(middleware.AuthMiddleware(cfg, principals, revoked, nil))
 	{
 		ws.GET("/chat", handler.HandleChat)
 	}
 
 	// WebSocket API 엔드포인트 (인증 필요)
 	api := r.Group("/api/ws")
-	api.Use(middleware.AuthMiddleware(cfg))
+	api.Use(middleware.AuthMiddleware(cfg, principals, revoked, nil))
 	{
 		api.GET("/room/:room_id", handler.GetRoomInfo)
 		api.GET("/stats", handler.GetStats)
 	}
-}
\ No newline at end of file
+}
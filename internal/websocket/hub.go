@@ -3,6 +3,7 @@ package websocket
 import (
 	"gin_starter/pkg/logger"
 	"sync"
+	"sync/atomic"
 )
 
 // Hub WebSocket 연결 관리
@@ -13,6 +14,10 @@ type Hub struct {
 	register   chan *Client          // 클라이언트 등록
 	unregister chan *Client          // 클라이언트 해제
 	mu         sync.RWMutex          // 동시성 제어
+
+	running   atomic.Bool   // Run 루프 실행 중 여부 (readiness 검사용)
+	delivered atomic.Uint64 // 클라이언트에 전달한 메시지 수
+	dropped   atomic.Uint64 // 송신 버퍼가 가득 차 버린 메시지 수
 }
 
 // Message WebSocket 메시지 구조
@@ -36,6 +41,9 @@ func NewHub() *Hub {
 
 // Run Hub 실행 (고루틴으로 실행)
 func (h *Hub) Run() {
+	h.running.Store(true)
+	defer h.running.Store(false)
+
 	for {
 		select {
 		case client := <-h.register:
@@ -126,8 +134,10 @@ func (h *Hub) broadcastMessage(message *Message) {
 			for client := range clients {
 				select {
 				case client.send <- message:
+					h.delivered.Add(1)
 				default:
 					// 전송 실패 시 클라이언트 제거
+					h.dropped.Add(1)
 					go func(c *Client) {
 						h.unregister <- c
 					}(client)
@@ -139,7 +149,9 @@ func (h *Hub) broadcastMessage(message *Message) {
 		for client := range h.clients {
 			select {
 			case client.send <- message:
+				h.delivered.Add(1)
 			default:
+				h.dropped.Add(1)
 				go func(c *Client) {
 					h.unregister <- c
 				}(client)
@@ -148,6 +160,11 @@ func (h *Hub) broadcastMessage(message *Message) {
 	}
 }
 
+// IsRunning Run 루프가 실행 중인지 여부
+func (h *Hub) IsRunning() bool {
+	return h.running.Load()
+}
+
 // GetRoomClients 방의 클라이언트 목록 조회
 func (h *Hub) GetRoomClients(roomID string) []string {
 	h.mu.RLock()
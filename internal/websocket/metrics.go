@@ -0,0 +1,11 @@
+package websocket
+
+import "gin_starter/pkg/metrics"
+
+// Collect 방, 클라이언트 수와 메시지 전달/유실 지표 기록 (metrics.Collector 구현)
+func (h *Hub) Collect(w *metrics.Writer) {
+	w.Gauge("websocket_rooms", "열려 있는 방 수", float64(h.GetRoomCount()))
+	w.Gauge("websocket_clients", "연결된 클라이언트 수", float64(h.GetClientCount()))
+	w.Counter("websocket_messages_delivered_total", "클라이언트에 전달한 메시지 수", float64(h.delivered.Load()))
+	w.Counter("websocket_messages_dropped_total", "송신 버퍼가 가득 차 버린 메시지 수", float64(h.dropped.Load()))
+}
@@ -1,4 +1,6 @@
-CREATE TABLE `_blog` (
+-- 블로그 테이블
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `_blog` (
 	`id` BIGINT NOT NULL AUTO_INCREMENT,
 	`title` VARCHAR(200) NULL DEFAULT NULL COMMENT '제목' COLLATE 'utf8mb4_unicode_ci',
 	`content` TEXT NULL DEFAULT NULL COMMENT '내용' COLLATE 'utf8mb4_unicode_ci',
@@ -13,3 +15,6 @@ COMMENT='블로그 테이블'
 COLLATE='utf8mb4_unicode_ci'
 ENGINE=InnoDB
 ;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `_blog`;
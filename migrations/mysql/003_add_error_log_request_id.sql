@@ -0,0 +1,10 @@
+-- 에러 로그 요청 ID (X-Request-ID로 요청 로그와 연결)
+-- +migrate Up
+ALTER TABLE `_a_error_logs`
+	ADD COLUMN `el_request_id` VARCHAR(128) NULL DEFAULT NULL COMMENT '요청 ID' COLLATE 'utf8mb4_general_ci' AFTER `el_sql`,
+	ADD INDEX `idx_el_request_id` (`el_request_id`) USING BTREE;
+
+-- +migrate Down
+ALTER TABLE `_a_error_logs`
+	DROP INDEX `idx_el_request_id`,
+	DROP COLUMN `el_request_id`;
@@ -0,0 +1,32 @@
+-- 로그인 실패 추적과 계정 잠금 기록
+-- +migrate Up
+ALTER TABLE `_user`
+	ADD COLUMN `u_login_fails` INT(10) NOT NULL DEFAULT '0' COMMENT '연속 로그인 실패 횟수' AFTER `u_re_token`,
+	ADD COLUMN `u_login_fail_at` DATETIME NULL DEFAULT NULL COMMENT '마지막 로그인 실패 시각' AFTER `u_login_fails`,
+	ADD COLUMN `u_locked_until` DATETIME NULL DEFAULT NULL COMMENT '로그인 잠금 해제 시각' AFTER `u_login_fail_at`;
+
+CREATE TABLE IF NOT EXISTS `_login_lockouts` (
+	`ll_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`ll_scope` VARCHAR(10) NOT NULL COMMENT 'account, ip' COLLATE 'utf8mb4_general_ci',
+	`ll_user_id` VARCHAR(50) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`ll_ip` VARCHAR(45) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`ll_fail_count` INT(10) NOT NULL DEFAULT '0',
+	`ll_locked_until` DATETIME NULL DEFAULT NULL,
+	`ll_unlocked_by` VARCHAR(50) NULL DEFAULT NULL COMMENT '잠금을 해제한 관리자' COLLATE 'utf8mb4_general_ci',
+	`ll_unlocked_at` DATETIME NULL DEFAULT NULL,
+	`ll_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ll_idx`) USING BTREE,
+	INDEX `idx_ll_user_id` (`ll_user_id`) USING BTREE,
+	INDEX `idx_ll_ip` (`ll_ip`) USING BTREE
+)
+COMMENT='로그인 잠금 기록'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `_login_lockouts`;
+
+ALTER TABLE `_user`
+	DROP COLUMN `u_locked_until`,
+	DROP COLUMN `u_login_fail_at`,
+	DROP COLUMN `u_login_fails`;
@@ -0,0 +1,26 @@
+-- 기기별 로그인 세션 (리프레시 토큰 패밀리)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `_user_sessions` (
+	`us_id` VARCHAR(32) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`us_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`us_token_hash` VARCHAR(64) NOT NULL COMMENT '현재 리프레시 토큰 SHA-256' COLLATE 'utf8mb4_general_ci',
+	`us_user_agent` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`us_ip` VARCHAR(45) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`us_last_used` DATETIME NULL DEFAULT NULL COMMENT '마지막 토큰 갱신 시각',
+	`us_expires_at` DATETIME NOT NULL,
+	`us_revoked_at` DATETIME NULL DEFAULT NULL,
+	`us_revoke_reason` VARCHAR(20) NULL DEFAULT NULL COMMENT 'logout, reuse, user, admin' COLLATE 'utf8mb4_general_ci',
+	`us_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`us_id`) USING BTREE,
+	INDEX `idx_us_user_id` (`us_user_id`) USING BTREE
+)
+COMMENT='로그인 세션'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB;
+
+ALTER TABLE `_user` DROP COLUMN `u_re_token`;
+
+-- +migrate Down
+ALTER TABLE `_user` ADD COLUMN `u_re_token` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci' AFTER `u_name`;
+
+DROP TABLE IF EXISTS `_user_sessions`;
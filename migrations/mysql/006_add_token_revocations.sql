@@ -0,0 +1,18 @@
+-- 액세스 토큰 폐기 기록 (로그아웃, 비밀번호 변경 등 즉시 반영)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `_token_revocations` (
+	`tr_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`tr_kind` VARCHAR(10) NOT NULL COMMENT 'token, session, user' COLLATE 'utf8mb4_general_ci',
+	`tr_subject` VARCHAR(50) NOT NULL COMMENT 'jti, 세션 ID, 사용자 ID' COLLATE 'utf8mb4_general_ci',
+	`tr_revoked_at` DATETIME NOT NULL COMMENT '이 시각 이전에 발급된 토큰 거부',
+	`tr_expires_at` DATETIME NOT NULL COMMENT '대상 토큰이 모두 만료되는 시각',
+	PRIMARY KEY (`tr_idx`) USING BTREE,
+	INDEX `idx_tr_revoked_at` (`tr_revoked_at`) USING BTREE,
+	INDEX `idx_tr_expires_at` (`tr_expires_at`) USING BTREE
+)
+COMMENT='액세스 토큰 폐기 기록'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `_token_revocations`;
@@ -0,0 +1,27 @@
+-- 이메일 인증과 비밀번호 재설정 (일회용 토큰)
+-- +migrate Up
+ALTER TABLE `_user` ADD COLUMN `u_email_verified_at` DATETIME NULL DEFAULT NULL COMMENT '이메일 인증 시각' AFTER `u_email`;
+
+-- 기존 사용자는 인증된 것으로 본다 (도입 시점에 갑자기 이용이 제한되지 않도록)
+UPDATE `_user` SET `u_email_verified_at` = COALESCE(`u_regi_date`, NOW());
+
+CREATE TABLE IF NOT EXISTS `_user_tokens` (
+	`ut_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`ut_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ut_purpose` VARCHAR(10) NOT NULL COMMENT 'verify, reset' COLLATE 'utf8mb4_general_ci',
+	`ut_token_hash` VARCHAR(64) NOT NULL COMMENT '토큰 SHA-256' COLLATE 'utf8mb4_general_ci',
+	`ut_email` VARCHAR(100) NULL DEFAULT NULL COMMENT '인증 대상 이메일 (verify)' COLLATE 'utf8mb4_general_ci',
+	`ut_expires_at` DATETIME NOT NULL,
+	`ut_used_at` DATETIME NULL DEFAULT NULL,
+	`ut_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ut_idx`) USING BTREE,
+	UNIQUE INDEX `idx_ut_token_hash` (`ut_token_hash`) USING BTREE,
+	INDEX `idx_ut_user_id` (`ut_user_id`) USING BTREE
+)
+COMMENT='이메일 인증, 비밀번호 재설정 토큰'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `_user_tokens`;
+ALTER TABLE `_user` DROP COLUMN `u_email_verified_at`;
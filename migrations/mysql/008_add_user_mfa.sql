@@ -0,0 +1,26 @@
+-- TOTP 2단계 인증과 복구 코드
+-- +migrate Up
+ALTER TABLE `_user`
+	ADD COLUMN `u_mfa_secret` VARCHAR(255) NULL DEFAULT NULL COMMENT 'TOTP 시크릿 (암호화)' COLLATE 'utf8mb4_general_ci' AFTER `u_locked_until`,
+	ADD COLUMN `u_mfa_enabled_at` DATETIME NULL DEFAULT NULL COMMENT '2단계 인증 사용 시작 시각' AFTER `u_mfa_secret`,
+	ADD COLUMN `u_mfa_last_step` BIGINT NOT NULL DEFAULT '0' COMMENT '마지막으로 사용한 TOTP 구간 (재사용 방지)' AFTER `u_mfa_enabled_at`;
+
+CREATE TABLE IF NOT EXISTS `_user_recovery_codes` (
+	`rc_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`rc_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`rc_code_hash` VARCHAR(64) NOT NULL COMMENT '복구 코드 SHA-256' COLLATE 'utf8mb4_general_ci',
+	`rc_used_at` DATETIME NULL DEFAULT NULL,
+	`rc_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`rc_idx`) USING BTREE,
+	INDEX `idx_rc_user_id` (`rc_user_id`) USING BTREE
+)
+COMMENT='2단계 인증 복구 코드'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `_user_recovery_codes`;
+ALTER TABLE `_user`
+	DROP COLUMN `u_mfa_last_step`,
+	DROP COLUMN `u_mfa_enabled_at`,
+	DROP COLUMN `u_mfa_secret`;
@@ -0,0 +1,16 @@
+-- 비밀번호 변경 이력 (최근 비밀번호 재사용 금지)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `_user_password_history` (
+	`ph_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`ph_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ph_hash` TEXT NOT NULL COMMENT '비밀번호 해시' COLLATE 'utf8mb4_general_ci',
+	`ph_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ph_idx`) USING BTREE,
+	INDEX `idx_ph_user_id` (`ph_user_id`, `ph_idx`) USING BTREE
+)
+COMMENT='비밀번호 변경 이력'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `_user_password_history`;
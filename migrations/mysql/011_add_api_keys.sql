@@ -0,0 +1,37 @@
+-- 서버 간 호출용 API 키 (키 원문은 저장하지 않고 SHA-256 해시만)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `_api_keys` (
+	`ak_id` VARCHAR(32) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ak_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ak_name` VARCHAR(100) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ak_prefix` VARCHAR(20) NOT NULL COMMENT '키 식별용 접두사 (gsk_ + 12자)' COLLATE 'utf8mb4_general_ci',
+	`ak_key_hash` VARCHAR(64) NOT NULL COMMENT '키 원문 SHA-256' COLLATE 'utf8mb4_general_ci',
+	`ak_scopes` TEXT NOT NULL COMMENT '사용 범위 권한 (쉼표 구분, 와일드카드 포함)' COLLATE 'utf8mb4_general_ci',
+	`ak_allow_ips` TEXT NULL DEFAULT NULL COMMENT '허용 IP/CIDR (쉼표 구분, NULL이면 제한 없음)' COLLATE 'utf8mb4_general_ci',
+	`ak_expires_at` DATETIME NULL DEFAULT NULL COMMENT 'NULL이면 만료 없음',
+	`ak_last_used` DATETIME NULL DEFAULT NULL,
+	`ak_last_ip` VARCHAR(45) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`ak_revoked_at` DATETIME NULL DEFAULT NULL,
+	`ak_revoked_by` VARCHAR(50) NULL DEFAULT NULL COMMENT '폐기한 사용자 또는 관리자 ID' COLLATE 'utf8mb4_general_ci',
+	`ak_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ak_id`) USING BTREE,
+	UNIQUE INDEX `uk_ak_prefix` (`ak_prefix`) USING BTREE,
+	INDEX `idx_ak_user_id` (`ak_user_id`) USING BTREE
+)
+COMMENT='API 키'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB;
+
+INSERT IGNORE INTO `_permissions` (`p_code`, `p_description`) VALUES
+	('apikey:create', '본인 API 키 발급');
+
+INSERT IGNORE INTO `_role_permissions` (`rp_role`, `rp_permission`) VALUES
+	('U', 'apikey:create'),
+	('M', 'apikey:create'),
+	('AG', 'apikey:create');
+
+-- +migrate Down
+DELETE FROM `_role_permissions` WHERE `rp_permission` = 'apikey:create';
+DELETE FROM `_permissions` WHERE `p_code` = 'apikey:create';
+
+DROP TABLE IF EXISTS `_api_keys`;
@@ -0,0 +1,36 @@
+-- 소셜 로그인 (OAuth2/OIDC 제공자 계정 연결, 로그인 진행 중 state)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `_user_identities` (
+	`ui_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`ui_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ui_provider` VARCHAR(20) NOT NULL COMMENT 'OAUTH_PROVIDERS의 이름' COLLATE 'utf8mb4_general_ci',
+	`ui_subject` VARCHAR(255) NOT NULL COMMENT '제공자의 사용자 ID (sub)' COLLATE 'utf8mb4_general_ci',
+	`ui_email` VARCHAR(100) NULL DEFAULT NULL COMMENT '연결 당시 제공자 이메일' COLLATE 'utf8mb4_general_ci',
+	`ui_last_login` DATETIME NULL DEFAULT NULL,
+	`ui_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ui_idx`) USING BTREE,
+	UNIQUE INDEX `uk_ui_provider_subject` (`ui_provider`, `ui_subject`) USING BTREE,
+	UNIQUE INDEX `uk_ui_user_provider` (`ui_user_id`, `ui_provider`) USING BTREE
+)
+COMMENT='소셜 로그인 연결 계정'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS `_oauth_states` (
+	`os_state_hash` VARCHAR(64) NOT NULL COMMENT 'state SHA-256' COLLATE 'utf8mb4_general_ci',
+	`os_provider` VARCHAR(20) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`os_verifier` VARCHAR(128) NOT NULL COMMENT 'PKCE code_verifier' COLLATE 'utf8mb4_general_ci',
+	`os_nonce` VARCHAR(64) NOT NULL COMMENT 'ID 토큰 nonce' COLLATE 'utf8mb4_general_ci',
+	`os_user_id` VARCHAR(50) NULL DEFAULT NULL COMMENT '계정 연결을 요청한 사용자 (NULL이면 로그인)' COLLATE 'utf8mb4_general_ci',
+	`os_expires_at` DATETIME NOT NULL,
+	`os_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`os_state_hash`) USING BTREE,
+	INDEX `idx_os_expires_at` (`os_expires_at`) USING BTREE
+)
+COMMENT='소셜 로그인 진행 중 state (콜백에서 한 번 사용 후 삭제)'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `_oauth_states`;
+DROP TABLE IF EXISTS `_user_identities`;
@@ -0,0 +1,9 @@
+-- 토큰 폐기 시각을 밀리초까지 저장 (토큰 iat_ms와 비교, 초 단위면 반올림되어 폐기 직후 발급된 토큰이 거부될 수 있음)
+-- SQLite는 시각을 문자열로 저장해 밀리초가 유지되므로 변경 없음
+-- +migrate Up
+ALTER TABLE `_token_revocations`
+	MODIFY COLUMN `tr_revoked_at` DATETIME(3) NOT NULL COMMENT '이 시각 이전에 발급된 토큰 거부';
+
+-- +migrate Down
+ALTER TABLE `_token_revocations`
+	MODIFY COLUMN `tr_revoked_at` DATETIME NOT NULL COMMENT '이 시각 이전에 발급된 토큰 거부';
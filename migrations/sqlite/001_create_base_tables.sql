@@ -0,0 +1,75 @@
+-- 기본 테이블 (에러 로그, 사용자, 메뉴, 채팅)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "_a_error_logs" (
+	"el_where" TEXT NULL DEFAULT NULL,
+	"el_message" TEXT NULL DEFAULT NULL,
+	"el_sql" TEXT NULL DEFAULT NULL,
+	"el_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "_user" (
+	"u_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"u_id" VARCHAR(50) NULL DEFAULT NULL UNIQUE,
+	"u_pass" TEXT NULL DEFAULT NULL,
+	"u_auth_type" VARCHAR(10) NULL DEFAULT 'U',
+	"u_auth_level" INTEGER NULL DEFAULT 0,
+	"u_email" VARCHAR(100) NULL DEFAULT NULL,
+	"u_name" VARCHAR(50) NULL DEFAULT NULL,
+	"u_re_token" TEXT NULL DEFAULT NULL,
+	"u_memo" TEXT NULL DEFAULT NULL,
+	"u_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "_menu_groups" (
+	"mg_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"mg_label" VARCHAR(100) NULL DEFAULT NULL,
+	"mg_order" INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS "_menu_items" (
+	"mi_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"mi_group_id" INTEGER NULL DEFAULT NULL,
+	"mi_label" VARCHAR(100) NULL DEFAULT NULL,
+	"mi_href" VARCHAR(255) NULL DEFAULT NULL,
+	"mi_roles" TEXT NULL DEFAULT NULL,
+	"mi_order" INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "mi_group_id" ON "_menu_items" ("mi_group_id");
+
+INSERT OR IGNORE INTO "_menu_groups" ("mg_idx", "mg_label", "mg_order") VALUES
+	(1, '기본 메뉴', 1),
+	(2, '게시물 관리', 2),
+	(3, '광고 관리', 3),
+	(4, '설정', 4),
+	(5, NULL, 5);
+
+INSERT OR IGNORE INTO "_menu_items" ("mi_idx", "mi_group_id", "mi_label", "mi_href", "mi_roles", "mi_order") VALUES
+	(1, 1, '대시보드', '/adm/dashboard', '["A", "M", "AG"]', 1),
+	(2, 2, '공지사항', '/adm/posts/notice', '["A", "M", "AG"]', 1),
+	(3, 2, '자주 묻는 질문', '/adm/posts/faq', '["A", "M", "AG"]', 2),
+	(4, 3, '배너 설정', '/adm/ads/banner', '["A", "M"]', 1),
+	(5, 3, '광고 승인', '/adm/ads/approval', '["A", "M"]', 2),
+	(6, 4, '설정', '/adm/settings', '["A"]', 1),
+	(7, 5, '로그아웃', '/adm/manage/logout', '["A", "M", "AG"]', 6),
+	(8, 1, '메뉴', '/adm/menu', '["A"]', 2),
+	(9, 1, '사용자', '/adm/users', '["A"]', 3),
+	(10, 1, '채팅', '/adm/chat', '["A", "M", "AG"]', 4);
+
+CREATE TABLE IF NOT EXISTS "_chat_messages" (
+	"cm_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"cm_room_id" VARCHAR(255) NULL DEFAULT NULL,
+	"cm_sender_id" VARCHAR(50) NULL DEFAULT NULL,
+	"cm_receiver_id" VARCHAR(50) NULL DEFAULT NULL,
+	"cm_content" TEXT NULL DEFAULT NULL,
+	"cm_timestamp" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "cm_room_id" ON "_chat_messages" ("cm_room_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "_chat_messages";
+DROP TABLE IF EXISTS "_menu_items";
+DROP TABLE IF EXISTS "_menu_groups";
+DROP TABLE IF EXISTS "_user";
+DROP TABLE IF EXISTS "_a_error_logs";
@@ -0,0 +1,16 @@
+-- 블로그 테이블
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "_blog" (
+	"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"title" VARCHAR(200) NULL DEFAULT NULL,
+	"content" TEXT NULL DEFAULT NULL,
+	"author_id" VARCHAR(50) NULL DEFAULT NULL,
+	"created_at" TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_author_id" ON "_blog" ("author_id");
+CREATE INDEX IF NOT EXISTS "idx_created_at" ON "_blog" ("created_at");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "_blog";
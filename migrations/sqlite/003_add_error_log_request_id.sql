@@ -0,0 +1,9 @@
+-- 에러 로그 요청 ID (X-Request-ID로 요청 로그와 연결)
+-- +migrate Up
+ALTER TABLE "_a_error_logs" ADD COLUMN "el_request_id" VARCHAR(128) NULL DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_el_request_id" ON "_a_error_logs" ("el_request_id");
+
+-- +migrate Down
+DROP INDEX IF EXISTS "idx_el_request_id";
+ALTER TABLE "_a_error_logs" DROP COLUMN "el_request_id";
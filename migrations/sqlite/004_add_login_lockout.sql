@@ -0,0 +1,26 @@
+-- 로그인 실패 추적과 계정 잠금 기록
+-- +migrate Up
+ALTER TABLE "_user" ADD COLUMN "u_login_fails" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "_user" ADD COLUMN "u_login_fail_at" DATETIME NULL DEFAULT NULL;
+ALTER TABLE "_user" ADD COLUMN "u_locked_until" DATETIME NULL DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS "_login_lockouts" (
+	"ll_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"ll_scope" VARCHAR(10) NOT NULL,
+	"ll_user_id" VARCHAR(50) NULL DEFAULT NULL,
+	"ll_ip" VARCHAR(45) NULL DEFAULT NULL,
+	"ll_fail_count" INTEGER NOT NULL DEFAULT 0,
+	"ll_locked_until" DATETIME NULL DEFAULT NULL,
+	"ll_unlocked_by" VARCHAR(50) NULL DEFAULT NULL,
+	"ll_unlocked_at" DATETIME NULL DEFAULT NULL,
+	"ll_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_ll_user_id" ON "_login_lockouts" ("ll_user_id");
+CREATE INDEX IF NOT EXISTS "idx_ll_ip" ON "_login_lockouts" ("ll_ip");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "_login_lockouts";
+ALTER TABLE "_user" DROP COLUMN "u_locked_until";
+ALTER TABLE "_user" DROP COLUMN "u_login_fail_at";
+ALTER TABLE "_user" DROP COLUMN "u_login_fails";
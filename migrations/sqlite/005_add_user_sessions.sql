@@ -0,0 +1,22 @@
+-- 기기별 로그인 세션 (리프레시 토큰 패밀리)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "_user_sessions" (
+	"us_id" VARCHAR(32) NOT NULL PRIMARY KEY,
+	"us_user_id" VARCHAR(50) NOT NULL,
+	"us_token_hash" VARCHAR(64) NOT NULL,
+	"us_user_agent" VARCHAR(255) NULL DEFAULT NULL,
+	"us_ip" VARCHAR(45) NULL DEFAULT NULL,
+	"us_last_used" DATETIME NULL DEFAULT NULL,
+	"us_expires_at" DATETIME NOT NULL,
+	"us_revoked_at" DATETIME NULL DEFAULT NULL,
+	"us_revoke_reason" VARCHAR(20) NULL DEFAULT NULL,
+	"us_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_us_user_id" ON "_user_sessions" ("us_user_id");
+
+ALTER TABLE "_user" DROP COLUMN "u_re_token";
+
+-- +migrate Down
+ALTER TABLE "_user" ADD COLUMN "u_re_token" TEXT NULL DEFAULT NULL;
+DROP TABLE IF EXISTS "_user_sessions";
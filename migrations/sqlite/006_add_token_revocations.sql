@@ -0,0 +1,15 @@
+-- 액세스 토큰 폐기 기록 (로그아웃, 비밀번호 변경 등 즉시 반영)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "_token_revocations" (
+	"tr_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"tr_kind" VARCHAR(10) NOT NULL,
+	"tr_subject" VARCHAR(50) NOT NULL,
+	"tr_revoked_at" DATETIME NOT NULL,
+	"tr_expires_at" DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "idx_tr_revoked_at" ON "_token_revocations" ("tr_revoked_at");
+CREATE INDEX IF NOT EXISTS "idx_tr_expires_at" ON "_token_revocations" ("tr_expires_at");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "_token_revocations";
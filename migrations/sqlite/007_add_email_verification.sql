@@ -0,0 +1,24 @@
+-- 이메일 인증과 비밀번호 재설정 (일회용 토큰)
+-- +migrate Up
+ALTER TABLE "_user" ADD COLUMN "u_email_verified_at" DATETIME NULL DEFAULT NULL;
+
+-- 기존 사용자는 인증된 것으로 본다 (도입 시점에 갑자기 이용이 제한되지 않도록)
+UPDATE "_user" SET "u_email_verified_at" = COALESCE("u_regi_date", CURRENT_TIMESTAMP);
+
+CREATE TABLE IF NOT EXISTS "_user_tokens" (
+	"ut_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"ut_user_id" VARCHAR(50) NOT NULL,
+	"ut_purpose" VARCHAR(10) NOT NULL,
+	"ut_token_hash" VARCHAR(64) NOT NULL,
+	"ut_email" VARCHAR(100) NULL DEFAULT NULL,
+	"ut_expires_at" DATETIME NOT NULL,
+	"ut_used_at" DATETIME NULL DEFAULT NULL,
+	"ut_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_ut_token_hash" ON "_user_tokens" ("ut_token_hash");
+CREATE INDEX IF NOT EXISTS "idx_ut_user_id" ON "_user_tokens" ("ut_user_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "_user_tokens";
+ALTER TABLE "_user" DROP COLUMN "u_email_verified_at";
@@ -0,0 +1,21 @@
+-- TOTP 2단계 인증과 복구 코드
+-- +migrate Up
+ALTER TABLE "_user" ADD COLUMN "u_mfa_secret" VARCHAR(255) NULL DEFAULT NULL;
+ALTER TABLE "_user" ADD COLUMN "u_mfa_enabled_at" DATETIME NULL DEFAULT NULL;
+ALTER TABLE "_user" ADD COLUMN "u_mfa_last_step" BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "_user_recovery_codes" (
+	"rc_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"rc_user_id" VARCHAR(50) NOT NULL,
+	"rc_code_hash" VARCHAR(64) NOT NULL,
+	"rc_used_at" DATETIME NULL DEFAULT NULL,
+	"rc_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_rc_user_id" ON "_user_recovery_codes" ("rc_user_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "_user_recovery_codes";
+ALTER TABLE "_user" DROP COLUMN "u_mfa_last_step";
+ALTER TABLE "_user" DROP COLUMN "u_mfa_enabled_at";
+ALTER TABLE "_user" DROP COLUMN "u_mfa_secret";
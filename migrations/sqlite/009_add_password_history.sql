@@ -0,0 +1,13 @@
+-- 비밀번호 변경 이력 (최근 비밀번호 재사용 금지)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "_user_password_history" (
+	"ph_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"ph_user_id" VARCHAR(50) NOT NULL,
+	"ph_hash" TEXT NOT NULL,
+	"ph_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_ph_user_id" ON "_user_password_history" ("ph_user_id", "ph_idx");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "_user_password_history";
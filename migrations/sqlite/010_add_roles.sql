@@ -0,0 +1,79 @@
+-- 역할 기반 권한 (역할, 권한, 역할별 권한, 사용자 역할)
+-- 기존 u_auth_type(U, A) 계정은 같은 코드의 역할에 연결한다
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "_roles" (
+	"r_code" VARCHAR(10) NOT NULL,
+	"r_name" VARCHAR(50) NOT NULL,
+	"r_description" VARCHAR(255) NULL DEFAULT NULL,
+	"r_system" INTEGER NOT NULL DEFAULT 0,
+	"r_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY ("r_code")
+);
+
+CREATE TABLE IF NOT EXISTS "_permissions" (
+	"p_code" VARCHAR(100) NOT NULL,
+	"p_description" VARCHAR(255) NULL DEFAULT NULL,
+	"p_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY ("p_code")
+);
+
+CREATE TABLE IF NOT EXISTS "_role_permissions" (
+	"rp_role" VARCHAR(10) NOT NULL,
+	"rp_permission" VARCHAR(100) NOT NULL,
+	"rp_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY ("rp_role", "rp_permission")
+);
+
+CREATE TABLE IF NOT EXISTS "_user_roles" (
+	"ur_user_id" VARCHAR(50) NOT NULL,
+	"ur_role" VARCHAR(10) NOT NULL,
+	"ur_granted_by" VARCHAR(50) NULL DEFAULT NULL,
+	"ur_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY ("ur_user_id", "ur_role")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_ur_role" ON "_user_roles" ("ur_role");
+
+INSERT OR IGNORE INTO "_roles" ("r_code", "r_name", "r_description", "r_system") VALUES
+	('U', '일반 사용자', '가입 시 기본 역할', 1),
+	('A', '관리자', '모든 권한', 1),
+	('M', '매니저', '관리자 페이지, 사용자 조회, 모든 블로그 관리', 0),
+	('AG', '에이전트', '관리자 페이지, 사용자 조회', 0);
+
+INSERT OR IGNORE INTO "_permissions" ("p_code", "p_description") VALUES
+	('admin:access', '관리자 API 접근'),
+	('user:read', '사용자, 세션, 로그인 잠금 기록 조회'),
+	('user:manage', '사용자 권한 변경, 잠금 해제, 세션 종료, 2단계 인증 초기화'),
+	('user:delete', '사용자 삭제'),
+	('role:manage', '역할과 권한 관리, 사용자 역할 부여'),
+	('stats:read', '통계 조회'),
+	('blog:create', '블로그 작성'),
+	('blog:update:own', '본인 블로그 수정'),
+	('blog:delete:own', '본인 블로그 삭제'),
+	('blog:update:any', '모든 블로그 수정'),
+	('blog:delete:any', '모든 블로그 삭제');
+
+INSERT OR IGNORE INTO "_role_permissions" ("rp_role", "rp_permission") VALUES
+	('U', 'blog:create'),
+	('U', 'blog:update:own'),
+	('U', 'blog:delete:own'),
+	('A', '*'),
+	('M', 'admin:access'),
+	('M', 'user:read'),
+	('M', 'stats:read'),
+	('M', 'blog:*'),
+	('AG', 'admin:access'),
+	('AG', 'user:read'),
+	('AG', 'blog:create'),
+	('AG', 'blog:update:own'),
+	('AG', 'blog:delete:own');
+
+-- 기존 계정 (알 수 없는 타입은 일반 사용자로)
+INSERT OR IGNORE INTO "_user_roles" ("ur_user_id", "ur_role")
+	SELECT "u_id", CASE WHEN "u_auth_type" IN ('A', 'M', 'AG') THEN "u_auth_type" ELSE 'U' END FROM "_user";
+
+-- +migrate Down
+DROP TABLE IF EXISTS "_user_roles";
+DROP TABLE IF EXISTS "_role_permissions";
+DROP TABLE IF EXISTS "_permissions";
+DROP TABLE IF EXISTS "_roles";
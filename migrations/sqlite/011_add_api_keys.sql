@@ -0,0 +1,34 @@
+-- 서버 간 호출용 API 키 (키 원문은 저장하지 않고 SHA-256 해시만)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "_api_keys" (
+	"ak_id" VARCHAR(32) NOT NULL PRIMARY KEY,
+	"ak_user_id" VARCHAR(50) NOT NULL,
+	"ak_name" VARCHAR(100) NOT NULL,
+	"ak_prefix" VARCHAR(20) NOT NULL,
+	"ak_key_hash" VARCHAR(64) NOT NULL,
+	"ak_scopes" TEXT NOT NULL,
+	"ak_allow_ips" TEXT NULL DEFAULT NULL,
+	"ak_expires_at" DATETIME NULL DEFAULT NULL,
+	"ak_last_used" DATETIME NULL DEFAULT NULL,
+	"ak_last_ip" VARCHAR(45) NULL DEFAULT NULL,
+	"ak_revoked_at" DATETIME NULL DEFAULT NULL,
+	"ak_revoked_by" VARCHAR(50) NULL DEFAULT NULL,
+	"ak_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "uk_ak_prefix" ON "_api_keys" ("ak_prefix");
+CREATE INDEX IF NOT EXISTS "idx_ak_user_id" ON "_api_keys" ("ak_user_id");
+
+INSERT OR IGNORE INTO "_permissions" ("p_code", "p_description") VALUES
+	('apikey:create', '본인 API 키 발급');
+
+INSERT OR IGNORE INTO "_role_permissions" ("rp_role", "rp_permission") VALUES
+	('U', 'apikey:create'),
+	('M', 'apikey:create'),
+	('AG', 'apikey:create');
+
+-- +migrate Down
+DELETE FROM "_role_permissions" WHERE "rp_permission" = 'apikey:create';
+DELETE FROM "_permissions" WHERE "p_code" = 'apikey:create';
+
+DROP TABLE IF EXISTS "_api_keys";
@@ -0,0 +1,30 @@
+-- 소셜 로그인 (OAuth2/OIDC 제공자 계정 연결, 로그인 진행 중 state)
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "_user_identities" (
+	"ui_idx" INTEGER PRIMARY KEY AUTOINCREMENT,
+	"ui_user_id" VARCHAR(50) NOT NULL,
+	"ui_provider" VARCHAR(20) NOT NULL,
+	"ui_subject" VARCHAR(255) NOT NULL,
+	"ui_email" VARCHAR(100) NULL DEFAULT NULL,
+	"ui_last_login" DATETIME NULL DEFAULT NULL,
+	"ui_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "uk_ui_provider_subject" ON "_user_identities" ("ui_provider", "ui_subject");
+CREATE UNIQUE INDEX IF NOT EXISTS "uk_ui_user_provider" ON "_user_identities" ("ui_user_id", "ui_provider");
+
+CREATE TABLE IF NOT EXISTS "_oauth_states" (
+	"os_state_hash" VARCHAR(64) NOT NULL PRIMARY KEY,
+	"os_provider" VARCHAR(20) NOT NULL,
+	"os_verifier" VARCHAR(128) NOT NULL,
+	"os_nonce" VARCHAR(64) NOT NULL,
+	"os_user_id" VARCHAR(50) NULL DEFAULT NULL,
+	"os_expires_at" DATETIME NOT NULL,
+	"os_regi_date" DATETIME NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_os_expires_at" ON "_oauth_states" ("os_expires_at");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "_oauth_states";
+DROP TABLE IF EXISTS "_user_identities";
@@ -1,15 +1,19 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
+	"net/http"
 )
 
 // AppError 애플리케이션 에러
+// Message는 응답에 그대로 보여주는 문구이고, 원본 에러(Err)는 로그에만 남긴다 (release 모드 응답에서 숨김)
 type AppError struct {
+	Status  int                    // HTTP 상태 코드 (0이면 500)
 	Code    string                 // 에러 코드
-	Message string                 // 에러 메시지
-	Err     error                  // 원본 에러
-	Meta    map[string]interface{} // 추가 메타데이터
+	Message string                 // 에러 메시지 (사용자에게 보여줌)
+	Err     error                  // 원본 에러 (내부용)
+	Meta    map[string]interface{} // 추가 메타데이터 (응답의 error.details)
 }
 
 func (e *AppError) Error() string {
@@ -23,72 +27,172 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
-// New 새로운 AppError 생성
+// Is 같은 코드의 AppError면 true (errors.Is(err, errors.ErrUserNotFound)가 감싼 에러까지 확인하도록)
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// HTTPStatus 응답 상태 코드
+func (e *AppError) HTTPStatus() int {
+	if e.Status == 0 {
+		return http.StatusInternalServerError
+	}
+	return e.Status
+}
+
+// New 새로운 AppError 생성 (400 - 요청을 고쳐야 하는 에러)
 func New(code, message string) *AppError {
+	return NewStatus(http.StatusBadRequest, code, message)
+}
+
+// NewStatus 상태 코드를 지정해 AppError 생성
+func NewStatus(status int, code, message string) *AppError {
 	return &AppError{
+		Status:  status,
 		Code:    code,
 		Message: message,
 	}
 }
 
-// Wrap 기존 에러를 AppError로 래핑
+// Wrap 기존 에러를 AppError로 래핑 (500 - 원본 에러는 응답에 노출하지 않음)
 func Wrap(err error, code, message string) *AppError {
 	if err == nil {
 		return nil
 	}
 	return &AppError{
+		Status:  http.StatusInternalServerError,
 		Code:    code,
 		Message: message,
 		Err:     err,
 	}
 }
 
-// WithMeta 메타데이터 추가
+// Wrap 같은 코드, 상태로 원본 에러를 감싼 복사본 (errors.ErrOAuthFailed.Wrap(err))
+func (e *AppError) Wrap(err error) *AppError {
+	c := e.clone()
+	c.Err = err
+	return c
+}
+
+// WithMeta 메타데이터를 추가한 복사본 (미리 정의된 에러를 바꾸지 않음)
 func (e *AppError) WithMeta(key string, value interface{}) *AppError {
-	if e.Meta == nil {
-		e.Meta = make(map[string]interface{})
+	c := e.clone()
+	c.Meta = make(map[string]interface{}, len(e.Meta)+1)
+	for k, v := range e.Meta {
+		c.Meta[k] = v
 	}
-	e.Meta[key] = value
-	return e
+	c.Meta[key] = value
+	return c
+}
+
+// WithMessage 메시지를 바꾼 복사본 (코드, 상태는 그대로)
+func (e *AppError) WithMessage(message string) *AppError {
+	c := e.clone()
+	c.Message = message
+	return c
+}
+
+func (e *AppError) clone() *AppError {
+	c := *e
+	return &c
 }
 
 // 미리 정의된 에러들
 var (
 	// 일반 에러
-	ErrInternal        = New("INTERNAL_ERROR", "내부 서버 오류가 발생했습니다")
-	ErrBadRequest      = New("BAD_REQUEST", "잘못된 요청입니다")
-	ErrNotFound        = New("NOT_FOUND", "요청한 리소스를 찾을 수 없습니다")
-	ErrUnauthorized    = New("UNAUTHORIZED", "인증이 필요합니다")
-	ErrForbidden       = New("FORBIDDEN", "접근 권한이 없습니다")
-	ErrConflict        = New("CONFLICT", "리소스 충돌이 발생했습니다")
-	ErrValidation      = New("VALIDATION_ERROR", "입력값 검증에 실패했습니다")
+	ErrInternal     = NewStatus(http.StatusInternalServerError, "INTERNAL_ERROR", "내부 서버 오류가 발생했습니다")
+	ErrBadRequest   = NewStatus(http.StatusBadRequest, "BAD_REQUEST", "잘못된 요청입니다")
+	ErrNotFound     = NewStatus(http.StatusNotFound, "NOT_FOUND", "요청한 리소스를 찾을 수 없습니다")
+	ErrUnauthorized = NewStatus(http.StatusUnauthorized, "UNAUTHORIZED", "인증이 필요합니다")
+	ErrForbidden    = NewStatus(http.StatusForbidden, "FORBIDDEN", "접근 권한이 없습니다")
+	ErrConflict     = NewStatus(http.StatusConflict, "CONFLICT", "리소스 충돌이 발생했습니다")
+	ErrValidation   = NewStatus(http.StatusUnprocessableEntity, "VALIDATION_ERROR", "입력값 검증에 실패했습니다")
+	ErrRateLimited  = NewStatus(http.StatusTooManyRequests, "RATE_LIMITED", "요청이 너무 많습니다. 잠시 후 다시 시도해주세요")
 
 	// 데이터베이스 에러
-	ErrDatabase        = New("DATABASE_ERROR", "데이터베이스 오류가 발생했습니다")
-	ErrDuplicateEntry  = New("DUPLICATE_ENTRY", "이미 존재하는 데이터입니다")
-	ErrRecordNotFound  = New("RECORD_NOT_FOUND", "데이터를 찾을 수 없습니다")
+	ErrDatabase        = NewStatus(http.StatusInternalServerError, "DATABASE_ERROR", "데이터베이스 오류가 발생했습니다")
+	ErrDuplicateEntry  = NewStatus(http.StatusConflict, "DUPLICATE_ENTRY", "이미 존재하는 데이터입니다")
+	ErrRecordNotFound  = NewStatus(http.StatusNotFound, "RECORD_NOT_FOUND", "데이터를 찾을 수 없습니다")
+	ErrQueryTimeout    = NewStatus(http.StatusGatewayTimeout, "QUERY_TIMEOUT", "요청 처리 시간이 초과되었습니다")
+	ErrRequestCanceled = NewStatus(StatusClientClosedRequest, "REQUEST_CANCELED", "요청이 취소되었습니다")
 
 	// 인증 에러
-	ErrInvalidToken    = New("INVALID_TOKEN", "유효하지 않은 토큰입니다")
-	ErrExpiredToken    = New("EXPIRED_TOKEN", "만료된 토큰입니다")
-	ErrInvalidPassword = New("INVALID_PASSWORD", "비밀번호가 일치하지 않습니다")
+	ErrInvalidToken    = NewStatus(http.StatusUnauthorized, "INVALID_TOKEN", "유효하지 않은 토큰입니다")
+	ErrExpiredToken    = NewStatus(http.StatusUnauthorized, "EXPIRED_TOKEN", "만료된 토큰입니다")
+	ErrInvalidPassword = NewStatus(http.StatusBadRequest, "INVALID_PASSWORD", "비밀번호가 일치하지 않습니다")
+	ErrAccountLocked   = NewStatus(http.StatusTooManyRequests, "ACCOUNT_LOCKED", "로그인 실패가 반복되어 잠시 로그인할 수 없습니다")
+	ErrLoginThrottled  = NewStatus(http.StatusTooManyRequests, "LOGIN_THROTTLED", "로그인 시도가 너무 잦습니다. 잠시 후 다시 시도해주세요")
+	ErrTokenReused     = NewStatus(http.StatusUnauthorized, "TOKEN_REUSED", "이미 사용된 리프레시 토큰입니다. 보안을 위해 세션이 종료되었습니다")
 
 	// 사용자 에러
-	ErrUserNotFound    = New("USER_NOT_FOUND", "사용자를 찾을 수 없습니다")
-	ErrUserExists      = New("USER_EXISTS", "이미 존재하는 사용자입니다")
-	ErrInvalidCredentials = New("INVALID_CREDENTIALS", "아이디 또는 비밀번호가 잘못되었습니다")
+	ErrUserNotFound       = NewStatus(http.StatusNotFound, "USER_NOT_FOUND", "사용자를 찾을 수 없습니다")
+	ErrUserExists         = NewStatus(http.StatusConflict, "USER_EXISTS", "이미 존재하는 사용자입니다")
+	ErrInvalidCredentials = NewStatus(http.StatusUnauthorized, "INVALID_CREDENTIALS", "아이디 또는 비밀번호가 잘못되었습니다")
+	ErrSessionNotFound    = NewStatus(http.StatusNotFound, "SESSION_NOT_FOUND", "세션을 찾을 수 없습니다")
+	ErrEmailNotVerified   = NewStatus(http.StatusForbidden, "EMAIL_NOT_VERIFIED", "이메일 인증이 필요합니다. 받은 편지함의 인증 메일을 확인해주세요")
+	ErrEmailVerified      = NewStatus(http.StatusConflict, "EMAIL_ALREADY_VERIFIED", "이미 인증된 이메일입니다")
+	ErrInvalidLinkToken   = NewStatus(http.StatusBadRequest, "INVALID_LINK_TOKEN", "유효하지 않거나 만료된 링크입니다. 다시 요청해주세요")
+	ErrMFARequired        = NewStatus(http.StatusForbidden, "MFA_REQUIRED", "관리자 계정은 2단계 인증을 설정해야 합니다")
+	ErrMFASetupRequired   = NewStatus(http.StatusBadRequest, "MFA_SETUP_REQUIRED", "2단계 인증 설정을 먼저 시작해주세요")
+	ErrMFAEnabled         = NewStatus(http.StatusConflict, "MFA_ALREADY_ENABLED", "이미 2단계 인증을 사용 중입니다")
+	ErrMFANotEnabled      = NewStatus(http.StatusBadRequest, "MFA_NOT_ENABLED", "2단계 인증을 사용하고 있지 않습니다")
+	ErrInvalidMFACode     = NewStatus(http.StatusBadRequest, "INVALID_MFA_CODE", "인증 코드가 올바르지 않습니다")
+	ErrMFATokenInvalid    = NewStatus(http.StatusUnauthorized, "MFA_TOKEN_INVALID", "2단계 인증 시간이 지났습니다. 다시 로그인해주세요")
+	ErrPasswordPolicy     = NewStatus(http.StatusUnprocessableEntity, "PASSWORD_POLICY", "비밀번호가 보안 정책에 맞지 않습니다")
+
+	// 역할, 권한 에러
+	ErrPermissionDenied  = NewStatus(http.StatusForbidden, "PERMISSION_DENIED", "접근 권한이 없습니다")
+	ErrRoleNotFound      = NewStatus(http.StatusNotFound, "ROLE_NOT_FOUND", "역할을 찾을 수 없습니다")
+	ErrRoleExists        = NewStatus(http.StatusConflict, "ROLE_EXISTS", "이미 존재하는 역할입니다")
+	ErrRoleInUse         = NewStatus(http.StatusConflict, "ROLE_IN_USE", "사용자에게 부여된 역할은 삭제할 수 없습니다")
+	ErrRoleProtected     = NewStatus(http.StatusConflict, "ROLE_PROTECTED", "기본 역할은 삭제할 수 없고, 관리자 역할의 권한은 바꿀 수 없습니다")
+	ErrRoleSelfLockout   = NewStatus(http.StatusConflict, "ROLE_SELF_LOCKOUT", "자신의 역할 관리 권한은 제거할 수 없습니다")
+	ErrInvalidPermission = NewStatus(http.StatusBadRequest, "INVALID_PERMISSION", "알 수 없는 권한입니다")
+
+	// API 키 에러
+	ErrInvalidAPIKey      = NewStatus(http.StatusUnauthorized, "INVALID_API_KEY", "유효하지 않은 API 키입니다")
+	ErrAPIKeyExpired      = NewStatus(http.StatusUnauthorized, "API_KEY_EXPIRED", "만료된 API 키입니다")
+	ErrAPIKeyIPDenied     = NewStatus(http.StatusForbidden, "API_KEY_IP_DENIED", "이 IP에서는 API 키를 사용할 수 없습니다")
+	ErrAPIKeyNotAllowed   = NewStatus(http.StatusUnauthorized, "API_KEY_NOT_ALLOWED", "API 키로 사용할 수 없는 API입니다")
+	ErrAPIKeyScope        = NewStatus(http.StatusForbidden, "API_KEY_SCOPE", "API 키의 사용 범위를 벗어난 요청입니다")
+	ErrAPIKeyNotFound     = NewStatus(http.StatusNotFound, "API_KEY_NOT_FOUND", "API 키를 찾을 수 없습니다")
+	ErrAPIKeyLimit        = NewStatus(http.StatusConflict, "API_KEY_LIMIT", "더 이상 API 키를 만들 수 없습니다. 사용하지 않는 키를 폐기해주세요")
+	ErrInvalidAPIKeyScope = NewStatus(http.StatusBadRequest, "INVALID_API_KEY_SCOPE", "API 키에 부여할 수 없는 권한입니다")
+
+	// 소셜 로그인 에러
+	ErrOAuthProviderNotFound = NewStatus(http.StatusNotFound, "OAUTH_PROVIDER_NOT_FOUND", "지원하지 않는 로그인 제공자입니다")
+	ErrOAuthStateInvalid     = NewStatus(http.StatusBadRequest, "OAUTH_STATE_INVALID", "로그인 요청이 만료되었거나 올바르지 않습니다. 다시 시도해주세요")
+	ErrOAuthDenied           = NewStatus(http.StatusBadRequest, "OAUTH_DENIED", "로그인 제공자에서 인증이 취소되었습니다")
+	ErrOAuthFailed           = NewStatus(http.StatusUnauthorized, "OAUTH_FAILED", "로그인 제공자 인증에 실패했습니다")
+	ErrOAuthNotLinked        = NewStatus(http.StatusForbidden, "OAUTH_NOT_LINKED", "연결된 계정이 없습니다. 로그인한 뒤 계정 연결을 해주세요")
+	ErrOAuthEmailRequired    = NewStatus(http.StatusForbidden, "OAUTH_EMAIL_REQUIRED", "로그인 제공자가 이메일을 알려주지 않아 가입할 수 없습니다")
+	ErrOAuthAccountExists    = NewStatus(http.StatusConflict, "OAUTH_ACCOUNT_EXISTS", "같은 이메일을 쓰는 계정이 있습니다. 그 계정으로 로그인한 뒤 연결해주세요")
+	ErrOAuthIdentityLinked   = NewStatus(http.StatusConflict, "OAUTH_IDENTITY_LINKED", "다른 계정에 연결된 소셜 계정입니다")
+	ErrOAuthProviderLinked   = NewStatus(http.StatusConflict, "OAUTH_PROVIDER_LINKED", "이 제공자의 다른 소셜 계정이 이미 연결되어 있습니다")
+	ErrOAuthLastLogin        = NewStatus(http.StatusConflict, "OAUTH_LAST_LOGIN", "비밀번호가 없는 계정은 마지막 소셜 계정 연결을 해제할 수 없습니다")
+	ErrIdentityNotFound      = NewStatus(http.StatusNotFound, "IDENTITY_NOT_FOUND", "연결된 소셜 계정을 찾을 수 없습니다")
+
+	// 블로그 에러
+	ErrBlogNotFound = NewStatus(http.StatusNotFound, "BLOG_NOT_FOUND", "블로그를 찾을 수 없습니다")
 )
 
-// Is 에러 타입 확인
+// StatusClientClosedRequest 클라이언트가 응답 전에 연결을 끊은 경우 (nginx 관례)
+const StatusClientClosedRequest = 499
+
+// Is 에러 타입 확인 (Wrap, fmt.Errorf("%w")로 감싼 에러도 코드로 비교)
 func Is(err error, target *AppError) bool {
 	if err == nil || target == nil {
 		return false
 	}
+	return stderrors.Is(err, target)
+}
 
-	appErr, ok := err.(*AppError)
-	if !ok {
-		return false
+// As err 체인에서 가장 바깥의 AppError (없으면 false)
+func As(err error) (*AppError, bool) {
+	var appErr *AppError
+	if !stderrors.As(err, &appErr) {
+		return nil, false
 	}
-
-	return appErr.Code == target.Code
-}
\ No newline at end of file
+	return appErr, true
+}
@@ -0,0 +1,34 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// errDiskUnsupported 여유 공간 조회를 지원하지 않는 OS
+var errDiskUnsupported = errors.New("여유 공간 조회를 지원하지 않는 OS입니다")
+
+// DiskSpace 디렉토리가 있는 파일 시스템의 여유 공간이 minFreeBytes 이상인지 확인
+// 디렉토리가 없으면 생성하고, 여유 공간 조회를 지원하지 않는 OS에서는 항상 정상으로 본다
+func DiskSpace(dir string, minFreeBytes uint64) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("디렉토리 확인 실패: %w", err)
+		}
+
+		free, err := freeBytes(dir)
+		if errors.Is(err, errDiskUnsupported) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("여유 공간 조회 실패: %w", err)
+		}
+
+		if free < minFreeBytes {
+			return fmt.Errorf("여유 공간 부족: %dMB (최소 %dMB)", free>>20, minFreeBytes>>20)
+		}
+		return nil
+	})
+}
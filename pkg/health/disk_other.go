@@ -0,0 +1,8 @@
+//go:build !unix
+
+package health
+
+// freeBytes 여유 공간 조회 미지원
+func freeBytes(string) (uint64, error) {
+	return 0, errDiskUnsupported
+}
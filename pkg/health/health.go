@@ -0,0 +1,205 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 상태 값
+const (
+	StatusOK           = "ok"
+	StatusFail         = "fail"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Checker 의존성 상태 확인 (정상이면 nil)
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc 함수형 Checker
+type CheckerFunc func(ctx context.Context) error
+
+// Check Checker 구현
+func (f CheckerFunc) Check(ctx context.Context) error { return f(ctx) }
+
+// CheckResult 검사 하나의 결과
+type CheckResult struct {
+	Status      string     `json:"status"`
+	LatencyMs   float64    `json:"latency_ms"`
+	Error       string     `json:"error,omitempty"`         // 이번 검사 에러
+	LastError   string     `json:"last_error,omitempty"`    // 마지막으로 실패했을 때 에러
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"` // 마지막 실패 시각
+}
+
+// Report 검사 전체 결과
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// check 등록된 검사 (마지막 실패 기록 유지)
+type check struct {
+	name    string
+	checker Checker
+
+	mu          sync.Mutex
+	lastError   string
+	lastErrorAt time.Time
+}
+
+// run 검사 실행 및 결과 기록
+func (c *check) run(ctx context.Context) CheckResult {
+	start := time.Now()
+	err := c.checker.Check(ctx)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+		c.lastError = err.Error()
+		c.lastErrorAt = time.Now()
+	}
+	if c.lastError != "" {
+		at := c.lastErrorAt
+		result.LastError = c.lastError
+		result.LastErrorAt = &at
+	}
+	return result
+}
+
+// Registry 이름 있는 liveness/readiness 검사 모음
+type Registry struct {
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+
+	mu        sync.RWMutex
+	liveness  []*check
+	readiness []*check
+}
+
+// NewRegistry 검사 등록소 생성 (timeout: 검사 하나의 최대 시간)
+func NewRegistry(timeout time.Duration) *Registry {
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	return &Registry{timeout: timeout}
+}
+
+// AddLiveness 프로세스가 살아 있는지 판단하는 검사 추가 (실패 시 재시작 대상)
+// 외부 의존성(DB 등)은 넣지 않는다 - 의존성 장애로 모든 인스턴스가 재시작되는 것을 막기 위함
+func (r *Registry) AddLiveness(name string, c Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.liveness = append(r.liveness, &check{name: name, checker: c})
+}
+
+// AddReadiness 트래픽을 받을 수 있는지 판단하는 검사 추가 (실패 시 로드밸런서에서 제외)
+func (r *Registry) AddReadiness(name string, c Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.readiness = append(r.readiness, &check{name: name, checker: c})
+}
+
+// Shutdown 종료 시작 표시 (이후 readiness는 503)
+func (r *Registry) Shutdown() {
+	r.shuttingDown.Store(true)
+}
+
+// ShuttingDown 종료 중 여부
+func (r *Registry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// Liveness liveness 검사 실행
+func (r *Registry) Liveness(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := r.liveness
+	r.mu.RUnlock()
+
+	return r.run(ctx, checks)
+}
+
+// Readiness readiness 검사 실행 (종료 중이면 검사 결과와 무관하게 shutting_down)
+func (r *Registry) Readiness(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := r.readiness
+	r.mu.RUnlock()
+
+	report := r.run(ctx, checks)
+	if r.ShuttingDown() {
+		report.Status = StatusShuttingDown
+	}
+	return report
+}
+
+// run 검사를 동시에 실행해 결과 취합
+func (r *Registry) run(ctx context.Context, checks []*check) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c *check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+			result := c.run(checkCtx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// LivenessHandler liveness 결과 응답 (정상 200, 실패 503)
+func (r *Registry) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeReport(w, r.Liveness(req.Context()))
+	})
+}
+
+// ReadinessHandler readiness 결과 응답 (정상 200, 실패/종료 중 503)
+func (r *Registry) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeReport(w, r.Readiness(req.Context()))
+	})
+}
+
+// writeReport 결과를 JSON으로 응답
+func writeReport(w http.ResponseWriter, report Report) {
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 출력 형식
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// Config 기본 로거 설정
+type Config struct {
+	Level          string // debug, info, warn, error
+	Format         string // console, json
+	Color          bool   // 콘솔 형식 레벨 색상 (파일에는 적용하지 않음)
+	File           string // 로그 파일 경로 (비우면 stdout만)
+	FileMaxSizeMB  int    // 파일 교체 기준 크기
+	FileMaxBackups int    // 보관할 이전 파일 개수
+}
+
+// Configure 설정에 맞게 기본 로거의 레벨과 출력 대상 교체
+// stdout은 항상 기록하고, File이 있으면 같은 형식으로 파일에도 기록한다
+func Configure(cfg Config) error {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+
+	encoder, err := encoderFor(cfg.Format, cfg.Color)
+	if err != nil {
+		return err
+	}
+
+	outputs := []Output{{Sink: NewWriterSink(os.Stdout), Encoder: encoder}}
+
+	if cfg.File != "" {
+		sink, err := NewFileSink(cfg.File, cfg.FileMaxSizeMB, cfg.FileMaxBackups)
+		if err != nil {
+			return err
+		}
+
+		fileEncoder := encoder
+		if cfg.Color {
+			fileEncoder, _ = encoderFor(cfg.Format, false)
+		}
+		outputs = append(outputs, Output{Sink: sink, Encoder: fileEncoder})
+	}
+
+	SetLevel(level)
+	SetOutputs(outputs...)
+	return nil
+}
+
+// encoderFor 형식 이름으로 인코더 반환
+func encoderFor(format string, color bool) (Encoder, error) {
+	switch strings.ToLower(format) {
+	case "", FormatConsole, "text":
+		return NewConsoleEncoder(color), nil
+	case FormatJSON:
+		return NewJSONEncoder(), nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 로그 형식: %s (console, json)", format)
+	}
+}
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Encoder 로그 엔트리를 한 줄로 직렬화 (끝에 줄바꿈 포함)
+type Encoder interface {
+	Encode(entry *Entry) []byte
+}
+
+// jsonEncoder 한 줄에 JSON 객체 하나 (로그 수집기용)
+type jsonEncoder struct{}
+
+// NewJSONEncoder JSON 인코더 생성
+// {"time":"...","level":"info","msg":"...", 필드...} 형식
+func NewJSONEncoder() Encoder {
+	return jsonEncoder{}
+}
+
+func (jsonEncoder) Encode(entry *Entry) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"time":`)
+	writeJSON(&buf, entry.Time.Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSON(&buf, strings.ToLower(entry.Level.String()))
+	buf.WriteString(`,"msg":`)
+	writeJSON(&buf, entry.Message)
+
+	for _, f := range entry.Fields {
+		buf.WriteByte(',')
+		writeJSON(&buf, f.Key)
+		buf.WriteByte(':')
+		writeJSON(&buf, jsonValue(f.Value))
+	}
+
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// jsonValue JSON으로 표현할 값 (Duration은 밀리초, Time은 RFC3339)
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Duration:
+		return float64(v) / float64(time.Millisecond)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return v
+}
+
+// writeJSON 값을 JSON으로 기록 (인코딩할 수 없는 값은 문자열로 대체)
+func writeJSON(buf *bytes.Buffer, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprintf("%+v", v))
+	}
+	buf.Write(b)
+}
+
+// consoleEncoder 사람이 읽기 쉬운 한 줄 형식 (개발 환경용)
+type consoleEncoder struct {
+	color      bool
+	timeFormat string
+}
+
+// NewConsoleEncoder 콘솔 인코더 생성
+// 2006-01-02 15:04:05 [INFO] 메시지 key=value 형식, color면 레벨에 ANSI 색상 적용
+func NewConsoleEncoder(color bool) Encoder {
+	return consoleEncoder{color: color, timeFormat: "2006-01-02 15:04:05"}
+}
+
+var levelColors = map[Level]string{
+	DEBUG: "\033[36m", // Cyan
+	INFO:  "\033[32m", // Green
+	WARN:  "\033[33m", // Yellow
+	ERROR: "\033[31m", // Red
+	FATAL: "\033[35m", // Magenta
+}
+
+const colorReset = "\033[0m"
+
+func (e consoleEncoder) Encode(entry *Entry) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(entry.Time.Format(e.timeFormat))
+	buf.WriteString(" [")
+	if e.color {
+		buf.WriteString(levelColors[entry.Level])
+		buf.WriteString(entry.Level.String())
+		buf.WriteString(colorReset)
+	} else {
+		buf.WriteString(entry.Level.String())
+	}
+	buf.WriteString("] ")
+	buf.WriteString(entry.Message)
+
+	for _, f := range entry.Fields {
+		buf.WriteByte(' ')
+		buf.WriteString(f.Key)
+		buf.WriteByte('=')
+		buf.WriteString(consoleValue(f.Value))
+	}
+
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// consoleValue 콘솔 출력용 값 (공백, 따옴표가 있으면 인용)
+func consoleValue(v interface{}) string {
+	var s string
+	switch v := v.(type) {
+	case nil:
+		return "<nil>"
+	case string:
+		s = v
+	case time.Time:
+		s = v.Format(time.RFC3339)
+	default:
+		s = fmt.Sprintf("%+v", v)
+	}
+
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
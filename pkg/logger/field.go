@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+)
+
+// Field 구조화 로그 필드
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String 문자열 필드
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int 정수 필드
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int64 64비트 정수 필드
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Float64 실수 필드
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool 불리언 필드
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration 경과 시간 필드 (JSON은 밀리초 숫자, 콘솔은 1.5ms 형식)
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Time 시각 필드 (RFC3339)
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err 에러 필드 (키는 error, nil이면 값도 nil)
+func Err(err error) Field {
+	if err == nil {
+		return Field{Key: "error", Value: nil}
+	}
+	return Field{Key: "error", Value: err.Error()}
+}
+
+// Any 임의 값 필드 (JSON 인코딩 가능한 값 권장)
+func Any(key string, value interface{}) Field {
+	if err, ok := value.(error); ok {
+		return Field{Key: key, Value: err.Error()}
+	}
+	return Field{Key: key, Value: value}
+}
+
+// Stringer fmt.Stringer 필드 (기록 시점에 문자열로 변환)
+func Stringer(key string, value fmt.Stringer) Field {
+	return Field{Key: key, Value: value.String()}
+}
@@ -1,9 +1,13 @@
 package logger
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -18,93 +22,257 @@ const (
 	FATAL
 )
 
-var (
-	levelNames = map[Level]string{
-		DEBUG: "DEBUG",
-		INFO:  "INFO",
-		WARN:  "WARN",
-		ERROR: "ERROR",
-		FATAL: "FATAL",
+var levelNames = map[Level]string{
+	DEBUG: "DEBUG",
+	INFO:  "INFO",
+	WARN:  "WARN",
+	ERROR: "ERROR",
+	FATAL: "FATAL",
+}
+
+// String 레벨 이름 (DEBUG, INFO, ...)
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
 	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
 
-	levelColors = map[Level]string{
-		DEBUG: "\033[36m", // Cyan
-		INFO:  "\033[32m", // Green
-		WARN:  "\033[33m", // Yellow
-		ERROR: "\033[31m", // Red
-		FATAL: "\033[35m", // Magenta
+// ParseLevel 문자열을 로그 레벨로 변환 (대소문자 무시, warning/err 별칭 허용)
+func ParseLevel(levelStr string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(levelStr)) {
+	case "debug":
+		return DEBUG, nil
+	case "info", "":
+		return INFO, nil
+	case "warn", "warning":
+		return WARN, nil
+	case "error", "err":
+		return ERROR, nil
+	case "fatal":
+		return FATAL, nil
+	default:
+		return INFO, fmt.Errorf("알 수 없는 로그 레벨: %s", levelStr)
 	}
+}
 
-	reset = "\033[0m"
-)
+// Entry 인코더에 전달되는 로그 한 건
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  []Field
+}
 
-// Logger 구조체
-type Logger struct {
-	level      Level
-	useColor   bool
-	timeFormat string
+// Output 로그를 기록할 대상 (인코더로 직렬화해 싱크에 기록)
+type Output struct {
+	Sink    Sink
+	Encoder Encoder
 }
 
-var defaultLogger *Logger
+// core 같은 설정을 공유하는 Logger들의 공통 상태 (레벨, 출력 대상)
+type core struct {
+	level atomic.Int32
 
-func init() {
-	defaultLogger = &Logger{
-		level:      INFO,
-		useColor:   true,
-		timeFormat: "2006-01-02 15:04:05",
+	mu      sync.Mutex
+	outputs []Output
+}
+
+// write 모든 출력 대상에 기록 (기록 실패는 stderr로 알림)
+func (c *core) write(entry *Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, out := range c.outputs {
+		if err := out.Sink.Write(out.Encoder.Encode(entry)); err != nil {
+			fmt.Fprintf(os.Stderr, "logger: 기록 실패: %v\n", err)
+		}
 	}
 }
 
-// SetLevel 로그 레벨 설정
+// replace 출력 대상 교체 (기존 싱크는 닫음)
+func (c *core) replace(outputs []Output) {
+	c.mu.Lock()
+	old := c.outputs
+	c.outputs = outputs
+	c.mu.Unlock()
+
+	closeOutputs(old, outputs)
+}
+
+// close 모든 싱크 닫기
+func (c *core) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	closeOutputs(c.outputs, nil)
+}
+
+// closeOutputs keep에 없는 싱크 닫기
+func closeOutputs(outputs, keep []Output) {
+	for _, out := range outputs {
+		kept := false
+		for _, k := range keep {
+			if k.Sink == out.Sink {
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			out.Sink.Close()
+		}
+	}
+}
+
+// Logger 필드를 가진 로거 (With로 파생한 로거는 레벨과 출력 대상을 공유)
+type Logger struct {
+	core   *core
+	fields []Field
+}
+
+// LogEntry 이전 버전 호환용 별칭
+type LogEntry = Logger
+
+// New 로거 생성
+func New(level Level, outputs ...Output) *Logger {
+	c := &core{outputs: outputs}
+	c.level.Store(int32(level))
+	return &Logger{core: c}
+}
+
+var defaultLogger = New(INFO, Output{Sink: NewWriterSink(os.Stdout), Encoder: NewConsoleEncoder(true)})
+
+// Default 기본 로거 반환
+func Default() *Logger {
+	return defaultLogger
+}
+
+// SetLevel 기본 로거 레벨 설정
 func SetLevel(level Level) {
-	defaultLogger.level = level
+	defaultLogger.SetLevel(level)
 }
 
-// SetLevelFromString 문자열로 로그 레벨 설정
+// SetLevelFromString 문자열로 기본 로거 레벨 설정 (알 수 없는 값은 경고 후 INFO)
 func SetLevelFromString(levelStr string) {
-	switch levelStr {
-	case "debug":
-		SetLevel(DEBUG)
-	case "info":
-		SetLevel(INFO)
-	case "warn":
-		SetLevel(WARN)
-	case "error":
-		SetLevel(ERROR)
-	case "fatal":
-		SetLevel(FATAL)
-	default:
-		SetLevel(INFO)
+	level, err := ParseLevel(levelStr)
+	if err != nil {
+		Warn("%v (INFO 사용)", err)
+	}
+	SetLevel(level)
+}
+
+// SetOutputs 기본 로거의 출력 대상 교체
+func SetOutputs(outputs ...Output) {
+	defaultLogger.core.replace(outputs)
+}
+
+// Close 기본 로거의 싱크 닫기 (파일 싱크 flush, 종료 직전 호출)
+func Close() {
+	defaultLogger.core.close()
+}
+
+// SetLevel 레벨 설정 (같은 core를 공유하는 모든 로거에 적용)
+func (l *Logger) SetLevel(level Level) {
+	l.core.level.Store(int32(level))
+}
+
+// Level 현재 레벨
+func (l *Logger) Level() Level {
+	return Level(l.core.level.Load())
+}
+
+// Enabled 해당 레벨 로그가 기록되는지 여부
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.Level()
+}
+
+// With 필드를 추가한 파생 로거 반환
+func (l *Logger) With(fields ...Field) *Logger {
+	if len(fields) == 0 {
+		return l
 	}
+
+	merged := make([]Field, 0, len(l.fields)+len(fields))
+	merged = append(merged, l.fields...)
+	merged = append(merged, fields...)
+	return &Logger{core: l.core, fields: merged}
+}
+
+// WithField 필드 하나를 추가한 파생 로거 반환
+func (l *Logger) WithField(key string, value interface{}) *Logger {
+	return l.With(Any(key, value))
+}
+
+// WithFields 여러 필드를 추가한 파생 로거 반환 (키 이름 순)
+func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	list := make([]Field, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, Any(key, fields[key]))
+	}
+	return l.With(list...)
 }
 
 // log 내부 로깅 함수
 func (l *Logger) log(level Level, format string, args ...interface{}) {
-	if level < l.level {
+	if !l.Enabled(level) {
 		return
 	}
 
-	timestamp := time.Now().Format(l.timeFormat)
-	levelName := levelNames[level]
-	message := fmt.Sprintf(format, args...)
-
-	var output string
-	if l.useColor {
-		color := levelColors[level]
-		output = fmt.Sprintf("%s [%s]%s %s - %s",
-			timestamp, color+levelName+reset, reset, message, "")
-	} else {
-		output = fmt.Sprintf("%s [%s] %s", timestamp, levelName, message)
+	message := format
+	if len(args) > 0 {
+		message = fmt.Sprintf(format, args...)
+	}
+	l.write(level, time.Now(), message, nil)
+}
+
+// write 엔트리 기록 (FATAL이면 싱크를 닫고 종료)
+func (l *Logger) write(level Level, t time.Time, message string, fields []Field) {
+	all := l.fields
+	if len(fields) > 0 {
+		all = make([]Field, 0, len(l.fields)+len(fields))
+		all = append(all, l.fields...)
+		all = append(all, fields...)
 	}
 
+	l.core.write(&Entry{Time: t, Level: level, Message: message, Fields: all})
+
 	if level == FATAL {
-		log.Fatal(output)
+		l.core.close()
 		os.Exit(1)
-	} else {
-		log.Println(output)
 	}
 }
 
+// Debug 디버그 로그
+func (l *Logger) Debug(format string, args ...interface{}) {
+	l.log(DEBUG, format, args...)
+}
+
+// Info 정보 로그
+func (l *Logger) Info(format string, args ...interface{}) {
+	l.log(INFO, format, args...)
+}
+
+// Warn 경고 로그
+func (l *Logger) Warn(format string, args ...interface{}) {
+	l.log(WARN, format, args...)
+}
+
+// Error 에러 로그
+func (l *Logger) Error(format string, args ...interface{}) {
+	l.log(ERROR, format, args...)
+}
+
+// Fatal 치명적 에러 로그 (프로그램 종료)
+func (l *Logger) Fatal(format string, args ...interface{}) {
+	l.log(FATAL, format, args...)
+}
+
 // Debug 디버그 로그
 func Debug(format string, args ...interface{}) {
 	defaultLogger.log(DEBUG, format, args...)
@@ -130,49 +298,40 @@ func Fatal(format string, args ...interface{}) {
 	defaultLogger.log(FATAL, format, args...)
 }
 
-// WithField 필드와 함께 로그
-func WithField(key string, value interface{}) *LogEntry {
-	return &LogEntry{
-		fields: map[string]interface{}{key: value},
-	}
+// With 기본 로거에 필드를 추가한 파생 로거 반환
+func With(fields ...Field) *Logger {
+	return defaultLogger.With(fields...)
 }
 
-// WithFields 여러 필드와 함께 로그
-func WithFields(fields map[string]interface{}) *LogEntry {
-	return &LogEntry{fields: fields}
+// WithField 필드와 함께 로그
+func WithField(key string, value interface{}) *Logger {
+	return defaultLogger.WithField(key, value)
 }
 
-// LogEntry 필드를 포함한 로그 엔트리
-type LogEntry struct {
-	fields map[string]interface{}
+// WithFields 여러 필드와 함께 로그
+func WithFields(fields map[string]interface{}) *Logger {
+	return defaultLogger.WithFields(fields)
 }
 
-// Debug 필드와 함께 디버그 로그
-func (e *LogEntry) Debug(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	Debug("%s %v", message, e.fields)
-}
+// ctxKey 컨텍스트에 로거를 담는 키
+type ctxKey struct{}
 
-// Info 필드와 함께 정보 로그
-func (e *LogEntry) Info(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	Info("%s %v", message, e.fields)
+// NewContext 로거를 담은 컨텍스트 반환 (요청 ID, 사용자 ID 등 요청 단위 필드 전달용)
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
 }
 
-// Warn 필드와 함께 경고 로그
-func (e *LogEntry) Warn(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	Warn("%s %v", message, e.fields)
+// FromContext 컨텍스트의 로거 반환 (없으면 기본 로거)
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(ctxKey{}).(*Logger); ok {
+			return l
+		}
+	}
+	return defaultLogger
 }
 
-// Error 필드와 함께 에러 로그
-func (e *LogEntry) Error(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	Error("%s %v", message, e.fields)
+// WithContextFields 컨텍스트 로거에 필드를 추가한 컨텍스트 반환
+func WithContextFields(ctx context.Context, fields ...Field) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(fields...))
 }
-
-// Fatal 필드와 함께 치명적 에러 로그
-func (e *LogEntry) Fatal(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	Fatal("%s %v", message, e.fields)
-}
\ No newline at end of file
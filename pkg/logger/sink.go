@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Sink 인코딩된 로그를 기록할 대상
+type Sink interface {
+	Write(p []byte) error
+	Close() error
+}
+
+// writerSink io.Writer 싱크 (stdout, stderr 등 - Close해도 writer는 닫지 않음)
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink io.Writer로 기록하는 싱크 생성
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+func (s *writerSink) Write(p []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.w.Write(p)
+	return err
+}
+
+func (s *writerSink) Close() error { return nil }
+
+// FileSink 크기 기준으로 교체되는 파일 싱크
+// 파일이 maxSize를 넘으면 app.log → app.log.1 → app.log.2 ... 로 밀어내고 maxBackups개만 유지
+type FileSink struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewFileSink 파일 싱크 생성 (maxSizeMB가 0 이하면 교체하지 않음)
+func NewFileSink(path string, maxSizeMB, maxBackups int) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("로그 디렉토리 생성 실패: %w", err)
+	}
+
+	s := &FileSink{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// open 로그 파일 열기 (이어쓰기)
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("로그 파일 열기 실패: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("로그 파일 확인 실패: %w", err)
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileSink) Write(p []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("닫힌 로그 파일입니다: %s", s.path)
+	}
+
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(p)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(p)
+	s.size += int64(n)
+	return err
+}
+
+// rotate 현재 파일을 백업으로 밀어내고 새 파일 열기 (s.mu 보유 상태에서 호출)
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file = nil
+
+	if s.maxBackups <= 0 {
+		os.Remove(s.path)
+	} else {
+		os.Remove(fmt.Sprintf("%s.%d", s.path, s.maxBackups))
+		for i := s.maxBackups - 1; i >= 1; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", s.path, i), fmt.Sprintf("%s.%d", s.path, i+1))
+		}
+		if err := os.Rename(s.path, s.path+".1"); err != nil {
+			return fmt.Errorf("로그 파일 교체 실패: %w", err)
+		}
+	}
+
+	return s.open()
+}
+
+// Close 파일 닫기
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// MemorySink 메모리에 기록하는 싱크 (테스트에서 로그 내용 확인용)
+type MemorySink struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+// NewMemorySink 메모리 싱크 생성
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Write(p []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, string(p))
+	return nil
+}
+
+func (s *MemorySink) Close() error { return nil }
+
+// Entries 기록된 로그 줄 목록 (줄바꿈 포함)
+func (s *MemorySink) Entries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.entries...)
+}
+
+// Reset 기록 비우기
+func (s *MemorySink) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = nil
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogHandler log/slog 레코드를 Logger로 기록하는 어댑터
+type slogHandler struct {
+	logger *Logger
+	group  string // WithGroup으로 지정된 키 접두사
+}
+
+// NewSlogHandler Logger로 기록하는 slog.Handler 생성
+func NewSlogHandler(l *Logger) slog.Handler {
+	return &slogHandler{logger: l}
+}
+
+// RedirectSlog slog 기본 로거(및 표준 log 패키지)를 기본 로거로 연결
+// 서드파티 코드의 slog/log 출력도 같은 형식과 싱크로 기록된다
+func RedirectSlog() {
+	slog.SetDefault(slog.New(NewSlogHandler(defaultLogger)))
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Enabled(fromSlogLevel(level))
+}
+
+// Handle 레코드 기록 (ctx에 요청 로거가 있으면 그 필드도 포함)
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	l := h.logger
+	if ctxLogger := FromContext(ctx); ctxLogger != defaultLogger && ctxLogger.core == l.core {
+		l = ctxLogger.With(l.fields...)
+	}
+
+	fields := make([]Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendAttr(fields, h.group, attr)
+		return true
+	})
+
+	l.write(fromSlogLevel(record.Level), record.Time, record.Message, fields)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]Field, 0, len(attrs))
+	for _, attr := range attrs {
+		fields = appendAttr(fields, h.group, attr)
+	}
+	return &slogHandler{logger: h.logger.With(fields...), group: h.group}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{logger: h.logger, group: h.group + name + "."}
+}
+
+// appendAttr slog 속성을 필드로 변환 (그룹은 group.key 형태로 펼침)
+func appendAttr(fields []Field, prefix string, attr slog.Attr) []Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, a := range attr.Value.Group() {
+			fields = appendAttr(fields, groupPrefix, a)
+		}
+		return fields
+	}
+
+	return append(fields, Any(prefix+attr.Key, attr.Value.Any()))
+}
+
+// fromSlogLevel slog 레벨을 로그 레벨로 변환 (slog에는 종료 의미가 없으므로 최대 ERROR)
+func fromSlogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelWarn:
+		return INFO
+	case level < slog.LevelError:
+		return WARN
+	default:
+		return ERROR
+	}
+}
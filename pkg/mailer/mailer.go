@@ -0,0 +1,34 @@
+// Package mailer 메일 발송
+//
+// 발송 방식은 Mailer 구현을 바꿔 끼운다.
+// 운영은 SMTP, 개발은 콘솔/파일(실제로 보내지 않고 내용만 기록), 테스트는 메모리 구현을 쓴다.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Message 보낼 메일 (본문은 UTF-8 텍스트)
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer 메일 발송 인터페이스
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// validate 헤더에 들어가는 값 검사 (줄바꿈으로 헤더를 끼워 넣지 못하게)
+func (m Message) validate() error {
+	if m.To == "" {
+		return fmt.Errorf("받는 사람이 없습니다")
+	}
+	if strings.ContainsAny(m.To, "\r\n") || strings.ContainsAny(m.Subject, "\r\n") {
+		return fmt.Errorf("메일 헤더에 줄바꿈을 넣을 수 없습니다")
+	}
+	return nil
+}
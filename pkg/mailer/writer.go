@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// WriterMailer 실제로 보내지 않고 메일 내용을 출력 (개발 환경에서 인증 링크 확인용)
+type WriterMailer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewConsoleMailer 메일 내용을 표준 출력에 기록
+func NewConsoleMailer() *WriterMailer {
+	return &WriterMailer{w: os.Stdout}
+}
+
+// NewFileMailer 메일 내용을 파일 끝에 이어서 기록 (파일이 없으면 생성)
+func NewFileMailer(path string) (*WriterMailer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("메일 파일 열기 실패: %w", err)
+	}
+	return &WriterMailer{w: f}, nil
+}
+
+// Send 메일 내용 기록
+func (m *WriterMailer) Send(ctx context.Context, msg Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := fmt.Fprintf(m.w, "----- mail %s -----\nTo: %s\nSubject: %s\n\n%s\n----- end -----\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	return err
+}
+
+// Close 파일 발송기면 파일 닫기
+func (m *WriterMailer) Close() error {
+	if f, ok := m.w.(*os.File); ok && f != os.Stdout {
+		return f.Close()
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Collector 수집 시점에 값을 계산해 기록하는 지표 묶음 (DB 풀, 런타임 등)
+type Collector interface {
+	Collect(w *Writer)
+}
+
+// CollectorFunc 함수형 Collector
+type CollectorFunc func(w *Writer)
+
+// Collect Collector 구현
+func (f CollectorFunc) Collect(w *Writer) { f(w) }
+
+// Registry 지표 등록소 (등록 순서대로 출력)
+type Registry struct {
+	mu         sync.Mutex
+	collectors []Collector
+	names      map[string]bool
+}
+
+// NewRegistry 빈 등록소 생성
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+// Register Collector 등록
+func (r *Registry) Register(c Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.collectors = append(r.collectors, c)
+}
+
+// reserve 지표 이름 중복 확인 (같은 이름을 두 번 등록하면 패닉 - 초기화 시점 실수)
+func (r *Registry) reserve(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names[name] {
+		panic("metrics: 중복된 지표 이름: " + name)
+	}
+	r.names[name] = true
+}
+
+// Gather 모든 지표를 텍스트 형식으로 기록
+func (r *Registry) Gather(w *Writer) {
+	r.mu.Lock()
+	collectors := append([]Collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	for _, c := range collectors {
+		c.Collect(w)
+	}
+}
+
+// NewCounterVec 레이블별 누적 카운터 등록
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	r.reserve(name)
+	v := &CounterVec{vec: newVec[*Counter](name, help, labelNames, func() *Counter { return &Counter{} })}
+	r.Register(v)
+	return v
+}
+
+// NewGaugeVec 레이블별 게이지 등록
+func (r *Registry) NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	r.reserve(name)
+	v := &GaugeVec{vec: newVec[*Gauge](name, help, labelNames, func() *Gauge { return &Gauge{} })}
+	r.Register(v)
+	return v
+}
+
+// NewHistogramVec 레이블별 히스토그램 등록 (buckets가 비어 있으면 DefBuckets)
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	r.reserve(name)
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	v := &HistogramVec{vec: newVec[*Histogram](name, help, labelNames, func() *Histogram { return newHistogram(buckets) })}
+	r.Register(v)
+	return v
+}
+
+// DefBuckets HTTP 응답 시간용 기본 구간 (초)
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// vec 레이블 값 조합별 지표 모음
+type vec[T any] struct {
+	name       string
+	help       string
+	labelNames []string
+	newChild   func() T
+
+	mu       sync.RWMutex
+	children map[string]*child[T]
+}
+
+// child 레이블 값 조합 하나의 지표
+type child[T any] struct {
+	labels []string
+	metric T
+}
+
+func newVec[T any](name, help string, labelNames []string, newChild func() T) *vec[T] {
+	return &vec[T]{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		newChild:   newChild,
+		children:   make(map[string]*child[T]),
+	}
+}
+
+// with 레이블 값 조합의 지표 반환 (없으면 생성)
+// 레이블 개수가 다르면 패닉 (호출 코드 실수)
+func (v *vec[T]) with(values []string) T {
+	if len(values) != len(v.labelNames) {
+		panic("metrics: " + v.name + " 레이블 개수가 맞지 않습니다")
+	}
+	key := strings.Join(values, "\xff")
+
+	v.mu.RLock()
+	c, ok := v.children[key]
+	v.mu.RUnlock()
+	if ok {
+		return c.metric
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if c, ok := v.children[key]; ok {
+		return c.metric
+	}
+	c = &child[T]{labels: append([]string(nil), values...), metric: v.newChild()}
+	v.children[key] = c
+	return c.metric
+}
+
+// sorted 레이블 값 순으로 정렬한 지표 목록 (출력 순서 고정)
+func (v *vec[T]) sorted() []*child[T] {
+	v.mu.RLock()
+	list := make([]*child[T], 0, len(v.children))
+	for _, c := range v.children {
+		list = append(list, c)
+	}
+	v.mu.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return strings.Join(list[i].labels, "\xff") < strings.Join(list[j].labels, "\xff")
+	})
+	return list
+}
+
+// labelPairs 레이블 이름과 값을 번갈아 나열
+func (v *vec[T]) labelPairs(values []string, extra ...string) []string {
+	pairs := make([]string, 0, len(values)*2+len(extra))
+	for i, name := range v.labelNames {
+		pairs = append(pairs, name, values[i])
+	}
+	return append(pairs, extra...)
+}
+
+// Counter 누적 카운터
+type Counter struct {
+	bits atomic.Uint64
+}
+
+// Inc 1 증가
+func (c *Counter) Inc() { c.Add(1) }
+
+// Add delta만큼 증가 (음수는 무시)
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	addFloat(&c.bits, delta)
+}
+
+// Value 현재 값
+func (c *Counter) Value() float64 { return math.Float64frombits(c.bits.Load()) }
+
+// CounterVec 레이블별 카운터
+type CounterVec struct {
+	*vec[*Counter]
+}
+
+// WithLabelValues 레이블 값 조합의 카운터
+func (v *CounterVec) WithLabelValues(values ...string) *Counter { return v.with(values) }
+
+// Collect Collector 구현
+func (v *CounterVec) Collect(w *Writer) {
+	w.Header(v.name, v.help, TypeCounter)
+	for _, c := range v.sorted() {
+		w.Sample(v.name, c.metric.Value(), v.labelPairs(c.labels)...)
+	}
+}
+
+// Gauge 증감 가능한 값
+type Gauge struct {
+	bits atomic.Uint64
+}
+
+// Set 값 설정
+func (g *Gauge) Set(value float64) { g.bits.Store(math.Float64bits(value)) }
+
+// Inc 1 증가
+func (g *Gauge) Inc() { addFloat(&g.bits, 1) }
+
+// Dec 1 감소
+func (g *Gauge) Dec() { addFloat(&g.bits, -1) }
+
+// Value 현재 값
+func (g *Gauge) Value() float64 { return math.Float64frombits(g.bits.Load()) }
+
+// GaugeVec 레이블별 게이지
+type GaugeVec struct {
+	*vec[*Gauge]
+}
+
+// WithLabelValues 레이블 값 조합의 게이지
+func (v *GaugeVec) WithLabelValues(values ...string) *Gauge { return v.with(values) }
+
+// Collect Collector 구현
+func (v *GaugeVec) Collect(w *Writer) {
+	w.Header(v.name, v.help, TypeGauge)
+	for _, c := range v.sorted() {
+		w.Sample(v.name, c.metric.Value(), v.labelPairs(c.labels)...)
+	}
+}
+
+// Histogram 값 분포 (구간별 누적 개수, 합계, 개수)
+type Histogram struct {
+	buckets []float64
+	counts  []atomic.Uint64 // 구간별 개수 (누적 아님), 마지막은 +Inf
+	sum     atomic.Uint64
+	count   atomic.Uint64
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	return &Histogram{buckets: buckets, counts: make([]atomic.Uint64, len(buckets)+1)}
+}
+
+// Observe 값 하나 기록
+func (h *Histogram) Observe(value float64) {
+	i := sort.SearchFloat64s(h.buckets, value)
+	h.counts[i].Add(1)
+	addFloat(&h.sum, value)
+	h.count.Add(1)
+}
+
+// HistogramVec 레이블별 히스토그램
+type HistogramVec struct {
+	*vec[*Histogram]
+}
+
+// WithLabelValues 레이블 값 조합의 히스토그램
+func (v *HistogramVec) WithLabelValues(values ...string) *Histogram { return v.with(values) }
+
+// Collect Collector 구현
+func (v *HistogramVec) Collect(w *Writer) {
+	w.Header(v.name, v.help, TypeHistogram)
+	for _, c := range v.sorted() {
+		h := c.metric
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += h.counts[i].Load()
+			w.Sample(v.name+"_bucket", float64(cumulative), v.labelPairs(c.labels, "le", formatFloat(upper))...)
+		}
+		cumulative += h.counts[len(h.buckets)].Load()
+		w.Sample(v.name+"_bucket", float64(cumulative), v.labelPairs(c.labels, "le", "+Inf")...)
+		w.Sample(v.name+"_sum", math.Float64frombits(h.sum.Load()), v.labelPairs(c.labels)...)
+		w.Sample(v.name+"_count", float64(h.count.Load()), v.labelPairs(c.labels)...)
+	}
+}
+
+// addFloat float64 비트를 담은 atomic 값에 delta 더하기
+func addFloat(bits *atomic.Uint64, delta float64) {
+	for {
+		old := bits.Load()
+		next := math.Float64bits(math.Float64frombits(old) + delta)
+		if bits.CompareAndSwap(old, next) {
+			return
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"runtime"
+	"time"
+)
+
+// RuntimeCollector Go 런타임 지표 (고루틴, 메모리, GC)
+func RuntimeCollector() Collector {
+	start := time.Now()
+
+	return CollectorFunc(func(w *Writer) {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+
+		w.Header("go_info", "Go 버전 정보", TypeGauge)
+		w.Sample("go_info", 1, "version", runtime.Version())
+
+		w.Gauge("go_goroutines", "실행 중인 고루틴 수", float64(runtime.NumGoroutine()))
+		w.Gauge("go_memstats_alloc_bytes", "할당되어 사용 중인 힙 메모리 (바이트)", float64(ms.Alloc))
+		w.Counter("go_memstats_alloc_bytes_total", "누적 힙 할당량 (바이트)", float64(ms.TotalAlloc))
+		w.Gauge("go_memstats_sys_bytes", "OS로부터 확보한 메모리 (바이트)", float64(ms.Sys))
+		w.Gauge("go_memstats_heap_inuse_bytes", "사용 중인 힙 span (바이트)", float64(ms.HeapInuse))
+		w.Gauge("go_memstats_heap_objects", "할당된 힙 객체 수", float64(ms.HeapObjects))
+		w.Counter("go_gc_cycles_total", "완료된 GC 횟수", float64(ms.NumGC))
+		w.Counter("go_gc_pause_seconds_total", "누적 GC 정지 시간 (초)", float64(ms.PauseTotalNs)/float64(time.Second))
+		w.Gauge("process_start_time_seconds", "프로세스 시작 시각 (유닉스 초)", float64(start.Unix()))
+	})
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// 지표 유형 (# TYPE 줄)
+const (
+	TypeCounter   = "counter"
+	TypeGauge     = "gauge"
+	TypeHistogram = "histogram"
+)
+
+// ContentType Prometheus 텍스트 형식 0.0.4
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Writer Prometheus 텍스트 형식 기록기
+type Writer struct {
+	buf bytes.Buffer
+}
+
+// Header 지표 설명(# HELP)과 유형(# TYPE) 기록 - 같은 이름의 Sample보다 먼저 호출
+func (w *Writer) Header(name, help, typ string) {
+	w.buf.WriteString("# HELP ")
+	w.buf.WriteString(name)
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	w.buf.WriteString("\n# TYPE ")
+	w.buf.WriteString(name)
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(typ)
+	w.buf.WriteByte('\n')
+}
+
+// Sample 값 한 줄 기록 (labels는 이름, 값을 번갈아 나열)
+func (w *Writer) Sample(name string, value float64, labels ...string) {
+	w.buf.WriteString(name)
+	if len(labels) >= 2 {
+		w.buf.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			w.buf.WriteString(labels[i])
+			w.buf.WriteString(`="`)
+			w.buf.WriteString(escapeLabel(labels[i+1]))
+			w.buf.WriteByte('"')
+		}
+		w.buf.WriteByte('}')
+	}
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(formatFloat(value))
+	w.buf.WriteByte('\n')
+}
+
+// Gauge 값 하나짜리 게이지 기록
+func (w *Writer) Gauge(name, help string, value float64) {
+	w.Header(name, help, TypeGauge)
+	w.Sample(name, value)
+}
+
+// Counter 값 하나짜리 카운터 기록
+func (w *Writer) Counter(name, help string, value float64) {
+	w.Header(name, help, TypeCounter)
+	w.Sample(name, value)
+}
+
+// Bytes 기록된 내용
+func (w *Writer) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// escapeLabel 레이블 값 이스케이프 (\, ", 줄바꿈)
+func escapeLabel(s string) string {
+	if !strings.ContainsAny(s, "\\\"\n") {
+		return s
+	}
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// formatFloat 지표 값 표기 (+Inf, -Inf, NaN 포함)
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Handler 등록소의 지표를 텍스트 형식으로 응답하는 http.Handler
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		var w Writer
+		r.Gather(&w)
+
+		rw.Header().Set("Content-Type", ContentType)
+		rw.Write(w.Bytes())
+	})
+}
@@ -0,0 +1,109 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// clockSkew 제공자와 시계 차이로 허용하는 시간 (exp, iat, nbf)
+const clockSkew = time.Minute
+
+// signingMethods ID 토큰에 허용하는 서명 방식 (비대칭 키만 - none, HS256 거부)
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// userClaims ID 토큰, 사용자 정보의 OIDC 표준 클레임
+type userClaims struct {
+	Subject       string   `json:"sub"`
+	Email         string   `json:"email"`
+	EmailVerified flexBool `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// identity 클레임을 Identity로 변환
+func (c userClaims) identity() *Identity {
+	return &Identity{
+		Subject:       c.Subject,
+		Email:         c.Email,
+		EmailVerified: c.Email != "" && bool(c.EmailVerified),
+		Name:          c.Name,
+	}
+}
+
+// idTokenClaims ID 토큰 클레임 (sub는 RegisteredClaims)
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Email           string   `json:"email"`
+	EmailVerified   flexBool `json:"email_verified"`
+	Name            string   `json:"name"`
+	Nonce           string   `json:"nonce"`
+	AuthorizedParty string   `json:"azp"`
+}
+
+// VerifyIDToken ID 토큰 검증 (서명, iss, aud, azp, exp, iat, nbf, nonce)
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (*Identity, error) {
+	cfg, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Issuer == "" || p.keys == nil {
+		return nil, fmt.Errorf("oauth: %s 제공자의 issuer나 JWKS 주소가 없어 ID 토큰을 검증할 수 없습니다", cfg.Name)
+	}
+
+	parser := jwt.NewParser(jwt.WithValidMethods(signingMethods), jwt.WithoutClaimsValidation())
+	var claims idTokenClaims
+	_, err = parser.ParseWithClaims(raw, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.keys.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("oauth: ID 토큰 검증 실패: %w", err)
+	}
+
+	now := time.Now()
+	switch {
+	case claims.Issuer != cfg.Issuer:
+		return nil, fmt.Errorf("oauth: ID 토큰 issuer가 다릅니다: %s", claims.Issuer)
+	case !claims.VerifyAudience(cfg.ClientID, true):
+		return nil, fmt.Errorf("oauth: ID 토큰 audience에 client_id가 없습니다")
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != cfg.ClientID:
+		return nil, fmt.Errorf("oauth: ID 토큰 azp가 client_id와 다릅니다")
+	case claims.ExpiresAt == nil || !claims.VerifyExpiresAt(now.Add(-clockSkew), true):
+		return nil, fmt.Errorf("oauth: 만료된 ID 토큰입니다")
+	case !claims.VerifyIssuedAt(now.Add(clockSkew), false) || !claims.VerifyNotBefore(now.Add(clockSkew), false):
+		return nil, fmt.Errorf("oauth: 아직 사용할 수 없는 ID 토큰입니다")
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("oauth: ID 토큰 nonce가 다릅니다")
+	case claims.Subject == "":
+		return nil, fmt.Errorf("oauth: ID 토큰에 sub가 없습니다")
+	}
+
+	return userClaims{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}.identity(), nil
+}
+
+// flexBool true/false와 "true"/"false"를 모두 받는 bool (email_verified를 문자열로 주는 제공자가 있음)
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = flexBool(v)
+	case string:
+		*b = flexBool(v == "true")
+	default:
+		*b = false
+	}
+	return nil
+}
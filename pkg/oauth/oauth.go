@@ -0,0 +1,294 @@
+// Package oauth OAuth2 인가 코드 방식 로그인 (PKCE, OIDC ID 토큰 검증)
+//
+// 제공자마다 Provider를 하나씩 만든다. Issuer를 지정하면 첫 사용 때
+// /.well-known/openid-configuration에서 인가, 토큰, 사용자 정보, JWKS 주소를 읽어 온다
+// (직접 지정한 주소가 우선).
+//
+// 로그인 흐름:
+//  1. state, nonce, PKCE verifier를 만들어 저장하고 AuthCodeURL로 제공자에게 보낸다
+//  2. 콜백에서 state를 확인한 뒤 Exchange로 코드를 토큰으로 바꾼다
+//  3. Identity로 ID 토큰(서명, iss, aud, exp, nonce)을 검증하고 사용자 정보를 얻는다
+//     ID 토큰이 없으면 사용자 정보 주소(UserInfoURL)에서 읽는다
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxResponseBytes 제공자 응답 최대 크기
+const maxResponseBytes = 1 << 20
+
+// ErrNoIdentity ID 토큰도 사용자 정보 주소도 없어 사용자를 알 수 없음
+var ErrNoIdentity = errors.New("oauth: ID 토큰과 사용자 정보 주소가 모두 없습니다")
+
+// Config 제공자 설정
+type Config struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	Issuer       string   // OIDC issuer (ID 토큰의 iss와 비교, 디스커버리 주소)
+	AuthURL      string   // 인가 주소 (비우면 디스커버리)
+	TokenURL     string   // 토큰 주소 (비우면 디스커버리)
+	UserInfoURL  string   // 사용자 정보 주소 (비우면 디스커버리)
+	JWKSURL      string   // ID 토큰 서명 키 주소 (비우면 디스커버리)
+	RedirectURL  string   // 콜백 주소 (제공자에 등록한 값과 같아야 함)
+	Scopes       []string // 요청 범위 (ID 토큰을 받으려면 openid)
+	HTTPClient   *http.Client
+}
+
+// Token 토큰 주소 응답
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Identity 제공자가 확인한 사용자
+type Identity struct {
+	Subject       string // 제공자 안에서 바뀌지 않는 사용자 ID (sub)
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider OAuth2/OIDC 제공자 (여러 요청이 함께 써도 됨)
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu         sync.Mutex
+	discovered bool
+	keys       *keySet
+}
+
+// New 제공자 생성 (네트워크 요청은 처음 사용할 때)
+func New(cfg Config) *Provider {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+
+	return &Provider{cfg: cfg, client: client}
+}
+
+// Name 제공자 이름
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// RedirectURL 콜백 주소
+func (p *Provider) RedirectURL() string {
+	return p.cfg.RedirectURL
+}
+
+// AuthCodeURL 사용자를 보낼 인가 주소 (verifier는 NewVerifier로 만들어 콜백까지 보관)
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	cfg, err := p.config(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", cfg.ClientID)
+	q.Set("redirect_uri", cfg.RedirectURL)
+	q.Set("state", state)
+	q.Set("code_challenge", Challenge(verifier))
+	q.Set("code_challenge_method", "S256")
+	if len(cfg.Scopes) > 0 {
+		q.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+	if nonce != "" {
+		q.Set("nonce", nonce)
+	}
+
+	sep := "?"
+	if strings.Contains(cfg.AuthURL, "?") {
+		sep = "&"
+	}
+	return cfg.AuthURL + sep + q.Encode(), nil
+}
+
+// Exchange 인가 코드를 토큰으로 교환
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	cfg, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", cfg.RedirectURL)
+	form.Set("code_verifier", verifier)
+	form.Set("client_id", cfg.ClientID)
+	if cfg.ClientSecret != "" {
+		form.Set("client_secret", cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token Token
+	if err := doJSON(p.client, req, &token); err != nil {
+		return nil, fmt.Errorf("oauth: 토큰 교환 실패: %w", err)
+	}
+	if token.AccessToken == "" && token.IDToken == "" {
+		return nil, fmt.Errorf("oauth: 토큰 응답에 토큰이 없습니다")
+	}
+	return &token, nil
+}
+
+// Identity 토큰으로 사용자 확인
+// ID 토큰이 있으면 검증해서 쓰고, 이메일이 빠져 있으면 사용자 정보 주소에서 채운다 (sub가 같을 때만)
+// Issuer가 없는 제공자(순수 OAuth2)는 ID 토큰을 확인할 수 없으므로 사용자 정보 주소만 쓴다
+func (p *Provider) Identity(ctx context.Context, token *Token, nonce string) (*Identity, error) {
+	cfg, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.IDToken == "" || cfg.Issuer == "" {
+		if cfg.UserInfoURL == "" || token.AccessToken == "" {
+			return nil, ErrNoIdentity
+		}
+		return p.userInfo(ctx, cfg.UserInfoURL, token.AccessToken)
+	}
+
+	identity, err := p.VerifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity.Email == "" && cfg.UserInfoURL != "" && token.AccessToken != "" {
+		info, err := p.userInfo(ctx, cfg.UserInfoURL, token.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		if info.Subject != identity.Subject {
+			return nil, fmt.Errorf("oauth: 사용자 정보의 sub가 ID 토큰과 다릅니다")
+		}
+		identity.Email, identity.EmailVerified = info.Email, info.EmailVerified
+		if identity.Name == "" {
+			identity.Name = info.Name
+		}
+	}
+	return identity, nil
+}
+
+// userInfo 사용자 정보 주소 조회 (OIDC 표준 클레임)
+func (p *Provider) userInfo(ctx context.Context, endpoint, accessToken string) (*Identity, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var claims userClaims
+	if err := doJSON(p.client, req, &claims); err != nil {
+		return nil, fmt.Errorf("oauth: 사용자 정보 조회 실패: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("oauth: 사용자 정보에 sub가 없습니다")
+	}
+	return claims.identity(), nil
+}
+
+// config 디스커버리로 채운 설정
+func (p *Provider) config(ctx context.Context) (Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.discovered {
+		if p.cfg.Issuer != "" && (p.cfg.AuthURL == "" || p.cfg.TokenURL == "" || p.cfg.JWKSURL == "" || p.cfg.UserInfoURL == "") {
+			if err := p.discover(ctx); err != nil {
+				return Config{}, err
+			}
+		}
+		if p.cfg.AuthURL == "" || p.cfg.TokenURL == "" {
+			return Config{}, fmt.Errorf("oauth: %s 제공자의 인가/토큰 주소가 없습니다", p.cfg.Name)
+		}
+		if p.cfg.JWKSURL != "" {
+			p.keys = newKeySet(p.cfg.JWKSURL, p.client)
+		}
+		p.discovered = true
+	}
+	return p.cfg, nil
+}
+
+// discover OIDC 디스커버리 문서로 비어 있는 주소 채우기
+func (p *Provider) discover(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var doc struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserInfoEndpoint      string `json:"userinfo_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+	if err := doJSON(p.client, req, &doc); err != nil {
+		return fmt.Errorf("oauth: %s 디스커버리 실패: %w", p.cfg.Name, err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != p.cfg.Issuer {
+		return fmt.Errorf("oauth: %s 디스커버리의 issuer가 다릅니다: %s", p.cfg.Name, doc.Issuer)
+	}
+
+	fill := func(dst *string, value string) {
+		if *dst == "" {
+			*dst = value
+		}
+	}
+	fill(&p.cfg.AuthURL, doc.AuthorizationEndpoint)
+	fill(&p.cfg.TokenURL, doc.TokenEndpoint)
+	fill(&p.cfg.UserInfoURL, doc.UserInfoEndpoint)
+	fill(&p.cfg.JWKSURL, doc.JWKSURI)
+	return nil
+}
+
+// doJSON 요청을 보내고 JSON 응답 읽기 (2xx가 아니면 error, error_description 포함)
+func doJSON(client *http.Client, req *http.Request, dst interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var oauthErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error != "" {
+			return fmt.Errorf("%d %s: %s", resp.StatusCode, oauthErr.Error, oauthErr.Description)
+		}
+		return fmt.Errorf("응답 코드 %d", resp.StatusCode)
+	}
+
+	return json.Unmarshal(body, dst)
+}
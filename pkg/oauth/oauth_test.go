@@ -0,0 +1,302 @@
+package oauth_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gin_starter/pkg/oauth"
+	"gin_starter/pkg/oauth/oauthtest"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	clientID    = "test-client"
+	redirectURL = "https://app.example.com/api/user/oauth/test/callback"
+)
+
+var testUser = oauthtest.User{Subject: "sub-1", Email: "user@example.com", EmailVerified: true, Name: "Tester"}
+
+func newProvider(idp *oauthtest.Server) *oauth.Provider {
+	return oauth.New(oauth.Config{
+		Name:        "test",
+		ClientID:    clientID,
+		Issuer:      idp.URL,
+		RedirectURL: redirectURL,
+		Scopes:      []string{"openid", "email", "profile"},
+	})
+}
+
+// login 인가 주소 생성부터 인가 코드까지 (콜백의 code 반환)
+func login(t *testing.T, p *oauth.Provider, idp *oauthtest.Server, state, nonce, verifier string) string {
+	t.Helper()
+
+	authURL, err := p.AuthCodeURL(context.Background(), state, nonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	callback, err := idp.Authorize(authURL, testUser)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+
+	u, _ := url.Parse(callback)
+	if got := u.Query().Get("state"); got != state {
+		t.Fatalf("콜백 state = %q, want %q", got, state)
+	}
+	return u.Query().Get("code")
+}
+
+func TestChallenge(t *testing.T) {
+	// RFC 7636 부록 B
+	got := oauth.Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("Challenge = %q, want %q", got, want)
+	}
+
+	verifier, err := oauth.NewVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(verifier) < 43 || len(verifier) > 128 {
+		t.Errorf("verifier 길이 %d, RFC 7636은 43~128자", len(verifier))
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	idp := oauthtest.NewServer(t, clientID)
+	p := newProvider(idp)
+
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(authURL, idp.URL+"/authorize?") {
+		t.Fatalf("디스커버리의 인가 주소를 쓰지 않음: %s", authURL)
+	}
+
+	u, _ := url.Parse(authURL)
+	q := u.Query()
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             clientID,
+		"redirect_uri":          redirectURL,
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"scope":                 "openid email profile",
+		"code_challenge":        oauth.Challenge("verifier-1"),
+		"code_challenge_method": "S256",
+	}
+	for key, value := range want {
+		if got := q.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+	if q.Has("code_verifier") {
+		t.Error("인가 주소에 code_verifier가 노출됨")
+	}
+}
+
+func TestLoginFlow(t *testing.T) {
+	idp := oauthtest.NewServer(t, clientID)
+	p := newProvider(idp)
+	ctx := context.Background()
+
+	verifier, _ := oauth.NewVerifier()
+	code := login(t, p, idp, "state-1", "nonce-1", verifier)
+
+	token, err := p.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if token.IDToken == "" {
+		t.Fatal("ID 토큰이 없음")
+	}
+
+	identity, err := p.Identity(ctx, token, "nonce-1")
+	if err != nil {
+		t.Fatalf("Identity: %v", err)
+	}
+	want := oauth.Identity{Subject: testUser.Subject, Email: testUser.Email, EmailVerified: true, Name: testUser.Name}
+	if *identity != want {
+		t.Errorf("Identity = %+v, want %+v", *identity, want)
+	}
+}
+
+func TestExchangePKCE(t *testing.T) {
+	idp := oauthtest.NewServer(t, clientID)
+	p := newProvider(idp)
+	ctx := context.Background()
+
+	verifier, _ := oauth.NewVerifier()
+	code := login(t, p, idp, "state-1", "nonce-1", verifier)
+
+	other, _ := oauth.NewVerifier()
+	if _, err := p.Exchange(ctx, code, other); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("다른 verifier로 교환: err = %v, want invalid_grant", err)
+	}
+
+	// 코드는 실패한 교환에서도 소모됨
+	if _, err := p.Exchange(ctx, code, verifier); err == nil {
+		t.Fatal("이미 사용한 코드로 교환됨")
+	}
+}
+
+func TestIdentityUserInfo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ID 토큰에 이메일이 없으면 사용자 정보 주소", func(t *testing.T) {
+		idp := oauthtest.NewServer(t, clientID)
+		idp.NoIDTokenEmail = true
+		p := newProvider(idp)
+
+		verifier, _ := oauth.NewVerifier()
+		token, err := p.Exchange(ctx, login(t, p, idp, "s", "n", verifier), verifier)
+		if err != nil {
+			t.Fatal(err)
+		}
+		identity, err := p.Identity(ctx, token, "n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if identity.Email != testUser.Email || !identity.EmailVerified {
+			t.Errorf("사용자 정보 주소의 이메일을 쓰지 않음: %+v", identity)
+		}
+	})
+
+	t.Run("ID 토큰이 없으면 사용자 정보 주소", func(t *testing.T) {
+		idp := oauthtest.NewServer(t, clientID)
+		idp.NoIDToken = true
+		p := newProvider(idp)
+
+		verifier, _ := oauth.NewVerifier()
+		token, err := p.Exchange(ctx, login(t, p, idp, "s", "n", verifier), verifier)
+		if err != nil {
+			t.Fatal(err)
+		}
+		identity, err := p.Identity(ctx, token, "n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if identity.Subject != testUser.Subject {
+			t.Errorf("Subject = %q, want %q", identity.Subject, testUser.Subject)
+		}
+	})
+
+	t.Run("사용자 정보의 sub가 다르면 거부", func(t *testing.T) {
+		idp := oauthtest.NewServer(t, clientID)
+		idp.NoIDTokenEmail = true
+		idp.IDTokenClaims = func(claims jwt.MapClaims) { claims["sub"] = "someone-else" }
+		p := newProvider(idp)
+
+		verifier, _ := oauth.NewVerifier()
+		token, err := p.Exchange(ctx, login(t, p, idp, "s", "n", verifier), verifier)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.Identity(ctx, token, "n"); err == nil {
+			t.Fatal("sub가 다른 사용자 정보를 받아들임")
+		}
+	})
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	idp := oauthtest.NewServer(t, clientID)
+	p := newProvider(idp)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		modify func(claims jwt.MapClaims)
+		nonce  string
+		ok     bool
+	}{
+		{name: "정상", nonce: "nonce-1", ok: true},
+		{name: "nonce 다름", nonce: "nonce-2"},
+		{name: "nonce 없음", modify: func(c jwt.MapClaims) { delete(c, "nonce") }, nonce: "nonce-1"},
+		{name: "aud 다름", modify: func(c jwt.MapClaims) { c["aud"] = "other-client" }, nonce: "nonce-1"},
+		{name: "aud 여러 개 (azp 없음)", modify: func(c jwt.MapClaims) { c["aud"] = []string{clientID, "other-client"} }, nonce: "nonce-1"},
+		{name: "aud 여러 개 (azp 일치)", modify: func(c jwt.MapClaims) {
+			c["aud"] = []string{clientID, "other-client"}
+			c["azp"] = clientID
+		}, nonce: "nonce-1", ok: true},
+		{name: "iss 다름", modify: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, nonce: "nonce-1"},
+		{name: "만료", modify: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-2 * time.Minute).Unix() }, nonce: "nonce-1"},
+		{name: "시계 차이 안의 만료", modify: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-30 * time.Second).Unix() }, nonce: "nonce-1", ok: true},
+		{name: "exp 없음", modify: func(c jwt.MapClaims) { delete(c, "exp") }, nonce: "nonce-1"},
+		{name: "미래의 iat", modify: func(c jwt.MapClaims) { c["iat"] = time.Now().Add(time.Hour).Unix() }, nonce: "nonce-1"},
+		{name: "sub 없음", modify: func(c jwt.MapClaims) { delete(c, "sub") }, nonce: "nonce-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := idp.Claims(testUser, "nonce-1")
+			if tt.modify != nil {
+				tt.modify(claims)
+			}
+
+			_, err := p.VerifyIDToken(ctx, idp.SignIDToken(claims), tt.nonce)
+			if tt.ok && err != nil {
+				t.Fatalf("err = %v, want nil", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("검증을 통과함")
+			}
+		})
+	}
+}
+
+func TestVerifyIDTokenSignature(t *testing.T) {
+	idp := oauthtest.NewServer(t, clientID)
+	p := newProvider(idp)
+	ctx := context.Background()
+	claims := idp.Claims(testUser, "nonce-1")
+
+	t.Run("다른 키로 서명", func(t *testing.T) {
+		other := oauthtest.NewServer(t, clientID)
+		if _, err := p.VerifyIDToken(ctx, other.SignIDToken(claims), "nonce-1"); err == nil {
+			t.Fatal("JWKS에 없는 키의 서명을 받아들임")
+		}
+	})
+
+	t.Run("HS256", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token.Header["kid"] = idp.KeyID
+		signed, _ := token.SignedString([]byte(clientID))
+		if _, err := p.VerifyIDToken(ctx, signed, "nonce-1"); err == nil {
+			t.Fatal("대칭 키 서명을 받아들임")
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+		signed, _ := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		if _, err := p.VerifyIDToken(ctx, signed, "nonce-1"); err == nil {
+			t.Fatal("서명 없는 토큰을 받아들임")
+		}
+	})
+
+	t.Run("변조", func(t *testing.T) {
+		signed := idp.SignIDToken(claims)
+		parts := strings.Split(signed, ".")
+		forged := idp.Claims(oauthtest.User{Subject: "admin"}, "nonce-1")
+		payload, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, forged).SigningString()
+		parts[1] = strings.Split(payload, ".")[1]
+		if _, err := p.VerifyIDToken(ctx, strings.Join(parts, "."), "nonce-1"); err == nil {
+			t.Fatal("내용을 바꾼 토큰을 받아들임")
+		}
+	})
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	idp := oauthtest.NewServer(t, clientID)
+	idp.Issuer = "https://evil.example.com"
+	p := newProvider(idp)
+
+	if _, err := p.AuthCodeURL(context.Background(), "s", "n", "v"); err == nil {
+		t.Fatal("issuer가 다른 디스커버리 문서를 받아들임")
+	}
+}
@@ -0,0 +1,288 @@
+// Package oauthtest 테스트용 OIDC 제공자
+//
+// httptest 서버로 디스커버리, JWKS, 토큰, 사용자 정보 주소를 제공한다.
+// 인가 화면은 띄우지 않고 Authorize로 사용자가 로그인을 마친 것처럼 인가 코드를 발급한다.
+//
+//	idp := oauthtest.NewServer(t, "client")
+//	provider := oauth.New(oauth.Config{Issuer: idp.URL, ClientID: "client", RedirectURL: "https://app/callback"})
+//	authURL, _ := provider.AuthCodeURL(ctx, state, nonce, verifier)
+//	callback, _ := idp.Authorize(authURL, oauthtest.User{Subject: "u1", Email: "u1@example.com", EmailVerified: true})
+package oauthtest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// User 제공자에서 로그인한 사용자
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Server 테스트용 OIDC 제공자 (테스트가 끝나면 닫힘)
+type Server struct {
+	*httptest.Server
+	ClientID string
+	KeyID    string
+	Key      *rsa.PrivateKey
+
+	// Issuer 디스커버리 문서와 ID 토큰의 iss (비우면 서버 주소)
+	Issuer string
+	// IDTokenClaims 토큰 주소가 발급하는 ID 토큰 클레임 변경 (nonce, aud 조작 등)
+	IDTokenClaims func(claims jwt.MapClaims)
+	// NoIDToken 토큰 응답에 ID 토큰을 넣지 않음 (순수 OAuth2 제공자)
+	NoIDToken bool
+	// NoIDTokenEmail ID 토큰에 이메일을 넣지 않음 (사용자 정보 주소에서 채우는 제공자)
+	NoIDTokenEmail bool
+
+	mu     sync.Mutex
+	grants map[string]grant // 인가 코드 -> 발급 정보 (한 번만 사용)
+	tokens map[string]User  // 액세스 토큰 -> 사용자
+}
+
+// grant 인가 코드 발급 정보
+type grant struct {
+	user        User
+	redirectURI string
+	challenge   string
+	nonce       string
+}
+
+// NewServer 제공자 시작 (RSA 서명 키 생성)
+func NewServer(t testing.TB, clientID string) *Server {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("oauthtest: 서명 키 생성 실패: %v", err)
+	}
+
+	s := &Server{
+		ClientID: clientID,
+		KeyID:    "test-key",
+		Key:      key,
+		grants:   make(map[string]grant),
+		tokens:   make(map[string]User),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/userinfo", s.userInfo)
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Authorize 사용자가 인가 화면에서 로그인을 마친 것처럼 인가 코드 발급
+// authURL은 Provider.AuthCodeURL이 만든 주소, 반환값은 code, state를 붙인 콜백 주소
+func (s *Server) Authorize(authURL string, user User) (string, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+
+	switch {
+	case q.Get("response_type") != "code":
+		return "", fmt.Errorf("oauthtest: response_type이 code가 아닙니다: %q", q.Get("response_type"))
+	case q.Get("client_id") != s.ClientID:
+		return "", fmt.Errorf("oauthtest: 알 수 없는 client_id: %q", q.Get("client_id"))
+	case q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "":
+		return "", fmt.Errorf("oauthtest: PKCE S256 code_challenge가 없습니다")
+	case q.Get("redirect_uri") == "" || q.Get("state") == "":
+		return "", fmt.Errorf("oauthtest: redirect_uri, state가 없습니다")
+	}
+
+	code := randomString()
+	s.mu.Lock()
+	s.grants[code] = grant{
+		user:        user,
+		redirectURI: q.Get("redirect_uri"),
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+	}
+	s.mu.Unlock()
+
+	callback, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		return "", err
+	}
+	cq := callback.Query()
+	cq.Set("code", code)
+	cq.Set("state", q.Get("state"))
+	callback.RawQuery = cq.Encode()
+	return callback.String(), nil
+}
+
+// Claims user의 기본 ID 토큰 클레임 (iss, aud, exp, iat, nonce)
+func (s *Server) Claims(user User, nonce string) jwt.MapClaims {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": s.issuer(),
+		"sub": user.Subject,
+		"aud": s.ClientID,
+		"exp": now.Add(time.Hour).Unix(),
+		"iat": now.Unix(),
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	if user.Email != "" {
+		claims["email"] = user.Email
+		claims["email_verified"] = user.EmailVerified
+	}
+	if user.Name != "" {
+		claims["name"] = user.Name
+	}
+	return claims
+}
+
+// SignIDToken 클레임을 제공자 키로 서명 (RS256, kid 헤더)
+func (s *Server) SignIDToken(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.KeyID
+	signed, err := token.SignedString(s.Key)
+	if err != nil {
+		panic(fmt.Sprintf("oauthtest: ID 토큰 서명 실패: %v", err))
+	}
+	return signed
+}
+
+func (s *Server) issuer() string {
+	if s.Issuer != "" {
+		return s.Issuer
+	}
+	return s.URL
+}
+
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 s.issuer(),
+		"authorization_endpoint": s.URL + "/authorize",
+		"token_endpoint":         s.URL + "/token",
+		"userinfo_endpoint":      s.URL + "/userinfo",
+		"jwks_uri":               s.URL + "/jwks",
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := s.Key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": s.KeyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// token 인가 코드 교환 (코드는 한 번만, redirect_uri와 PKCE verifier 확인)
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		tokenError(w, "invalid_request", "POST 폼이 아닙니다")
+		return
+	}
+
+	s.mu.Lock()
+	g, ok := s.grants[r.PostForm.Get("code")]
+	delete(s.grants, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case r.PostForm.Get("grant_type") != "authorization_code":
+		tokenError(w, "unsupported_grant_type", r.PostForm.Get("grant_type"))
+		return
+	case r.PostForm.Get("client_id") != s.ClientID:
+		tokenError(w, "invalid_client", "알 수 없는 client_id")
+		return
+	case !ok:
+		tokenError(w, "invalid_grant", "알 수 없거나 이미 사용한 코드")
+		return
+	case r.PostForm.Get("redirect_uri") != g.redirectURI:
+		tokenError(w, "invalid_grant", "redirect_uri가 다릅니다")
+		return
+	case base64.RawURLEncoding.EncodeToString(verifier[:]) != g.challenge:
+		tokenError(w, "invalid_grant", "code_verifier가 다릅니다")
+		return
+	}
+
+	accessToken := randomString()
+	s.mu.Lock()
+	s.tokens[accessToken] = g.user
+	s.mu.Unlock()
+
+	resp := map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	}
+	if !s.NoIDToken {
+		claims := s.Claims(g.user, g.nonce)
+		if s.NoIDTokenEmail {
+			delete(claims, "email")
+			delete(claims, "email_verified")
+		}
+		if s.IDTokenClaims != nil {
+			s.IDTokenClaims(claims)
+		}
+		resp["id_token"] = s.SignIDToken(claims)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) userInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	user, ok := s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	s.mu.Unlock()
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sub":            user.Subject,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"name":           user.Name,
+	})
+}
+
+func tokenError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": description})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
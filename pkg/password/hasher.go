@@ -0,0 +1,150 @@
+// Package password 비밀번호 해싱(bcrypt, argon2id)과 정책 검사
+//
+// 해시 문자열에 알고리즘과 파라미터가 들어 있으므로 설정을 바꿔도 이전 해시를 그대로 검증할 수 있고,
+// NeedsRehash로 현재 설정과 다른 해시를 찾아 로그인할 때 다시 해싱한다.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgBcrypt   = "bcrypt"
+	AlgArgon2id = "argon2id"
+)
+
+// ErrUnknownHash 알 수 없는 해시 형식
+var ErrUnknownHash = errors.New("알 수 없는 비밀번호 해시 형식")
+
+// Argon2Params argon2id 파라미터
+type Argon2Params struct {
+	Memory  uint32 // KiB
+	Time    uint32 // 반복 횟수
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+// DefaultArgon2Params OWASP 권장값 (64MiB, 3회, 2스레드)
+var DefaultArgon2Params = Argon2Params{Memory: 64 * 1024, Time: 3, Threads: 2, KeyLen: 32, SaltLen: 16}
+
+// Hasher 설정된 알고리즘으로 해싱하고, 해시 형식을 보고 알맞은 알고리즘으로 검증
+type Hasher struct {
+	algorithm  string
+	bcryptCost int
+	argon2     Argon2Params
+}
+
+// NewHasher 해셔 생성 (algorithm: bcrypt, argon2id)
+func NewHasher(algorithm string, bcryptCost int, argon2 Argon2Params) *Hasher {
+	if argon2.KeyLen == 0 {
+		argon2.KeyLen = DefaultArgon2Params.KeyLen
+	}
+	if argon2.SaltLen == 0 {
+		argon2.SaltLen = DefaultArgon2Params.SaltLen
+	}
+	return &Hasher{algorithm: algorithm, bcryptCost: bcryptCost, argon2: argon2}
+}
+
+// Hash 현재 설정으로 해싱
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.algorithm == AlgArgon2id {
+		return h.hashArgon2id(password)
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// Verify 비밀번호 확인 (해시 형식으로 알고리즘 판단)
+func (h *Hasher) Verify(hash, password string) (bool, error) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+		other := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+		return subtle.ConstantTimeCompare(key, other) == 1, nil
+
+	case strings.HasPrefix(hash, "$2"):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+
+	default:
+		return false, ErrUnknownHash
+	}
+}
+
+// NeedsRehash 현재 설정과 알고리즘이나 파라미터가 다른 해시인지
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if h.algorithm == AlgArgon2id {
+		params, _, _, err := decodeArgon2id(hash)
+		if err != nil {
+			return true
+		}
+		return params.Memory != h.argon2.Memory || params.Time != h.argon2.Time || params.Threads != h.argon2.Threads
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.bcryptCost
+}
+
+// hashArgon2id PHC 문자열 형식 ($argon2id$v=19$m=65536,t=3,p=2$salt$hash)
+func (h *Hasher) hashArgon2id(password string) (string, error) {
+	p := h.argon2
+
+	salt := make([]byte, p.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// decodeArgon2id PHC 문자열에서 파라미터, 솔트, 해시 추출
+func decodeArgon2id(hash string) (Argon2Params, []byte, []byte, error) {
+	var p Argon2Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgArgon2id {
+		return p, nil, nil, ErrUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrUnknownHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return p, nil, nil, ErrUnknownHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, ErrUnknownHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, ErrUnknownHash
+	}
+
+	p.SaltLen = uint32(len(salt))
+	p.KeyLen = uint32(len(key))
+	return p, salt, key, nil
+}
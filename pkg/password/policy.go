@@ -0,0 +1,155 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 정책 위반 코드
+const (
+	CodeTooShort = "PASSWORD_TOO_SHORT"
+	CodeTooLong  = "PASSWORD_TOO_LONG"
+	CodeTooWeak  = "PASSWORD_TOO_SIMPLE"        // 문자 종류 부족
+	CodeCommon   = "PASSWORD_COMMON"            // 흔히 쓰는 비밀번호
+	CodeIdentity = "PASSWORD_CONTAINS_IDENTITY" // 아이디, 이메일 포함
+	CodeReused   = "PASSWORD_REUSED"            // 최근 사용한 비밀번호
+)
+
+//go:embed common.txt
+var commonPasswords string
+
+// Rules 비밀번호 정책
+type Rules struct {
+	MinLength        int      // 최소 글자 수
+	MaxLength        int      // 최대 글자 수 (0이면 제한 없음)
+	MaxBytes         int      // 최대 바이트 (bcrypt는 72바이트까지만 사용, 0이면 제한 없음)
+	MinClasses       int      // 소문자, 대문자, 숫자, 특수문자 중 최소 종류 수
+	DisallowIdentity bool     // 아이디, 이메일 포함 금지
+	Blocklist        []string // 내장 목록에 더할 금지 비밀번호
+}
+
+// Violation 정책 위반 항목
+type Violation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Policy 비밀번호 정책 검사기
+type Policy struct {
+	rules     Rules
+	blocklist map[string]struct{}
+}
+
+// NewPolicy 정책 생성 (내장 흔한 비밀번호 목록 + rules.Blocklist)
+func NewPolicy(rules Rules) *Policy {
+	p := &Policy{rules: rules, blocklist: make(map[string]struct{})}
+	for _, line := range strings.Split(commonPasswords, "\n") {
+		p.block(line)
+	}
+	for _, word := range rules.Blocklist {
+		p.block(word)
+	}
+	return p
+}
+
+// Check 정책 검사 (identity: 포함하면 안 되는 아이디, 이메일 등)
+// 위반 항목이 없으면 nil
+func (p *Policy) Check(password string, identity ...string) []Violation {
+	var violations []Violation
+	r := p.rules
+
+	length := utf8.RuneCountInString(password)
+	if length < r.MinLength {
+		violations = append(violations, Violation{CodeTooShort, fmt.Sprintf("비밀번호는 %d자 이상이어야 합니다", r.MinLength)})
+	}
+	if (r.MaxLength > 0 && length > r.MaxLength) || (r.MaxBytes > 0 && len(password) > r.MaxBytes) {
+		violations = append(violations, Violation{CodeTooLong, "비밀번호가 너무 깁니다"})
+	}
+
+	if classes := countClasses(password); classes < r.MinClasses {
+		violations = append(violations, Violation{CodeTooWeak,
+			fmt.Sprintf("영문 소문자, 대문자, 숫자, 특수문자 중 %d종류 이상을 사용해야 합니다", r.MinClasses)})
+	}
+
+	lower := strings.ToLower(password)
+	if _, ok := p.blocklist[lower]; ok {
+		violations = append(violations, Violation{CodeCommon, "너무 흔한 비밀번호입니다"})
+	}
+
+	if r.DisallowIdentity {
+		for _, id := range identity {
+			if at := strings.IndexByte(id, '@'); at >= 0 {
+				id = id[:at] // 이메일은 @ 앞부분
+			}
+			id = strings.ToLower(strings.TrimSpace(id))
+			if utf8.RuneCountInString(id) >= 3 && strings.Contains(lower, id) {
+				violations = append(violations, Violation{CodeIdentity, "비밀번호에 아이디나 이메일을 포함할 수 없습니다"})
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+// Reused 최근 사용한 비밀번호 위반 항목 (이력 확인은 저장소가 있는 쪽에서 한다)
+func Reused(history int) Violation {
+	return Violation{CodeReused, fmt.Sprintf("최근 사용한 비밀번호 %d개는 다시 사용할 수 없습니다", history)}
+}
+
+// LoadBlocklist 파일에서 금지 비밀번호 목록 읽기 (한 줄에 하나, 빈 줄과 # 줄 무시)
+func LoadBlocklist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	return words, scanner.Err()
+}
+
+// block 금지 목록에 추가 (소문자로 비교)
+func (p *Policy) block(word string) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if word == "" || strings.HasPrefix(word, "#") {
+		return
+	}
+	p.blocklist[word] = struct{}{}
+}
+
+// countClasses 사용한 문자 종류 수 (소문자, 대문자, 숫자, 그 외)
+func countClasses(s string) int {
+	var lower, upper, digit, other bool
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	count := 0
+	for _, ok := range []bool{lower, upper, digit, other} {
+		if ok {
+			count++
+		}
+	}
+	return count
+}
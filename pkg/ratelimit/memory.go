@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry 키 하나의 버킷과 마지막으로 쓴 한도 (정리 시 가득 찼는지 판단용)
+type memoryEntry struct {
+	bucket bucket
+	limit  Limit
+}
+
+// MemoryStore 프로세스 메모리 저장소 (인스턴스마다 한도가 따로 적용됨)
+type MemoryStore struct {
+	sweepEvery time.Duration
+
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryStore 메모리 저장소 생성
+// sweepEvery 주기마다 가득 찬 버킷을 지워 메모리를 회수한다 (가득 찬 버킷은 새로 만든 것과 같음)
+func NewMemoryStore(sweepEvery time.Duration) *MemoryStore {
+	if sweepEvery <= 0 {
+		sweepEvery = time.Minute
+	}
+	return &MemoryStore{
+		sweepEvery: sweepEvery,
+		entries:    make(map[string]*memoryEntry),
+		lastSweep:  time.Now(),
+	}
+}
+
+// Take Store 구현
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= s.sweepEvery {
+		s.sweep(now)
+	}
+
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &memoryEntry{bucket: bucket{tokens: float64(limit.Burst), updated: now}}
+		s.entries[key] = entry
+	}
+	entry.limit = limit
+
+	return entry.bucket.take(limit, now), nil
+}
+
+// Len 저장된 버킷 수
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.entries)
+}
+
+// sweep 가득 찬 버킷 제거 (잠금 상태에서 호출)
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if entry.bucket.full(entry.limit, now) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit 토큰 버킷 한도 (Rate: 초당 채워지는 토큰 수, Burst: 버킷 크기)
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerSecond 초당 n회 (순간 최대 n회)
+func PerSecond(n int) Limit {
+	return Limit{Rate: float64(n), Burst: n}
+}
+
+// PerMinute 분당 n회 (순간 최대 n회)
+func PerMinute(n int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: n}
+}
+
+// Window 버킷이 비었다가 가득 찰 때까지 걸리는 시간 (RateLimit-Policy의 w 값)
+func (l Limit) Window() time.Duration {
+	if l.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Result 요청 하나에 대한 판정
+type Result struct {
+	Allowed    bool
+	Limit      int           // 버킷 크기
+	Remaining  int           // 남은 토큰 수
+	Reset      time.Duration // 버킷이 가득 찰 때까지 남은 시간
+	RetryAfter time.Duration // 거부된 경우 다음 토큰까지 남은 시간
+}
+
+// Store 키별 토큰 버킷 저장소
+// 여러 인스턴스가 한도를 공유해야 하면 Redis 등 공용 저장소로 구현해 교체한다
+type Store interface {
+	// Take 키의 버킷에서 토큰 하나를 꺼낸다 (없으면 Allowed=false)
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// bucket 토큰 버킷 상태
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take 경과 시간만큼 토큰을 채운 뒤 하나 꺼내기
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	burst := float64(limit.Burst)
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*limit.Rate)
+	}
+	b.updated = now
+
+	result := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else if limit.Rate > 0 {
+		result.RetryAfter = seconds((1 - b.tokens) / limit.Rate)
+	}
+
+	result.Remaining = int(b.tokens)
+	if limit.Rate > 0 {
+		result.Reset = seconds((burst - b.tokens) / limit.Rate)
+	}
+	return result
+}
+
+// full 버킷이 가득 찼는지 (정리 대상)
+func (b *bucket) full(limit Limit, now time.Time) bool {
+	return b.tokens+now.Sub(b.updated).Seconds()*limit.Rate >= float64(limit.Burst)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,170 @@
+// Package rbac 역할 기반 권한 검사
+//
+// 권한은 "리소스:동작[:범위]" 형식의 문자열(blog:delete:any)이고 역할에 부여한다.
+// 부여한 권한에는 와일드카드를 쓸 수 있다: "*"는 모든 권한, "blog:*"는 blog:로 시작하는 모든 권한.
+// 역할별 권한은 Store(DB 등)에서 읽어 메모리에 캐시하고, ttl이 지나거나 Invalidate한 뒤 처음 조회할 때 다시 읽는다.
+package rbac
+
+import (
+	"context"
+	"gin_starter/pkg/logger"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Wildcard 모든 권한
+const Wildcard = "*"
+
+// permissionPattern 권한 형식 (소문자 세그먼트를 :로 연결, 마지막 세그먼트는 * 가능)
+var permissionPattern = regexp.MustCompile(`^(\*|[a-z][a-z0-9_-]*(:[a-z][a-z0-9_-]*)*(:\*)?)$`)
+
+// Store 역할별 권한 저장소
+type Store interface {
+	// LoadGrants 역할 코드 -> 부여된 권한 목록
+	LoadGrants(ctx context.Context) (map[string][]string, error)
+}
+
+// Policy 역할별 권한 캐시
+type Policy struct {
+	store Store
+	ttl   time.Duration
+
+	loadMu   sync.Mutex // 동시에 여러 요청이 Store를 읽지 않도록
+	mu       sync.RWMutex
+	grants   map[string][]string
+	loadedAt time.Time
+	stale    bool
+}
+
+// NewPolicy 권한 캐시 생성 (ttl이 0 이하면 Invalidate할 때만 다시 읽음)
+func NewPolicy(store Store, ttl time.Duration) *Policy {
+	return &Policy{store: store, ttl: ttl, stale: true}
+}
+
+// Load Store에서 역할별 권한을 다시 읽음
+func (p *Policy) Load(ctx context.Context) error {
+	grants, err := p.store.LoadGrants(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.grants = grants
+	p.loadedAt = time.Now()
+	p.stale = false
+	p.mu.Unlock()
+	return nil
+}
+
+// Invalidate 다음 조회 때 다시 읽도록 표시 (역할, 권한 부여 변경 시 호출)
+func (p *Policy) Invalidate() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.stale = true
+	p.mu.Unlock()
+}
+
+// Permissions 역할들에 부여된 권한 (중복 제거, 정렬)
+func (p *Policy) Permissions(ctx context.Context, roles []string) ([]string, error) {
+	grants, err := p.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var permissions []string
+	for _, role := range roles {
+		for _, permission := range grants[role] {
+			if _, ok := seen[permission]; ok {
+				continue
+			}
+			seen[permission] = struct{}{}
+			permissions = append(permissions, permission)
+		}
+	}
+	sort.Strings(permissions)
+	return permissions, nil
+}
+
+// Allowed 역할들 중 하나라도 permission을 부여받았는지
+func (p *Policy) Allowed(ctx context.Context, roles []string, permission string) bool {
+	permissions, err := p.Permissions(ctx, roles)
+	if err != nil {
+		return false
+	}
+	return Has(permissions, permission)
+}
+
+// current 캐시된 권한 (만료되었으면 다시 읽고, 실패하면 이전 캐시 사용)
+func (p *Policy) current(ctx context.Context) (map[string][]string, error) {
+	p.mu.RLock()
+	grants, fresh := p.grants, p.fresh(time.Now())
+	p.mu.RUnlock()
+
+	if fresh {
+		return grants, nil
+	}
+
+	p.loadMu.Lock()
+	defer p.loadMu.Unlock()
+
+	// 기다리는 사이 다른 요청이 다시 읽었으면 그대로 사용
+	p.mu.RLock()
+	grants, fresh = p.grants, p.fresh(time.Now())
+	p.mu.RUnlock()
+	if fresh {
+		return grants, nil
+	}
+
+	if err := p.Load(ctx); err != nil {
+		if grants == nil {
+			return nil, err
+		}
+		logger.FromContext(ctx).Warn("역할 권한 다시 읽기 실패 (이전 캐시 사용): %v", err)
+		return grants, nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.grants, nil
+}
+
+// fresh 캐시를 그대로 써도 되는지 (mu를 잡은 상태에서 호출)
+func (p *Policy) fresh(now time.Time) bool {
+	if p.grants == nil || p.stale {
+		return false
+	}
+	return p.ttl <= 0 || now.Before(p.loadedAt.Add(p.ttl))
+}
+
+// Match 부여된 권한(와일드카드 포함)이 요구 권한을 포함하는지
+func Match(granted, permission string) bool {
+	if granted == Wildcard || granted == permission {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(granted, ":*"); ok {
+		return strings.HasPrefix(permission, prefix+":")
+	}
+	return false
+}
+
+// Has 부여된 권한 목록 중 요구 권한을 포함하는 것이 있는지
+func Has(granted []string, permission string) bool {
+	for _, g := range granted {
+		if Match(g, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid 권한 형식 확인 (blog:delete:any, blog:*, *)
+func Valid(permission string) bool {
+	return permissionPattern.MatchString(permission)
+}
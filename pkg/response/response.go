@@ -1,7 +1,13 @@
 package response
 
 import (
+	"context"
+	stderrors "errors"
+	"gin_starter/pkg/errors"
+	"gin_starter/pkg/logger"
+	"gin_starter/pkg/trace"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,9 +22,10 @@ type Response struct {
 
 // ErrorInfo 에러 상세 정보
 type ErrorInfo struct {
-	Code    string                 `json:"code"`
-	Message string                 `json:"message"`
-	Details map[string]interface{} `json:"details,omitempty"`
+	Code      string                 `json:"code"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"` // 문의, 로그 추적용 요청 ID
 }
 
 // Meta 페이지네이션 등 메타 정보
@@ -62,8 +69,9 @@ func NoContent(c *gin.Context) {
 // Error 에러 응답
 func Error(c *gin.Context, statusCode int, code string, message string, details ...map[string]interface{}) {
 	errorInfo := &ErrorInfo{
-		Code:    code,
-		Message: message,
+		Code:      code,
+		Message:   message,
+		RequestID: trace.RequestID(c.Request.Context()),
 	}
 
 	if len(details) > 0 {
@@ -106,11 +114,84 @@ func ValidationError(c *gin.Context, details map[string]interface{}) {
 	Error(c, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "입력값 검증에 실패했습니다", details)
 }
 
+// TooManyRequests 429 에러 (retryAfterSec: 다시 시도할 수 있을 때까지 남은 초)
+func TooManyRequests(c *gin.Context, retryAfterSec int) {
+	RetryLater(c, errors.ErrRateLimited.Code, errors.ErrRateLimited.Message, retryAfterSec)
+}
+
+// RetryLater 잠시 후 다시 시도해야 하는 429 에러 (Retry-After 헤더와 details.retry_after 포함)
+func RetryLater(c *gin.Context, code string, message string, retryAfterSec int) {
+	c.Header("Retry-After", strconv.Itoa(retryAfterSec))
+	Error(c, http.StatusTooManyRequests, code, message, map[string]interface{}{"retry_after": retryAfterSec})
+}
+
 // InternalError 500 에러
 func InternalError(c *gin.Context, message string) {
 	Error(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
 }
 
+// StatusClientClosedRequest 클라이언트가 응답 전에 연결을 끊은 경우 (nginx 관례)
+const StatusClientClosedRequest = errors.StatusClientClosedRequest
+
+// FromError 에러를 상태 코드, 코드, 메시지, details(Meta)로 응답
+// AppError가 아니면 500 INTERNAL_ERROR이고, 5xx는 원본 에러를 로그에 남긴다
+// 원본 에러는 release 모드가 아닐 때만 details.cause로 보여준다 (개발 중 확인용)
+// retry_after 메타가 있는 429는 Retry-After 헤더도 설정한다
+func FromError(c *gin.Context, err error) {
+	if ContextError(c, err) {
+		return
+	}
+
+	appErr, ok := errors.As(err)
+	if !ok {
+		appErr = errors.ErrInternal.Wrap(err)
+	}
+
+	status := appErr.HTTPStatus()
+	details := appErr.Meta
+
+	if status >= http.StatusInternalServerError {
+		logger.FromContext(c.Request.Context()).Error("%s: %v", appErr.Code, err)
+		if gin.Mode() != gin.ReleaseMode {
+			details = withCause(details, err)
+		}
+	}
+
+	if sec, ok := details["retry_after"].(int); ok && status == http.StatusTooManyRequests {
+		c.Header("Retry-After", strconv.Itoa(sec))
+	}
+
+	if len(details) > 0 {
+		Error(c, status, appErr.Code, appErr.Message, details)
+	} else {
+		Error(c, status, appErr.Code, appErr.Message)
+	}
+}
+
+// withCause details 복사본에 원본 에러 추가
+func withCause(details map[string]interface{}, err error) map[string]interface{} {
+	result := make(map[string]interface{}, len(details)+1)
+	for k, v := range details {
+		result[k] = v
+	}
+	result["cause"] = err.Error()
+	return result
+}
+
+// ContextError 요청 시간 초과/취소로 실패했으면 전용 에러 응답 후 true 반환
+// 시간 초과는 504 QUERY_TIMEOUT, 클라이언트 취소는 499 REQUEST_CANCELED
+func ContextError(c *gin.Context, err error) bool {
+	switch {
+	case stderrors.Is(err, context.DeadlineExceeded):
+		Error(c, http.StatusGatewayTimeout, errors.ErrQueryTimeout.Code, errors.ErrQueryTimeout.Message)
+	case stderrors.Is(err, context.Canceled):
+		Error(c, StatusClientClosedRequest, errors.ErrRequestCanceled.Code, errors.ErrRequestCanceled.Message)
+	default:
+		return false
+	}
+	return true
+}
+
 // TokenExpired 토큰 만료
 func TokenExpired(c *gin.Context) {
 	Error(c, http.StatusUnauthorized, "TOKEN_EXPIRED", "토큰이 만료되었습니다")
@@ -119,4 +200,9 @@ func TokenExpired(c *gin.Context) {
 // TokenInvalid 토큰 무효
 func TokenInvalid(c *gin.Context) {
 	Error(c, http.StatusUnauthorized, "TOKEN_INVALID", "유효하지 않은 토큰입니다")
-}
\ No newline at end of file
+}
+
+// TokenRevoked 폐기된 토큰 (로그아웃, 비밀번호 변경 등)
+func TokenRevoked(c *gin.Context) {
+	Error(c, http.StatusUnauthorized, "TOKEN_REVOKED", "더 이상 사용할 수 없는 토큰입니다. 다시 로그인해주세요")
+}
@@ -0,0 +1,227 @@
+// Package revocation 액세스 토큰 폐기 목록
+//
+// 폐기 기록은 Store(DB 등)에 저장해 재시작 후에도 유지하고 여러 인스턴스가 공유한다.
+// 요청마다 확인하는 것은 메모리 목록이고, syncEvery마다 Store에서 다른 인스턴스가 추가한 기록을 가져온다.
+// 기록은 대상 토큰이 모두 만료되는 시각(ExpiresAt)까지만 유지한다.
+package revocation
+
+import (
+	"context"
+	"gin_starter/pkg/logger"
+	"sync"
+	"time"
+)
+
+// 폐기 대상 종류
+const (
+	KindToken   = "token"   // 토큰 하나 (jti)
+	KindSession = "session" // 세션의 그때까지 발급된 토큰 전체 (sid)
+	KindUser    = "user"    // 사용자의 그때까지 발급된 토큰 전체 (user_id)
+)
+
+// Entry 폐기 기록
+type Entry struct {
+	Kind      string
+	Subject   string    // jti, 세션 ID, 사용자 ID
+	RevokedAt time.Time // 세션/사용자는 이 시각까지(같은 밀리초 포함) 발급된 토큰을 거부 (밀리초 단위)
+	ExpiresAt time.Time // 이후에는 대상 토큰이 모두 만료되어 기록이 필요 없음
+}
+
+// Store 폐기 기록 저장소
+type Store interface {
+	Save(ctx context.Context, entry Entry) error
+	// LoadSince since 이후 폐기되고 아직 만료되지 않은 기록
+	LoadSince(ctx context.Context, since, now time.Time) ([]Entry, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Token 폐기 여부를 확인할 토큰 정보
+type Token struct {
+	ID        string // jti
+	SessionID string
+	UserID    string
+	IssuedAt  time.Time // 밀리초 단위 발급 시각
+}
+
+// List 메모리 폐기 목록 (Store와 주기적으로 동기화)
+type List struct {
+	store     Store
+	syncEvery time.Duration
+
+	mu       sync.RWMutex
+	entries  map[string]Entry // kind:subject -> 기록
+	lastSync time.Time
+	syncing  bool
+}
+
+// NewList 폐기 목록 생성 (store가 nil이면 메모리에만 보관 - 단일 인스턴스, 재시작 시 초기화)
+func NewList(store Store, syncEvery time.Duration) *List {
+	return &List{
+		store:     store,
+		syncEvery: syncEvery,
+		entries:   make(map[string]Entry),
+	}
+}
+
+// Load 시작 시 Store에서 만료되지 않은 기록 전체를 불러옴
+func (l *List) Load(ctx context.Context) error {
+	if l.store == nil {
+		return nil
+	}
+
+	now := time.Now()
+	entries, err := l.store.LoadSince(ctx, time.Time{}, now)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	for _, e := range entries {
+		l.merge(e)
+	}
+	l.lastSync = now
+	l.mu.Unlock()
+	return nil
+}
+
+// RevokeToken 토큰 하나 폐기 (expiresAt: 토큰 만료 시각)
+func (l *List) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) {
+	l.revoke(ctx, Entry{Kind: KindToken, Subject: jti, RevokedAt: time.Now(), ExpiresAt: expiresAt})
+}
+
+// RevokeSession 세션에서 지금까지 발급된 토큰 폐기 (ttl: 액세스 토큰 유효 기간)
+func (l *List) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) {
+	now := revokedNow()
+	l.revoke(ctx, Entry{Kind: KindSession, Subject: sessionID, RevokedAt: now, ExpiresAt: now.Add(ttl)})
+}
+
+// RevokeUser 사용자에게 지금까지 발급된 토큰 폐기 (ttl: 액세스 토큰 유효 기간)
+func (l *List) RevokeUser(ctx context.Context, userID string, ttl time.Duration) {
+	now := revokedNow()
+	l.revoke(ctx, Entry{Kind: KindUser, Subject: userID, RevokedAt: now, ExpiresAt: now.Add(ttl)})
+}
+
+// revoke 메모리에 바로 반영하고 Store에 저장
+// 저장에 실패해도 이 인스턴스에는 반영되므로 기록만 남긴다 (다른 인스턴스는 토큰 만료까지 모를 수 있음)
+func (l *List) revoke(ctx context.Context, entry Entry) {
+	if l == nil || entry.Subject == "" {
+		return
+	}
+
+	l.mu.Lock()
+	l.merge(entry)
+	l.mu.Unlock()
+
+	if l.store == nil {
+		return
+	}
+	if err := l.store.Save(ctx, entry); err != nil {
+		logger.FromContext(ctx).Error("토큰 폐기 기록 저장 실패 (%s:%s): %v", entry.Kind, entry.Subject, err)
+	}
+}
+
+// Revoked 토큰이 폐기됐는지 확인
+func (l *List) Revoked(ctx context.Context, token Token) bool {
+	if l == nil {
+		return false
+	}
+
+	now := time.Now()
+	l.sync(ctx, now)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if e, ok := l.entries[key(KindToken, token.ID)]; ok && now.Before(e.ExpiresAt) {
+		return true
+	}
+	if e, ok := l.entries[key(KindSession, token.SessionID)]; ok && now.Before(e.ExpiresAt) && issuedBefore(token.IssuedAt, e.RevokedAt) {
+		return true
+	}
+	if e, ok := l.entries[key(KindUser, token.UserID)]; ok && now.Before(e.ExpiresAt) && issuedBefore(token.IssuedAt, e.RevokedAt) {
+		return true
+	}
+	return false
+}
+
+// Len 메모리에 있는 기록 수
+func (l *List) Len() int {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.entries)
+}
+
+// sync syncEvery가 지났으면 Store에서 새 기록을 가져오고 만료된 기록 정리
+// 한 요청만 동기화하고 나머지는 기다리지 않고 현재 목록으로 확인한다 (실패 시 다음 주기에 재시도)
+func (l *List) sync(ctx context.Context, now time.Time) {
+	l.mu.Lock()
+	if l.syncing || now.Sub(l.lastSync) < l.syncEvery {
+		l.mu.Unlock()
+		return
+	}
+	l.syncing = true
+	// 인스턴스 간 시계 차이를 감안해 한 주기 겹쳐서 가져온다 (같은 기록은 덮어씀)
+	since := l.lastSync.Add(-l.syncEvery)
+	l.mu.Unlock()
+
+	var entries []Entry
+	var err error
+	if l.store != nil {
+		entries, err = l.store.LoadSince(ctx, since, now)
+		if err == nil {
+			_, err = l.store.DeleteExpired(ctx, now)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.syncing = false
+	if err != nil {
+		logger.FromContext(ctx).Warn("토큰 폐기 목록 동기화 실패: %v", err)
+		return
+	}
+
+	for _, e := range entries {
+		l.merge(e)
+	}
+	for k, e := range l.entries {
+		if !now.Before(e.ExpiresAt) {
+			delete(l.entries, k)
+		}
+	}
+	l.lastSync = now
+}
+
+// merge 기록 추가 (같은 대상이면 더 나중 폐기 시각, 더 늦은 만료 시각 유지 - 잠금 상태에서 호출)
+func (l *List) merge(entry Entry) {
+	k := key(entry.Kind, entry.Subject)
+	if old, ok := l.entries[k]; ok {
+		if old.RevokedAt.After(entry.RevokedAt) {
+			entry.RevokedAt = old.RevokedAt
+		}
+		if old.ExpiresAt.After(entry.ExpiresAt) {
+			entry.ExpiresAt = old.ExpiresAt
+		}
+	}
+	l.entries[k] = entry
+}
+
+// revokedNow 폐기 시각 (토큰 발급 시각과 같은 밀리초 단위, Store에 저장해도 올림되지 않도록 버림)
+func revokedNow() time.Time {
+	return time.Now().Truncate(time.Millisecond)
+}
+
+// issuedBefore 토큰이 폐기 시각 이전(같은 밀리초 포함)에 발급됐는지
+// 같은 밀리초에 발급된 토큰은 폐기 전인지 후인지 구분할 수 없으므로 폐기 대상으로 본다
+// (로그인 직후 전체 로그아웃, 비밀번호 변경과 동시에 갱신된 토큰이 살아남지 않도록)
+func issuedBefore(issuedAt, revokedAt time.Time) bool {
+	return !issuedAt.After(revokedAt)
+}
+
+func key(kind, subject string) string {
+	return kind + ":" + subject
+}
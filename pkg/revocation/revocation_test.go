@@ -0,0 +1,76 @@
+package revocation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gin_starter/pkg/revocation"
+)
+
+// fixedStore 정해진 기록만 돌려주는 Store
+type fixedStore struct {
+	entries []revocation.Entry
+}
+
+func (s *fixedStore) Save(context.Context, revocation.Entry) error { return nil }
+
+func (s *fixedStore) LoadSince(context.Context, time.Time, time.Time) ([]revocation.Entry, error) {
+	return s.entries, nil
+}
+
+func (s *fixedStore) DeleteExpired(context.Context, time.Time) (int64, error) { return 0, nil }
+
+func TestRevokedSameMillisecond(t *testing.T) {
+	ctx := context.Background()
+	revokedAt := time.Now().Truncate(time.Millisecond)
+	expiresAt := revokedAt.Add(time.Hour)
+
+	list := revocation.NewList(&fixedStore{entries: []revocation.Entry{
+		{Kind: revocation.KindUser, Subject: "user-1", RevokedAt: revokedAt, ExpiresAt: expiresAt},
+		{Kind: revocation.KindSession, Subject: "session-1", RevokedAt: revokedAt, ExpiresAt: expiresAt},
+	}}, time.Hour)
+	if err := list.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		token   revocation.Token
+		revoked bool
+	}{
+		{"사용자, 이전 밀리초", revocation.Token{UserID: "user-1", IssuedAt: revokedAt.Add(-time.Millisecond)}, true},
+		{"사용자, 같은 밀리초", revocation.Token{UserID: "user-1", IssuedAt: revokedAt}, true},
+		{"사용자, 다음 밀리초", revocation.Token{UserID: "user-1", IssuedAt: revokedAt.Add(time.Millisecond)}, false},
+		{"세션, 같은 밀리초", revocation.Token{SessionID: "session-1", IssuedAt: revokedAt}, true},
+		{"세션, 다음 밀리초", revocation.Token{SessionID: "session-1", IssuedAt: revokedAt.Add(time.Millisecond)}, false},
+		{"다른 사용자", revocation.Token{UserID: "user-2", IssuedAt: revokedAt}, false},
+	}
+	for _, tc := range cases {
+		if got := list.Revoked(ctx, tc.token); got != tc.revoked {
+			t.Errorf("%s: Revoked = %v, want %v", tc.name, got, tc.revoked)
+		}
+	}
+}
+
+func TestRevokeUserRejectsTokenIssuedNow(t *testing.T) {
+	ctx := context.Background()
+	list := revocation.NewList(nil, time.Hour)
+
+	// 발급과 폐기가 같은 밀리초에 일어나도 폐기된다
+	issuedAt := time.Now().Truncate(time.Millisecond)
+	list.RevokeUser(ctx, "user-1", time.Hour)
+	if !list.Revoked(ctx, revocation.Token{UserID: "user-1", IssuedAt: issuedAt}) {
+		t.Error("폐기 직전에 발급된 토큰이 통과함")
+	}
+}
+
+func TestNilList(t *testing.T) {
+	var list *revocation.List
+	if got := list.Len(); got != 0 {
+		t.Errorf("nil Len = %d", got)
+	}
+	if list.Revoked(context.Background(), revocation.Token{UserID: "user-1"}) {
+		t.Error("nil 목록이 토큰을 폐기로 판단함")
+	}
+}
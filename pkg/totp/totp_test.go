@@ -0,0 +1,91 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"gin_starter/pkg/totp"
+)
+
+// rfcSecret RFC 6238 부록 B의 SHA1 시크릿 "12345678901234567890"
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+// rfcVectors RFC 6238 부록 B SHA1 값 (8자리 중 뒤 6자리)
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},          // 94287082
+	{1111111109, "081804"},  // 07081804
+	{1111111111, "050471"},  // 14050471
+	{1234567890, "005924"},  // 89005924
+	{2000000000, "279037"},  // 69279037
+	{20000000000, "353130"}, // 65353130
+}
+
+func TestCodeRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		got, err := totp.Code(rfcSecret, totp.Step(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v.code {
+			t.Errorf("T=%d: code = %s, want %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestValidateSkewWindow(t *testing.T) {
+	const skew = 1
+	period := int64(totp.Period / time.Second)
+
+	for _, v := range rfcVectors {
+		step := totp.Step(time.Unix(v.unix, 0))
+
+		cases := []struct {
+			name string
+			at   int64 // 검증 시각 (Unix 초)
+			ok   bool
+		}{
+			{"같은 구간", v.unix, true},
+			{"skew만큼 뒤 구간의 첫 초", (step + skew) * period, true},
+			{"skew만큼 뒤 구간의 마지막 초", (step+skew+1)*period - 1, true},
+			{"skew를 넘은 뒤 구간", (step + skew + 1) * period, false},
+			{"skew만큼 앞 구간의 첫 초", (step - skew) * period, true},
+			{"skew를 넘은 앞 구간의 마지막 초", (step-skew)*period - 1, false},
+		}
+		for _, tc := range cases {
+			if tc.at < 0 {
+				continue // 1970년 이전은 구간 계산 대상이 아님
+			}
+			matched, ok := totp.Validate(rfcSecret, v.code, time.Unix(tc.at, 0), skew)
+			if ok != tc.ok {
+				t.Errorf("T=%d %s (%d): ok = %v, want %v", v.unix, tc.name, tc.at, ok, tc.ok)
+				continue
+			}
+			// 일치한 구간은 검증 시각이 아니라 코드의 구간
+			if ok && matched != step {
+				t.Errorf("T=%d %s: step = %d, want %d", v.unix, tc.name, matched, step)
+			}
+		}
+	}
+}
+
+func TestValidateInput(t *testing.T) {
+	at := time.Unix(59, 0)
+
+	if _, ok := totp.Validate(rfcSecret, " 287082 ", at, 0); !ok {
+		t.Error("앞뒤 공백이 있는 코드 거부")
+	}
+	if _, ok := totp.Validate(rfcSecret, "94287082", at, 0); ok {
+		t.Error("8자리 코드 허용")
+	}
+	if _, ok := totp.Validate("not base32!", "287082", at, 0); ok {
+		t.Error("잘못된 시크릿으로 검증 통과")
+	}
+	// 소문자, 공백, 패딩이 섞인 시크릿도 같은 키
+	if _, ok := totp.Validate("gezd gnbv gy3t qojq gezd gnbv gy3t qojq===", "287082", at, 0); !ok {
+		t.Error("소문자 시크릿 거부")
+	}
+}
@@ -0,0 +1,141 @@
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// 요청 추적 헤더
+const (
+	HeaderRequestID   = "X-Request-ID"
+	HeaderTraceparent = "traceparent"
+)
+
+// maxRequestIDLength 외부에서 받은 요청 ID 최대 길이 (초과하거나 허용하지 않는 문자가 있으면 새로 생성)
+const maxRequestIDLength = 128
+
+// Trace 요청 하나의 추적 정보 (W3C Trace Context)
+type Trace struct {
+	RequestID    string // X-Request-ID (없으면 TraceID 사용)
+	TraceID      string // 32자리 16진수, 서비스 간 공유
+	SpanID       string // 16자리 16진수, 이 서버의 처리 구간
+	ParentSpanID string // 호출한 쪽 구간 (traceparent로 받은 경우)
+	Sampled      bool   // trace-flags 01
+}
+
+// FromHeaders 요청 헤더로 추적 정보 생성
+// 유효한 traceparent가 있으면 같은 trace를 이어가고, 없으면 새 trace를 시작한다
+func FromHeaders(requestID, traceparent string) *Trace {
+	t := &Trace{SpanID: newID(8)}
+
+	if traceID, parentID, sampled, ok := ParseTraceparent(traceparent); ok {
+		t.TraceID = traceID
+		t.ParentSpanID = parentID
+		t.Sampled = sampled
+	} else {
+		t.TraceID = newID(16)
+		t.Sampled = true
+	}
+
+	if validRequestID(requestID) {
+		t.RequestID = requestID
+	} else {
+		t.RequestID = t.TraceID
+	}
+
+	return t
+}
+
+// Traceparent 다음 서비스로 전달할 traceparent 헤더 값 (현재 구간이 부모)
+func (t *Trace) Traceparent() string {
+	flags := "00"
+	if t.Sampled {
+		flags = "01"
+	}
+	return "00-" + t.TraceID + "-" + t.SpanID + "-" + flags
+}
+
+// ParseTraceparent W3C traceparent 헤더 해석 (version-traceid-parentid-flags)
+func ParseTraceparent(header string) (traceID, parentID string, sampled bool, ok bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return "", "", false, false
+	}
+
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+	// 버전 ff는 금지, 00은 필드가 정확히 4개
+	if !isHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return "", "", false, false
+	}
+	if !isHex(traceID, 32) || !isHex(parentID, 16) || !isHex(flags, 2) {
+		return "", "", false, false
+	}
+	// 모두 0인 ID는 유효하지 않음
+	if strings.Trim(traceID, "0") == "" || strings.Trim(parentID, "0") == "" {
+		return "", "", false, false
+	}
+
+	flagBits, _ := hex.DecodeString(flags)
+	return traceID, parentID, flagBits[0]&0x01 == 1, true
+}
+
+// isHex 길이가 n인 소문자 16진수 문자열인지 확인
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, ch := range s {
+		if !(ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// validRequestID 로그, 헤더에 그대로 써도 되는 요청 ID인지 확인
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, ch := range id {
+		if !(ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' ||
+			ch == '-' || ch == '_' || ch == '.' || ch == ':') {
+			return false
+		}
+	}
+	return true
+}
+
+// newID n바이트 난수 16진수 ID
+func newID(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ctxKey 컨텍스트에 추적 정보를 담는 키
+type ctxKey struct{}
+
+// NewContext 추적 정보를 담은 컨텍스트 반환
+func NewContext(ctx context.Context, t *Trace) context.Context {
+	return context.WithValue(ctx, ctxKey{}, t)
+}
+
+// FromContext 컨텍스트의 추적 정보 반환
+func FromContext(ctx context.Context) (*Trace, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	t, ok := ctx.Value(ctxKey{}).(*Trace)
+	return t, ok
+}
+
+// RequestID 컨텍스트의 요청 ID (없으면 빈 문자열)
+func RequestID(ctx context.Context) string {
+	if t, ok := FromContext(ctx); ok {
+		return t.RequestID
+	}
+	return ""
+}
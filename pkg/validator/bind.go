@@ -0,0 +1,311 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Bind 요청 값을 dst(구조체 포인터)에 채우고 validate 태그로 검증
+// 값은 쿼리, 폼, JSON 바디 순서로 읽고 뒤에 읽은 값이 우선하며, URL 파라미터는 항상 우선한다 (Validate와 같은 출처)
+// 필드 이름은 json 태그(없으면 필드명), 표시 이름은 label 태그를 쓰고, 문자열은 앞뒤 공백을 제거한다
+// 폼, 쿼리의 중첩 구조체는 점으로 구분한다 (address.city)
+//
+//	type CreateUserRequest struct {
+//	    ID string `json:"user_id" label:"아이디" validate:"required,min=3,max=20,pattern=alphanum"`
+//	}
+//
+//	var req CreateUserRequest
+//	if result := validator.Bind(c, &req); !result.Valid {
+//	    response.ValidationError(c, result.GetErrorMap())
+//	    return
+//	}
+func Bind(c *gin.Context, dst interface{}) *Result {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validator: Bind에는 구조체 포인터가 필요합니다 (%T)", dst))
+	}
+
+	result := newResult()
+
+	input, err := requestInput(c)
+	if err != nil {
+		result.addError("body", "요청 본문", "INVALID_BODY", "요청 본문을 읽을 수 없습니다")
+		return result
+	}
+
+	assignStruct(result, v.Elem(), input, "")
+	validateStruct(result, v.Elem(), "")
+	return result
+}
+
+// requestInput 요청 값을 하나의 트리로 모음 (폼, 쿼리 값은 []string)
+func requestInput(c *gin.Context) (map[string]interface{}, error) {
+	input := make(map[string]interface{})
+
+	for key, values := range c.Request.URL.Query() {
+		setFormValue(input, key, values)
+	}
+
+	switch c.ContentType() {
+	case "application/json":
+		var body map[string]interface{}
+		decoder := json.NewDecoder(c.Request.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil && err != io.EOF {
+			return nil, err
+		}
+		for key, value := range body {
+			input[key] = value
+		}
+
+	case "multipart/form-data":
+		form, err := c.MultipartForm()
+		if err != nil {
+			return nil, err
+		}
+		for key, values := range form.Value {
+			setFormValue(input, key, values)
+		}
+
+	case "application/x-www-form-urlencoded":
+		if err := c.Request.ParseForm(); err != nil {
+			return nil, err
+		}
+		for key, values := range c.Request.PostForm {
+			setFormValue(input, key, values)
+		}
+	}
+
+	// URL 파라미터는 마지막에 넣어 바디, 쿼리의 같은 키가 라우트 값을 바꾸지 못하게 한다
+	for _, p := range c.Params {
+		setFormValue(input, p.Key, []string{p.Value})
+	}
+
+	return input, nil
+}
+
+// setFormValue 점으로 구분한 키(address.city)를 중첩 map에 넣기
+func setFormValue(input map[string]interface{}, key string, values []string) {
+	parts := strings.Split(key, ".")
+	node := input
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := node[part].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			node[part] = child
+		}
+		node = child
+	}
+	node[parts[len(parts)-1]] = values
+}
+
+// assignStruct 입력 트리를 구조체 필드에 채우기 (형식이 맞지 않으면 INVALID_TYPE)
+func assignStruct(r *Result, v reflect.Value, input map[string]interface{}, prefix string) {
+	for _, f := range structFields(v.Type()) {
+		fv := v.FieldByIndex(f.index)
+		if f.embedded {
+			assignStruct(r, fv, input, prefix)
+			continue
+		}
+
+		raw, ok := input[f.name]
+		if !ok {
+			continue
+		}
+		name := joinName(prefix, f.name)
+		if err := assignValue(r, fv, raw, name, f.timeFormat); err != nil {
+			r.addError(name, f.label, "INVALID_TYPE", fmt.Sprintf("%s의 값 형식이 올바르지 않습니다", f.label))
+		}
+	}
+}
+
+// assignValue 값 하나를 필드 타입에 맞게 변환해서 넣기
+func assignValue(r *Result, v reflect.Value, raw interface{}, name, timeFormat string) error {
+	if raw == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := assignValue(r, elem.Elem(), raw, name, timeFormat); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+
+	case reflect.Slice:
+		items, ok := raw.([]interface{})
+		if !ok {
+			if values, isForm := raw.([]string); isForm {
+				items = make([]interface{}, len(values))
+				for i, s := range values {
+					items[i] = s
+				}
+			} else {
+				items = []interface{}{raw}
+			}
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := assignValue(r, slice.Index(i), item, fmt.Sprintf("%s[%d]", name, i), timeFormat); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+
+	case reflect.Map:
+		m, ok := raw.(map[string]interface{})
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("map이 아닙니다")
+		}
+		out := reflect.MakeMapWithSize(v.Type(), len(m))
+		for key, item := range m {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := assignValue(r, elem, item, joinName(name, key), timeFormat); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		v.Set(out)
+		return nil
+
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(raw))
+		return nil
+	}
+
+	if v.Type() == timeType {
+		s, ok := scalar(raw).(string)
+		if !ok {
+			return fmt.Errorf("시간 형식이 아닙니다")
+		}
+		if s = strings.TrimSpace(s); s == "" {
+			v.Set(reflect.Zero(timeType))
+			return nil
+		}
+		layout := timeFormat
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if v.Kind() == reflect.Struct {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("객체가 아닙니다")
+		}
+		assignStruct(r, v, m, name)
+		return nil
+	}
+
+	return assignScalar(v, scalar(raw))
+}
+
+// assignScalar 문자열, 숫자, bool 필드 채우기 (폼 값과 JSON 숫자 문자열도 변환)
+func assignScalar(v reflect.Value, raw interface{}) error {
+	var text string
+	switch x := raw.(type) {
+	case string:
+		text = strings.TrimSpace(x)
+	case json.Number:
+		text = x.String()
+	case bool:
+		if v.Kind() == reflect.Bool {
+			v.SetBool(x)
+			return nil
+		}
+		if v.Kind() != reflect.String {
+			return fmt.Errorf("bool 값입니다")
+		}
+		text = strconv.FormatBool(x)
+	default:
+		return fmt.Errorf("지원하지 않는 값입니다: %T", raw)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		if text == "" {
+			v.SetBool(false)
+			return nil
+		}
+		if text == "on" {
+			text = "true"
+		}
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if text == "" {
+			v.SetInt(0)
+			return nil
+		}
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if text == "" {
+			v.SetUint(0)
+			return nil
+		}
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		if text == "" {
+			v.SetFloat(0)
+			return nil
+		}
+		n, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("지원하지 않는 필드 타입입니다: %s", v.Type())
+	}
+	return nil
+}
+
+// scalar 폼 값([]string)은 첫 번째 값만 사용
+func scalar(raw interface{}) interface{} {
+	if values, ok := raw.([]string); ok {
+		if len(values) == 0 {
+			return ""
+		}
+		return values[0]
+	}
+	return raw
+}
+
+func joinName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
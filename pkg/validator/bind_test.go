@@ -0,0 +1,218 @@
+package validator_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gin_starter/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newContext 요청 본문과 URL 파라미터로 gin 컨텍스트 생성
+func newContext(method, target, contentType, body string, params gin.Params) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		c.Request.Header.Set("Content-Type", contentType)
+	}
+	c.Params = params
+	return c
+}
+
+// expectCode 필드 에러 코드 확인 ("" 이면 에러 없음)
+func expectCode(t *testing.T, result *validator.Result, field, code string) {
+	t.Helper()
+
+	got := result.Errors[field].Code
+	if got != code {
+		t.Errorf("%s 에러 = %q, want %q (전체: %v)", field, got, code, result.Errors)
+	}
+}
+
+type address struct {
+	City string `json:"city" label:"도시" validate:"required,max=10"`
+	Zip  string `json:"zip" label:"우편번호" validate:"pattern=number"`
+}
+
+type item struct {
+	Name  string `json:"name" label:"상품명" validate:"required"`
+	Count int    `json:"count" label:"수량" validate:"min=1"`
+}
+
+type orderRequest struct {
+	ID        string     `json:"id" label:"주문번호"`
+	Name      string     `json:"name" label:"이름" validate:"required,min=2,max=5"`
+	Quantity  int        `json:"quantity" label:"수량" validate:"required,min=1,max=100"`
+	Price     float64    `json:"price" label:"가격" validate:"max=1000.5"`
+	Gift      *bool      `json:"gift" label:"선물 포장"`
+	Note      *string    `json:"note" label:"메모" validate:"max=3"`
+	Agree     bool       `json:"agree" label:"동의"`
+	Address   address    `json:"address"`
+	Tags      []string   `json:"tags" label:"태그" validate:"max=3,dive,max=5"`
+	Items     []item     `json:"items" label:"상품" validate:"dive,required"`
+	DeliverAt *time.Time `json:"deliver_at" label:"배송일" time_format:"2006-01-02"`
+	CreatedAt time.Time  `json:"created_at" label:"생성 시각"`
+	Internal  string     `json:"-"`
+}
+
+func TestBindJSON(t *testing.T) {
+	body := `{
+		"name": " 홍길동 ",
+		"quantity": 3,
+		"price": "99.5",
+		"gift": false,
+		"agree": true,
+		"address": {"city": "서울", "zip": "12345"},
+		"tags": ["a", "b"],
+		"items": [{"name": "책", "count": 2}],
+		"deliver_at": "2026-10-17",
+		"created_at": "2026-10-17T09:00:00Z",
+		"Internal": "x"
+	}`
+	c := newContext(http.MethodPost, "/orders", "application/json", body, nil)
+
+	var req orderRequest
+	result := validator.Bind(c, &req)
+	if !result.Valid {
+		t.Fatalf("검증 실패: %v", result.Errors)
+	}
+
+	if req.Name != "홍길동" {
+		t.Errorf("Name = %q (공백 제거)", req.Name)
+	}
+	if req.Quantity != 3 || req.Price != 99.5 {
+		t.Errorf("숫자 = %d, %v", req.Quantity, req.Price)
+	}
+	if req.Gift == nil || *req.Gift {
+		t.Errorf("Gift = %v, want false 포인터", req.Gift)
+	}
+	if req.Note != nil {
+		t.Errorf("없는 포인터 필드 = %v, want nil", *req.Note)
+	}
+	if !req.Agree {
+		t.Error("Agree = false")
+	}
+	if req.Address.City != "서울" || req.Address.Zip != "12345" {
+		t.Errorf("Address = %+v", req.Address)
+	}
+	if len(req.Tags) != 2 || req.Tags[1] != "b" {
+		t.Errorf("Tags = %v", req.Tags)
+	}
+	if len(req.Items) != 1 || req.Items[0].Name != "책" || req.Items[0].Count != 2 {
+		t.Errorf("Items = %+v", req.Items)
+	}
+	if req.DeliverAt == nil || !req.DeliverAt.Equal(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DeliverAt = %v", req.DeliverAt)
+	}
+	if !req.CreatedAt.Equal(time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("CreatedAt = %v", req.CreatedAt)
+	}
+	if req.Internal != "" {
+		t.Errorf(`json:"-" 필드가 채워짐: %q`, req.Internal)
+	}
+}
+
+func TestBindJSONErrors(t *testing.T) {
+	body := `{
+		"name": "가",
+		"quantity": "many",
+		"price": 2000,
+		"note": "메모입니다",
+		"address": {"zip": "12-34"},
+		"tags": ["a", "b", "toolong"],
+		"items": [{"name": "책", "count": 1}, {"count": 2}],
+		"deliver_at": "17/10/2026"
+	}`
+	c := newContext(http.MethodPost, "/orders", "application/json", body, nil)
+
+	var req orderRequest
+	result := validator.Bind(c, &req)
+	if result.Valid {
+		t.Fatal("잘못된 값이 통과함")
+	}
+
+	expectCode(t, result, "name", "MIN_LENGTH")
+	expectCode(t, result, "quantity", "INVALID_TYPE")
+	expectCode(t, result, "price", "MAX_VALUE")
+	expectCode(t, result, "note", "MAX_LENGTH")
+	expectCode(t, result, "address.city", "REQUIRED")
+	expectCode(t, result, "address.zip", "INVALID_FORMAT")
+	expectCode(t, result, "tags[2]", "MAX_LENGTH")
+	expectCode(t, result, "items[1].name", "REQUIRED")
+	expectCode(t, result, "items[1].count", "")
+	expectCode(t, result, "deliver_at", "INVALID_TYPE")
+}
+
+func TestBindInvalidBody(t *testing.T) {
+	c := newContext(http.MethodPost, "/orders", "application/json", `{"name":`, nil)
+
+	var req orderRequest
+	result := validator.Bind(c, &req)
+	expectCode(t, result, "body", "INVALID_BODY")
+}
+
+func TestBindFormAndQuery(t *testing.T) {
+	form := url.Values{
+		"name":         {"홍길동"},
+		"quantity":     {"7"},
+		"gift":         {"on"},
+		"agree":        {"true"},
+		"address.city": {"부산"},
+		"tags":         {"x", "y"},
+	}
+	c := newContext(http.MethodPost, "/orders?price=10.25&quantity=1", "application/x-www-form-urlencoded", form.Encode(), nil)
+
+	var req orderRequest
+	result := validator.Bind(c, &req)
+	if !result.Valid {
+		t.Fatalf("검증 실패: %v", result.Errors)
+	}
+
+	// 폼이 쿼리보다 우선
+	if req.Quantity != 7 || req.Price != 10.25 {
+		t.Errorf("숫자 = %d, %v", req.Quantity, req.Price)
+	}
+	if req.Gift == nil || !*req.Gift || !req.Agree {
+		t.Errorf("bool = %v, %v", req.Gift, req.Agree)
+	}
+	if req.Address.City != "부산" {
+		t.Errorf("중첩 폼 키 = %q", req.Address.City)
+	}
+	if len(req.Tags) != 2 || req.Tags[0] != "x" {
+		t.Errorf("Tags = %v", req.Tags)
+	}
+}
+
+func TestBindURLParamWins(t *testing.T) {
+	params := gin.Params{{Key: "id", Value: "order-1"}}
+
+	// 바디와 쿼리에 같은 키가 있어도 라우트 값이 유지된다
+	c := newContext(http.MethodPut, "/orders/order-1?id=query", "application/json",
+		`{"id": "body", "name": "홍길동", "quantity": 1, "address": {"city": "서울"}}`, params)
+
+	var req orderRequest
+	if result := validator.Bind(c, &req); !result.Valid {
+		t.Fatalf("검증 실패: %v", result.Errors)
+	}
+	if req.ID != "order-1" {
+		t.Errorf("ID = %q, want URL 파라미터 order-1", req.ID)
+	}
+}
+
+func TestBindRequiresStructPointer(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("구조체가 아닌 값에 panic하지 않음")
+		}
+	}()
+
+	c := newContext(http.MethodGet, "/", "", "", nil)
+	var s string
+	validator.Bind(c, &s)
+}
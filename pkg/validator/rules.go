@@ -0,0 +1,322 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FieldValue 태그 규칙에 넘기는 필드 정보
+type FieldValue struct {
+	Name  string        // 요청 필드 이름 (중첩이면 address.city, 목록이면 items[0])
+	Label string        // 사용자에게 표시할 이름
+	Value reflect.Value // 필드 값 (포인터는 푼 값)
+	Param string        // 규칙 인자 (min=3이면 "3")
+}
+
+// RuleFunc 태그 규칙 검증 함수 (통과하면 nil, 실패하면 에러 코드와 메시지)
+// 빈 값에는 호출되지 않는다 (required만 빈 값을 확인)
+type RuleFunc func(f FieldValue) *ValidationError
+
+var (
+	registryMu sync.RWMutex
+	rules      = map[string]RuleFunc{
+		"min":     ruleMin,
+		"max":     ruleMax,
+		"pattern": rulePattern,
+		"oneof":   ruleOneOf,
+	}
+	patterns = map[string]*regexp.Regexp{
+		"number":    PatternNumber,
+		"decimal":   PatternDecimal,
+		"english":   PatternEnglish,
+		"korean":    PatternKorean,
+		"koreng":    PatternKorEng,
+		"korengnum": PatternKorEngNum,
+		"email":     PatternEmail,
+		"alphanum":  PatternAlphaNum,
+		"slug":      PatternSlug,
+		"url":       PatternURL,
+		"phone":     PatternPhone,
+	}
+)
+
+// RegisterRule 태그 규칙 등록 (validate:"name" 또는 validate:"name=param")
+// 서버 시작 전에 등록한다. 같은 이름이 있으면 바꾼다 (required, dive는 바꿀 수 없음)
+func RegisterRule(name string, fn RuleFunc) {
+	if name == "required" || name == "dive" || fn == nil {
+		panic(fmt.Sprintf("validator: %q 규칙은 등록할 수 없습니다", name))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	rules[name] = fn
+}
+
+// RegisterPattern pattern 규칙에서 쓸 이름 있는 패턴 등록 (validate:"pattern=name")
+func RegisterPattern(name string, pattern *regexp.Regexp) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	patterns[name] = pattern
+}
+
+func lookupRule(name string) RuleFunc {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	fn, ok := rules[name]
+	if !ok {
+		panic(fmt.Sprintf("validator: 알 수 없는 규칙입니다: %q", name))
+	}
+	return fn
+}
+
+// ValidateStruct 채워진 구조체를 validate 태그로 검증 (Bind 없이 서비스 등에서 사용)
+func ValidateStruct(v interface{}) *Result {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validator: ValidateStruct에는 구조체가 필요합니다 (%T)", v))
+	}
+
+	result := newResult()
+	validateStruct(result, rv, "")
+	return result
+}
+
+// tagRule validate 태그의 규칙 하나
+type tagRule struct {
+	name  string
+	param string
+}
+
+// field 구조체 필드의 요청 이름, 표시 이름, 규칙 (타입별로 한 번만 해석)
+type field struct {
+	index      []int
+	name       string
+	label      string
+	timeFormat string
+	embedded   bool // json 태그 없는 임베드 구조체 (필드를 펼침)
+	required   bool
+	rules      []tagRule // required, dive 앞의 규칙
+	dive       []tagRule // dive 뒤의 규칙 (목록의 각 항목에 적용)
+}
+
+var fieldCache sync.Map // reflect.Type -> []field
+
+// structFields 구조체 필드 정보 (내보내지 않은 필드, json:"-"는 제외)
+func structFields(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, field{index: sf.Index, embedded: true})
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+		f := field{
+			index:      sf.Index,
+			name:       name,
+			label:      sf.Tag.Get("label"),
+			timeFormat: sf.Tag.Get("time_format"),
+		}
+		if f.label == "" {
+			f.label = name
+		}
+		f.required, f.rules, f.dive = parseTag(sf.Tag.Get("validate"))
+		fields = append(fields, f)
+	}
+
+	cached, _ := fieldCache.LoadOrStore(t, fields)
+	return cached.([]field)
+}
+
+// parseTag "required,min=3,dive,max=10" 해석
+func parseTag(tag string) (required bool, own []tagRule, dive []tagRule) {
+	if tag == "" {
+		return false, nil, nil
+	}
+
+	target := &own
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch name {
+		case "":
+			continue
+		case "required":
+			if target == &own {
+				required = true
+			} else {
+				dive = append(dive, tagRule{name: name})
+			}
+		case "dive":
+			target = &dive
+		default:
+			*target = append(*target, tagRule{name: name, param: param})
+		}
+	}
+	return required, own, dive
+}
+
+// validateStruct 구조체 필드 검증 (중첩 구조체, 구조체 목록은 안으로 들어가서 검증)
+func validateStruct(r *Result, v reflect.Value, prefix string) {
+	for _, f := range structFields(v.Type()) {
+		fv := v.FieldByIndex(f.index)
+		if f.embedded {
+			validateStruct(r, fv, prefix)
+			continue
+		}
+
+		name := joinName(prefix, f.name)
+		if _, failed := r.Errors[name]; failed {
+			continue // 형식 오류가 이미 있음
+		}
+
+		fv, ok := validateValue(r, fv, name, f.label, f.required, f.rules)
+		if !ok || !fv.IsValid() {
+			continue
+		}
+
+		switch {
+		case fv.Kind() == reflect.Struct && fv.Type() != timeType:
+			validateStruct(r, fv, name)
+		case fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array:
+			for i := 0; i < fv.Len(); i++ {
+				elemName := fmt.Sprintf("%s[%d]", name, i)
+				required := len(f.dive) > 0 && f.dive[0].name == "required"
+				elemRules := f.dive
+				if required {
+					elemRules = f.dive[1:]
+				}
+				elem, ok := validateValue(r, fv.Index(i), elemName, f.label, required, elemRules)
+				if !ok || !elem.IsValid() {
+					continue
+				}
+				if elem.Kind() == reflect.Struct && elem.Type() != timeType {
+					validateStruct(r, elem, elemName)
+				}
+			}
+		}
+	}
+}
+
+// validateValue 값 하나에 규칙 적용 (첫 번째 실패만 기록). 포인터를 푼 값과 통과 여부 반환
+func validateValue(r *Result, v reflect.Value, name, label string, required bool, tagRules []tagRule) (reflect.Value, bool) {
+	// 포인터는 nil만 빈 값 (0, false를 가리켜도 값이 있음)
+	empty := !v.IsValid() || v.IsZero()
+	v = indirect(v)
+	if empty {
+		if required {
+			r.addError(name, label, "REQUIRED", fmt.Sprintf("%s은(는) 필수 항목입니다", label))
+			return v, false
+		}
+		return v, true
+	}
+
+	for _, rule := range tagRules {
+		fn := lookupRule(rule.name)
+		if err := fn(FieldValue{Name: name, Label: label, Value: v, Param: rule.param}); err != nil {
+			r.addError(name, label, err.Code, err.Message)
+			return v, false
+		}
+	}
+	return v, true
+}
+
+// indirect 포인터를 풀어서 값으로 (nil이면 유효하지 않은 값)
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// ruleMin 문자열은 글자 수, 목록은 항목 수, 숫자는 값의 최소
+func ruleMin(f FieldValue) *ValidationError {
+	return compare(f, -1)
+}
+
+// ruleMax 문자열은 글자 수, 목록은 항목 수, 숫자는 값의 최대
+func ruleMax(f FieldValue) *ValidationError {
+	return compare(f, 1)
+}
+
+// compare min(sign=-1), max(sign=1) 공통
+func compare(f FieldValue, sign int) *ValidationError {
+	limit, err := strconv.ParseFloat(f.Param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validator: %s의 min/max 인자가 숫자가 아닙니다: %q", f.Name, f.Param))
+	}
+
+	var n float64
+	var code, unit string
+	switch f.Value.Kind() {
+	case reflect.String:
+		n, code, unit = float64(utf8.RuneCountInString(f.Value.String())), "LENGTH", "자"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n, code, unit = float64(f.Value.Len()), "ITEMS", "개"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, code = float64(f.Value.Int()), "VALUE"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, code = float64(f.Value.Uint()), "VALUE"
+	case reflect.Float32, reflect.Float64:
+		n, code = f.Value.Float(), "VALUE"
+	default:
+		panic(fmt.Sprintf("validator: %s(%s)에는 min/max를 쓸 수 없습니다", f.Name, f.Value.Type()))
+	}
+
+	if sign < 0 && n < limit {
+		return &ValidationError{Code: "MIN_" + code, Message: fmt.Sprintf("%s은(는) 최소 %s%s 이상이어야 합니다", f.Label, f.Param, unit)}
+	}
+	if sign > 0 && n > limit {
+		return &ValidationError{Code: "MAX_" + code, Message: fmt.Sprintf("%s은(는) 최대 %s%s 이하여야 합니다", f.Label, f.Param, unit)}
+	}
+	return nil
+}
+
+// rulePattern 이름 있는 패턴과 일치 (pattern=alphanum)
+func rulePattern(f FieldValue) *ValidationError {
+	registryMu.RLock()
+	pattern, ok := patterns[f.Param]
+	registryMu.RUnlock()
+	if !ok {
+		panic(fmt.Sprintf("validator: 알 수 없는 패턴입니다: %q", f.Param))
+	}
+
+	if !pattern.MatchString(fmt.Sprint(f.Value.Interface())) {
+		return &ValidationError{Code: "INVALID_FORMAT", Message: fmt.Sprintf("%s의 형식이 올바르지 않습니다", f.Label)}
+	}
+	return nil
+}
+
+// ruleOneOf 공백으로 구분한 값 중 하나 (oneof=asc desc)
+func ruleOneOf(f FieldValue) *ValidationError {
+	value := fmt.Sprint(f.Value.Interface())
+	for _, allowed := range strings.Fields(f.Param) {
+		if value == allowed {
+			return nil
+		}
+	}
+	return &ValidationError{Code: "INVALID_VALUE", Message: fmt.Sprintf("%s은(는) %s 중 하나여야 합니다", f.Label, strings.Join(strings.Fields(f.Param), ", "))}
+}
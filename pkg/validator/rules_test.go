@@ -0,0 +1,153 @@
+package validator_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"gin_starter/pkg/validator"
+)
+
+func TestMinMaxCountsRunes(t *testing.T) {
+	type profile struct {
+		Name string `json:"name" label:"이름" validate:"min=2,max=4"`
+	}
+
+	cases := []struct {
+		name string
+		code string
+	}{
+		{"김", "MIN_LENGTH"},
+		{"김철수", ""},
+		{"남궁민수", ""},            // 4글자, 12바이트
+		{"독고영재님", "MAX_LENGTH"}, // 5글자
+		{"abcd", ""},
+		{"abcde", "MAX_LENGTH"},
+	}
+	for _, tc := range cases {
+		result := validator.ValidateStruct(&profile{Name: tc.name})
+		expectCode(t, result, "name", tc.code)
+	}
+}
+
+func TestMinMaxNumbersAndItems(t *testing.T) {
+	type limits struct {
+		Age   int      `json:"age" label:"나이" validate:"min=14,max=120"`
+		Score uint     `json:"score" label:"점수" validate:"max=100"`
+		Rate  float64  `json:"rate" label:"비율" validate:"min=0.5"`
+		Tags  []string `json:"tags" label:"태그" validate:"min=2,max=3"`
+	}
+
+	result := validator.ValidateStruct(&limits{Age: 13, Score: 101, Rate: 0.25, Tags: []string{"a"}})
+	expectCode(t, result, "age", "MIN_VALUE")
+	expectCode(t, result, "score", "MAX_VALUE")
+	expectCode(t, result, "rate", "MIN_VALUE")
+	expectCode(t, result, "tags", "MIN_ITEMS")
+
+	result = validator.ValidateStruct(&limits{Age: 30, Score: 100, Rate: 0.5, Tags: []string{"a", "b", "c", "d"}})
+	expectCode(t, result, "age", "")
+	expectCode(t, result, "score", "")
+	expectCode(t, result, "rate", "")
+	expectCode(t, result, "tags", "MAX_ITEMS")
+}
+
+func TestRequiredAndPointers(t *testing.T) {
+	type flags struct {
+		Count  int   `json:"count" label:"개수" validate:"required"`
+		Zero   *int  `json:"zero" label:"0 허용" validate:"required"`
+		Enable *bool `json:"enable" label:"사용" validate:"required"`
+	}
+
+	zero, off := 0, false
+	result := validator.ValidateStruct(&flags{Zero: &zero, Enable: &off})
+	expectCode(t, result, "count", "REQUIRED")
+	expectCode(t, result, "zero", "") // 포인터면 0, false도 값이 있음
+	expectCode(t, result, "enable", "")
+
+	result = validator.ValidateStruct(&flags{Count: 1})
+	expectCode(t, result, "zero", "REQUIRED")
+	expectCode(t, result, "enable", "REQUIRED")
+}
+
+func TestOneOfAndPattern(t *testing.T) {
+	type query struct {
+		Sort  string `json:"sort" label:"정렬" validate:"oneof=asc desc"`
+		Email string `json:"email" label:"이메일" validate:"pattern=email"`
+	}
+
+	result := validator.ValidateStruct(&query{Sort: "up", Email: "not-an-email"})
+	expectCode(t, result, "sort", "INVALID_VALUE")
+	expectCode(t, result, "email", "INVALID_FORMAT")
+
+	result = validator.ValidateStruct(&query{Sort: "desc", Email: "user@example.com"})
+	if !result.Valid {
+		t.Errorf("검증 실패: %v", result.Errors)
+	}
+
+	// 빈 값은 required가 없으면 건너뜀
+	if result := validator.ValidateStruct(&query{}); !result.Valid {
+		t.Errorf("빈 값 검증 실패: %v", result.Errors)
+	}
+}
+
+func TestRegisterRule(t *testing.T) {
+	validator.RegisterRule("even", func(f validator.FieldValue) *validator.ValidationError {
+		if f.Value.Int()%2 != 0 {
+			return &validator.ValidationError{Code: "NOT_EVEN", Message: f.Label + "은(는) 짝수여야 합니다"}
+		}
+		return nil
+	})
+	validator.RegisterRule("prefix", func(f validator.FieldValue) *validator.ValidationError {
+		if !strings.HasPrefix(f.Value.String(), f.Param) {
+			return &validator.ValidationError{Code: "INVALID_PREFIX", Message: f.Label + "은(는) " + f.Param + "로 시작해야 합니다"}
+		}
+		return nil
+	})
+	validator.RegisterPattern("sku", regexp.MustCompile(`^[A-Z]{3}-[0-9]{4}$`))
+
+	type product struct {
+		Pairs int      `json:"pairs" label:"쌍" validate:"even"`
+		Code  string   `json:"code" label:"코드" validate:"prefix=P-"`
+		SKUs  []string `json:"skus" label:"SKU" validate:"dive,pattern=sku"`
+	}
+
+	result := validator.ValidateStruct(&product{Pairs: 3, Code: "X-1", SKUs: []string{"ABC-1234", "abc"}})
+	expectCode(t, result, "pairs", "NOT_EVEN")
+	expectCode(t, result, "code", "INVALID_PREFIX")
+	expectCode(t, result, "skus[0]", "")
+	expectCode(t, result, "skus[1]", "INVALID_FORMAT")
+	if msg := result.Errors["pairs"].Message; msg != "쌍은(는) 짝수여야 합니다" {
+		t.Errorf("메시지 = %q", msg)
+	}
+
+	result = validator.ValidateStruct(&product{Pairs: 4, Code: "P-1", SKUs: []string{"XYZ-0001"}})
+	if !result.Valid {
+		t.Errorf("검증 실패: %v", result.Errors)
+	}
+}
+
+func TestRegisterRuleReserved(t *testing.T) {
+	for _, name := range []string{"required", "dive"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s 규칙 등록이 허용됨", name)
+				}
+			}()
+			validator.RegisterRule(name, func(validator.FieldValue) *validator.ValidationError { return nil })
+		}()
+	}
+}
+
+func TestUnknownRulePanics(t *testing.T) {
+	type bad struct {
+		Name string `json:"name" validate:"no_such_rule"`
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("알 수 없는 규칙에 panic하지 않음")
+		}
+	}()
+	validator.ValidateStruct(&bad{Name: "x"})
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,8 +15,8 @@ type Rule struct {
 	Field    string          // 필드명
 	Label    string          // 사용자에게 표시할 이름
 	Required bool            // 필수 여부
-	MinLen   int             // 최소 길이
-	MaxLen   int             // 최대 길이
+	MinLen   int             // 최소 길이 (글자 수)
+	MaxLen   int             // 최대 길이 (글자 수)
 	Min      float64         // 최소값 (숫자)
 	Max      float64         // 최대값 (숫자)
 	Pattern  *regexp.Regexp  // 허용 패턴 (화이트리스트)
@@ -36,7 +37,15 @@ type ValidationError struct {
 type Result struct {
 	Valid  bool
 	Errors map[string]ValidationError
-	Values map[string]string
+	Values map[string]string // 검증을 통과한 값 (Validate만, Bind는 구조체에 채움)
+}
+
+func newResult() *Result {
+	return &Result{
+		Valid:  true,
+		Errors: make(map[string]ValidationError),
+		Values: make(map[string]string),
+	}
 }
 
 // 미리 정의된 패턴들
@@ -56,11 +65,7 @@ var (
 
 // Validate 검증 실행
 func Validate(c *gin.Context, rules []Rule) *Result {
-	result := &Result{
-		Valid:  true,
-		Errors: make(map[string]ValidationError),
-		Values: make(map[string]string),
-	}
+	result := newResult()
 
 	for _, rule := range rules {
 		value := extractValue(c, rule.Field)
@@ -78,14 +83,15 @@ func Validate(c *gin.Context, rules []Rule) *Result {
 			continue
 		}
 
-		// 길이 검증
-		if rule.MinLen > 0 && len(value) < rule.MinLen {
+		// 길이 검증 (바이트가 아닌 글자 수)
+		length := utf8.RuneCountInString(value)
+		if rule.MinLen > 0 && length < rule.MinLen {
 			result.addError(rule.Field, rule.Label, "MIN_LENGTH",
 				fmt.Sprintf("%s은(는) 최소 %d자 이상이어야 합니다", rule.Label, rule.MinLen))
 			continue
 		}
 
-		if rule.MaxLen > 0 && len(value) > rule.MaxLen {
+		if rule.MaxLen > 0 && length > rule.MaxLen {
 			result.addError(rule.Field, rule.Label, "MAX_LENGTH",
 				fmt.Sprintf("%s은(는) 최대 %d자 이하여야 합니다", rule.Label, rule.MaxLen))
 			continue
@@ -1,9 +1,16 @@
+-- 전체 스키마 참고용 스냅샷 (MySQL)
+-- migrations/mysql의 모든 마이그레이션을 적용한 결과와 같으며, 마이그레이션을 대신해 한 번에 테이블을 만들 때만 사용한다.
+-- 이 파일로 만든 DB에는 _schema_migrations 기록이 없으므로 cmd/migrate up을 함께 쓰면 이미 있는 테이블/컬럼에서 실패한다.
+-- 마이그레이션으로 관리할 DB는 빈 DB에서 go run ./cmd/migrate up 으로만 만든다.
+
 
 CREATE TABLE `_a_error_logs` (
 	`el_where` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
 	`el_message` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
 	`el_sql` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
-	`el_regi_date` DATETIME NULL DEFAULT (now())
+	`el_request_id` VARCHAR(128) NULL DEFAULT NULL COMMENT '요청 ID' COLLATE 'utf8mb4_general_ci',
+	`el_regi_date` DATETIME NULL DEFAULT (now()),
+	INDEX `idx_el_request_id` (`el_request_id`) USING BTREE
 )
 COMMENT='에러로그'
 COLLATE='utf8mb4_general_ci'
@@ -17,8 +24,14 @@ CREATE TABLE `_user` (
 	`u_auth_type` VARCHAR(10) NULL DEFAULT 'U' COLLATE 'utf8mb4_general_ci',
 	`u_auth_level` INT(10) NULL DEFAULT '0',
 	`u_email` VARCHAR(100) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`u_email_verified_at` DATETIME NULL DEFAULT NULL COMMENT '이메일 인증 시각',
 	`u_name` VARCHAR(50) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
-	`u_re_token` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`u_login_fails` INT(10) NOT NULL DEFAULT '0' COMMENT '연속 로그인 실패 횟수',
+	`u_login_fail_at` DATETIME NULL DEFAULT NULL COMMENT '마지막 로그인 실패 시각',
+	`u_locked_until` DATETIME NULL DEFAULT NULL COMMENT '로그인 잠금 해제 시각',
+	`u_mfa_secret` VARCHAR(255) NULL DEFAULT NULL COMMENT 'TOTP 시크릿 (암호화)' COLLATE 'utf8mb4_general_ci',
+	`u_mfa_enabled_at` DATETIME NULL DEFAULT NULL COMMENT '2단계 인증 사용 시작 시각',
+	`u_mfa_last_step` BIGINT NOT NULL DEFAULT '0' COMMENT '마지막으로 사용한 TOTP 구간 (재사용 방지)',
 	`u_memo` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
 	`u_regi_date` DATETIME NULL DEFAULT (now()),
 	PRIMARY KEY (`u_idx`) USING BTREE,
@@ -28,6 +41,246 @@ COLLATE='utf8mb4_general_ci'
 ENGINE=InnoDB
 ;
 
+CREATE TABLE `_login_lockouts` (
+	`ll_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`ll_scope` VARCHAR(10) NOT NULL COMMENT 'account, ip' COLLATE 'utf8mb4_general_ci',
+	`ll_user_id` VARCHAR(50) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`ll_ip` VARCHAR(45) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`ll_fail_count` INT(10) NOT NULL DEFAULT '0',
+	`ll_locked_until` DATETIME NULL DEFAULT NULL,
+	`ll_unlocked_by` VARCHAR(50) NULL DEFAULT NULL COMMENT '잠금을 해제한 관리자' COLLATE 'utf8mb4_general_ci',
+	`ll_unlocked_at` DATETIME NULL DEFAULT NULL,
+	`ll_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ll_idx`) USING BTREE,
+	INDEX `idx_ll_user_id` (`ll_user_id`) USING BTREE,
+	INDEX `idx_ll_ip` (`ll_ip`) USING BTREE
+)
+COMMENT='로그인 잠금 기록'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_user_sessions` (
+	`us_id` VARCHAR(32) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`us_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`us_token_hash` VARCHAR(64) NOT NULL COMMENT '현재 리프레시 토큰 SHA-256' COLLATE 'utf8mb4_general_ci',
+	`us_user_agent` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`us_ip` VARCHAR(45) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`us_last_used` DATETIME NULL DEFAULT NULL COMMENT '마지막 토큰 갱신 시각',
+	`us_expires_at` DATETIME NOT NULL,
+	`us_revoked_at` DATETIME NULL DEFAULT NULL,
+	`us_revoke_reason` VARCHAR(20) NULL DEFAULT NULL COMMENT 'logout, reuse, user, admin' COLLATE 'utf8mb4_general_ci',
+	`us_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`us_id`) USING BTREE,
+	INDEX `idx_us_user_id` (`us_user_id`) USING BTREE
+)
+COMMENT='로그인 세션'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_token_revocations` (
+	`tr_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`tr_kind` VARCHAR(10) NOT NULL COMMENT 'token, session, user' COLLATE 'utf8mb4_general_ci',
+	`tr_subject` VARCHAR(50) NOT NULL COMMENT 'jti, 세션 ID, 사용자 ID' COLLATE 'utf8mb4_general_ci',
+	`tr_revoked_at` DATETIME NOT NULL COMMENT '이 시각 이전에 발급된 토큰 거부',
+	`tr_expires_at` DATETIME NOT NULL COMMENT '대상 토큰이 모두 만료되는 시각',
+	PRIMARY KEY (`tr_idx`) USING BTREE,
+	INDEX `idx_tr_revoked_at` (`tr_revoked_at`) USING BTREE,
+	INDEX `idx_tr_expires_at` (`tr_expires_at`) USING BTREE
+)
+COMMENT='액세스 토큰 폐기 기록'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_user_tokens` (
+	`ut_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`ut_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ut_purpose` VARCHAR(10) NOT NULL COMMENT 'verify, reset' COLLATE 'utf8mb4_general_ci',
+	`ut_token_hash` VARCHAR(64) NOT NULL COMMENT '토큰 SHA-256' COLLATE 'utf8mb4_general_ci',
+	`ut_email` VARCHAR(100) NULL DEFAULT NULL COMMENT '인증 대상 이메일 (verify)' COLLATE 'utf8mb4_general_ci',
+	`ut_expires_at` DATETIME NOT NULL,
+	`ut_used_at` DATETIME NULL DEFAULT NULL,
+	`ut_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ut_idx`) USING BTREE,
+	UNIQUE INDEX `idx_ut_token_hash` (`ut_token_hash`) USING BTREE,
+	INDEX `idx_ut_user_id` (`ut_user_id`) USING BTREE
+)
+COMMENT='이메일 인증, 비밀번호 재설정 토큰'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_user_recovery_codes` (
+	`rc_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`rc_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`rc_code_hash` VARCHAR(64) NOT NULL COMMENT '복구 코드 SHA-256' COLLATE 'utf8mb4_general_ci',
+	`rc_used_at` DATETIME NULL DEFAULT NULL,
+	`rc_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`rc_idx`) USING BTREE,
+	INDEX `idx_rc_user_id` (`rc_user_id`) USING BTREE
+)
+COMMENT='2단계 인증 복구 코드'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_user_password_history` (
+	`ph_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`ph_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ph_hash` TEXT NOT NULL COMMENT '비밀번호 해시' COLLATE 'utf8mb4_general_ci',
+	`ph_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ph_idx`) USING BTREE,
+	INDEX `idx_ph_user_id` (`ph_user_id`, `ph_idx`) USING BTREE
+)
+COMMENT='비밀번호 변경 이력'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_roles` (
+	`r_code` VARCHAR(10) NOT NULL COMMENT '역할 코드 (u_auth_type과 같은 값)' COLLATE 'utf8mb4_general_ci',
+	`r_name` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`r_description` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`r_system` TINYINT(1) NOT NULL DEFAULT '0' COMMENT '기본 역할 (삭제 불가)',
+	`r_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`r_code`) USING BTREE
+)
+COMMENT='역할'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_permissions` (
+	`p_code` VARCHAR(100) NOT NULL COMMENT '권한 (리소스:동작[:범위])' COLLATE 'utf8mb4_general_ci',
+	`p_description` VARCHAR(255) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`p_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`p_code`) USING BTREE
+)
+COMMENT='권한 목록'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_role_permissions` (
+	`rp_role` VARCHAR(10) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`rp_permission` VARCHAR(100) NOT NULL COMMENT '권한 또는 와일드카드 (*, blog:*)' COLLATE 'utf8mb4_general_ci',
+	`rp_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`rp_role`, `rp_permission`) USING BTREE
+)
+COMMENT='역할별 권한'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_user_roles` (
+	`ur_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ur_role` VARCHAR(10) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ur_granted_by` VARCHAR(50) NULL DEFAULT NULL COMMENT '부여한 관리자 ID' COLLATE 'utf8mb4_general_ci',
+	`ur_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ur_user_id`, `ur_role`) USING BTREE,
+	INDEX `idx_ur_role` (`ur_role`) USING BTREE
+)
+COMMENT='사용자 역할'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+INSERT INTO `_roles` (`r_code`, `r_name`, `r_description`, `r_system`) VALUES
+	('U', '일반 사용자', '가입 시 기본 역할', 1),
+	('A', '관리자', '모든 권한', 1),
+	('M', '매니저', '관리자 페이지, 사용자 조회, 모든 블로그 관리', 0),
+	('AG', '에이전트', '관리자 페이지, 사용자 조회', 0);
+
+INSERT INTO `_permissions` (`p_code`, `p_description`) VALUES
+	('admin:access', '관리자 API 접근'),
+	('user:read', '사용자, 세션, 로그인 잠금 기록 조회'),
+	('user:manage', '사용자 권한 변경, 잠금 해제, 세션 종료, 2단계 인증 초기화'),
+	('user:delete', '사용자 삭제'),
+	('role:manage', '역할과 권한 관리, 사용자 역할 부여'),
+	('stats:read', '통계 조회'),
+	('blog:create', '블로그 작성'),
+	('blog:update:own', '본인 블로그 수정'),
+	('blog:delete:own', '본인 블로그 삭제'),
+	('blog:update:any', '모든 블로그 수정'),
+	('blog:delete:any', '모든 블로그 삭제'),
+	('apikey:create', '본인 API 키 발급');
+
+INSERT INTO `_role_permissions` (`rp_role`, `rp_permission`) VALUES
+	('U', 'blog:create'),
+	('U', 'blog:update:own'),
+	('U', 'blog:delete:own'),
+	('U', 'apikey:create'),
+	('A', '*'),
+	('M', 'admin:access'),
+	('M', 'user:read'),
+	('M', 'stats:read'),
+	('M', 'blog:*'),
+	('M', 'apikey:create'),
+	('AG', 'admin:access'),
+	('AG', 'user:read'),
+	('AG', 'blog:create'),
+	('AG', 'blog:update:own'),
+	('AG', 'blog:delete:own'),
+	('AG', 'apikey:create');
+
+CREATE TABLE `_api_keys` (
+	`ak_id` VARCHAR(32) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ak_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ak_name` VARCHAR(100) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ak_prefix` VARCHAR(20) NOT NULL COMMENT '키 식별용 접두사 (gsk_ + 12자)' COLLATE 'utf8mb4_general_ci',
+	`ak_key_hash` VARCHAR(64) NOT NULL COMMENT '키 원문 SHA-256' COLLATE 'utf8mb4_general_ci',
+	`ak_scopes` TEXT NOT NULL COMMENT '사용 범위 권한 (쉼표 구분, 와일드카드 포함)' COLLATE 'utf8mb4_general_ci',
+	`ak_allow_ips` TEXT NULL DEFAULT NULL COMMENT '허용 IP/CIDR (쉼표 구분, NULL이면 제한 없음)' COLLATE 'utf8mb4_general_ci',
+	`ak_expires_at` DATETIME NULL DEFAULT NULL COMMENT 'NULL이면 만료 없음',
+	`ak_last_used` DATETIME NULL DEFAULT NULL,
+	`ak_last_ip` VARCHAR(45) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`ak_revoked_at` DATETIME NULL DEFAULT NULL,
+	`ak_revoked_by` VARCHAR(50) NULL DEFAULT NULL COMMENT '폐기한 사용자 또는 관리자 ID' COLLATE 'utf8mb4_general_ci',
+	`ak_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ak_id`) USING BTREE,
+	UNIQUE INDEX `uk_ak_prefix` (`ak_prefix`) USING BTREE,
+	INDEX `idx_ak_user_id` (`ak_user_id`) USING BTREE
+)
+COMMENT='API 키'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_user_identities` (
+	`ui_idx` BIGINT NOT NULL AUTO_INCREMENT,
+	`ui_user_id` VARCHAR(50) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`ui_provider` VARCHAR(20) NOT NULL COMMENT 'OAUTH_PROVIDERS의 이름' COLLATE 'utf8mb4_general_ci',
+	`ui_subject` VARCHAR(255) NOT NULL COMMENT '제공자의 사용자 ID (sub)' COLLATE 'utf8mb4_general_ci',
+	`ui_email` VARCHAR(100) NULL DEFAULT NULL COMMENT '연결 당시 제공자 이메일' COLLATE 'utf8mb4_general_ci',
+	`ui_last_login` DATETIME NULL DEFAULT NULL,
+	`ui_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`ui_idx`) USING BTREE,
+	UNIQUE INDEX `uk_ui_provider_subject` (`ui_provider`, `ui_subject`) USING BTREE,
+	UNIQUE INDEX `uk_ui_user_provider` (`ui_user_id`, `ui_provider`) USING BTREE
+)
+COMMENT='소셜 로그인 연결 계정'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
+CREATE TABLE `_oauth_states` (
+	`os_state_hash` VARCHAR(64) NOT NULL COMMENT 'state SHA-256' COLLATE 'utf8mb4_general_ci',
+	`os_provider` VARCHAR(20) NOT NULL COLLATE 'utf8mb4_general_ci',
+	`os_verifier` VARCHAR(128) NOT NULL COMMENT 'PKCE code_verifier' COLLATE 'utf8mb4_general_ci',
+	`os_nonce` VARCHAR(64) NOT NULL COMMENT 'ID 토큰 nonce' COLLATE 'utf8mb4_general_ci',
+	`os_user_id` VARCHAR(50) NULL DEFAULT NULL COMMENT '계정 연결을 요청한 사용자 (NULL이면 로그인)' COLLATE 'utf8mb4_general_ci',
+	`os_expires_at` DATETIME NOT NULL,
+	`os_regi_date` DATETIME NULL DEFAULT (now()),
+	PRIMARY KEY (`os_state_hash`) USING BTREE,
+	INDEX `idx_os_expires_at` (`os_expires_at`) USING BTREE
+)
+COMMENT='소셜 로그인 진행 중 state (콜백에서 한 번 사용 후 삭제)'
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
+
 CREATE TABLE `_menu_groups` (
 	`mg_idx` INT NOT NULL AUTO_INCREMENT,
 	`mg_label` VARCHAR(100) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',